@@ -6,7 +6,10 @@ import (
 	"os"
 
 	"clx/bubble/list"
+	"clx/bubble/viewer"
 	"clx/favorites"
+	"clx/history"
+	"clx/hn"
 	"clx/settings"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -36,11 +39,38 @@ func (m model) View() string {
 }
 
 func Run(config *settings.Config) {
+	run(list.New(list.NewDefaultDelegate(), config, favorites.New(), 0, 0), config)
+}
+
+// RunSearch starts circumflex with the results of query instead of the front
+// page.
+func RunSearch(config *settings.Config, query hn.SearchQuery) {
+	l := list.New(list.NewDefaultDelegate(), config, favorites.New(), 0, 0)
+	l.SetSearch(query)
+
+	run(l, config)
+}
+
+// RunUser starts circumflex with the profile of name instead of the front
+// page.
+func RunUser(config *settings.Config, name string) {
+	l := list.New(list.NewDefaultDelegate(), config, favorites.New(), 0, 0)
+	l.SetUser(name)
+
+	run(l, config)
+}
+
+func run(l list.Model, config *settings.Config) {
 	cli.ClearScreen()
 
-	m := model{list: list.New(list.NewDefaultDelegate(), config, favorites.New(), 0, 0)}
+	m := model{list: l}
 
-	p := tea.NewProgram(m, tea.WithAltScreen())
+	options := []tea.ProgramOption{tea.WithAltScreen()}
+	if !config.DisableMouse {
+		options = append(options, tea.WithMouseCellMotion())
+	}
+
+	p := tea.NewProgram(m, options...)
 
 	_, err := p.Run()
 	if err != nil {
@@ -48,3 +78,46 @@ func Run(config *settings.Config) {
 		os.Exit(1)
 	}
 }
+
+type viewerModel struct {
+	viewer  viewer.Viewer
+	history history.History
+}
+
+func (m viewerModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m viewerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.viewer.SetSize(msg.Width, msg.Height)
+
+		return m, nil
+
+	case viewer.Closed:
+		if comments, ok := m.viewer.(*viewer.Comments); ok && m.history != nil {
+			m.history.SaveReadingPositionAndWriteToDisk(comments.StoryID(), comments.ReadingPosition())
+		}
+
+		return m, tea.Quit
+	}
+
+	return m, m.viewer.Update(msg)
+}
+
+func (m viewerModel) View() string {
+	return m.viewer.View()
+}
+
+// RunViewer shows v on its own, for 'clx view' and 'clx read'. Replying and
+// upvoting are only available from the main view. The reading position of a
+// comment section is saved to his when it is closed, unless his is nil.
+func RunViewer(v viewer.Viewer, his history.History) {
+	p := tea.NewProgram(viewerModel{viewer: v, history: his}, tea.WithAltScreen())
+
+	if _, err := p.Run(); err != nil {
+		fmt.Println("Error running program:", err)
+		os.Exit(1)
+	}
+}
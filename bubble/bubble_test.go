@@ -0,0 +1,50 @@
+package bubble
+
+import (
+	"testing"
+
+	"clx/bubble/viewer"
+	"clx/history"
+	"clx/item"
+	"clx/settings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingHistory struct {
+	history.Mock
+
+	saved map[int]history.ReadingPosition
+}
+
+func (h *recordingHistory) SaveReadingPositionAndWriteToDisk(id int, position history.ReadingPosition) {
+	h.saved[id] = position
+}
+
+func TestViewerSavesReadingPosition(t *testing.T) {
+	t.Parallel()
+
+	story := &item.Item{
+		ID:   100,
+		User: "op",
+		Comments: []*item.Item{
+			{ID: 1, User: "alfa", Content: "<p>Lorem ipsum"},
+			{ID: 2, User: "beta", Content: "<p>Dolor sit amet"},
+		},
+	}
+
+	his := &recordingHistory{saved: make(map[int]history.ReadingPosition)}
+	m := viewerModel{viewer: viewer.NewComments(story, settings.Default(), 0, 80, 24), history: his}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	_, cmd := m.Update(viewer.Closed{})
+
+	assert.Equal(t, 2, his.saved[100].LastReadComment)
+	assert.NotNil(t, cmd)
+
+	// The pager of 'clx read' has no history
+	m = viewerModel{viewer: viewer.NewComments(story, settings.Default(), 0, 80, 24)}
+	_, cmd = m.Update(viewer.Closed{})
+	assert.NotNil(t, cmd)
+}
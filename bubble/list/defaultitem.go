@@ -10,6 +10,8 @@ import (
 	"clx/constants/category"
 	"clx/item"
 	"clx/syntax"
+	"clx/theme"
+	"clx/watchlist"
 
 	"github.com/nleeper/goment"
 
@@ -55,10 +57,10 @@ func NewDefaultItemStyles() (s DefaultItemStyles) {
 	s.MarkAsReadTitle = s.NormalTitle.Copy().Italic(true).Faint(true)
 	s.MarkAsReadDesc = s.NormalDesc.Copy()
 
-	s.SelectedTitleAddToFavorites = s.NormalTitle.Copy().Foreground(lipgloss.Color("2")).Reverse(true)
+	s.SelectedTitleAddToFavorites = s.NormalTitle.Copy().Foreground(theme.Current().Content.Green.Lipgloss()).Reverse(true)
 	s.SelectedDescAddToFavorites = s.NormalDesc.Copy()
 
-	s.SelectedTitleRemoveFromFavorites = s.NormalTitle.Copy().Foreground(lipgloss.Color("1")).Reverse(true)
+	s.SelectedTitleRemoveFromFavorites = s.NormalTitle.Copy().Foreground(theme.Current().Content.Red.Lipgloss()).Reverse(true)
 	s.SelectedDescRemoveFromFavoritesFavorites = s.NormalDesc.Copy()
 
 	s.DimmedTitle = lipgloss.NewStyle()
@@ -121,6 +123,7 @@ func (d DefaultDelegate) Render(w io.Writer, m Model, index int, item *item.Item
 	enableNerdFonts := m.config.EnableNerdFonts
 
 	title = item.Title
+	watched := m.config.Watchlist.Matching(item.Title)
 
 	domain = syntax.HighlightDomain(item.Domain)
 
@@ -152,27 +155,27 @@ func (d DefaultDelegate) Render(w io.Writer, m Model, index int, item *item.Item
 	switch {
 	case isSelected && m.onAddToFavoritesPrompt:
 		title, desc = styleTitleAndDesc(title, s.SelectedTitleAddToFavorites, s.SelectedDescAddToFavorites, domain,
-			desc, syntax.AddToFavorites, m.config.DisableHeadlineHighlighting, enableNerdFonts)
+			desc, syntax.AddToFavorites, watched, m.config.DisableHeadlineHighlighting, enableNerdFonts)
 
-	case isSelected && m.onRemoveFromFavoritesPrompt:
+	case isSelected && (m.onRemoveFromFavoritesPrompt || m.onMutePrompt):
 		title, desc = styleTitleAndDesc(title, s.SelectedTitleRemoveFromFavorites, s.SelectedDescRemoveFromFavoritesFavorites, domain,
-			desc, syntax.RemoveFromFavorites, m.config.DisableHeadlineHighlighting, enableNerdFonts)
+			desc, syntax.RemoveFromFavorites, watched, m.config.DisableHeadlineHighlighting, enableNerdFonts)
 
 	case isSelected && !m.disableInput:
 		title, desc = styleTitleAndDesc(title, s.SelectedTitle, s.SelectedDesc, domain,
-			desc, syntax.Selected, m.config.DisableHeadlineHighlighting, enableNerdFonts)
+			desc, syntax.Selected, watched, m.config.DisableHeadlineHighlighting, enableNerdFonts)
 
 	case markAsRead && m.category != category.Favorites:
 		title, desc = styleTitleAndDesc(title, s.MarkAsReadTitle.Italic(true), s.MarkAsReadDesc, domain,
-			desc, syntax.MarkAsRead, m.config.DisableHeadlineHighlighting, enableNerdFonts)
+			desc, syntax.MarkAsRead, watched, m.config.DisableHeadlineHighlighting, enableNerdFonts)
 
-	case m.disableInput && !(m.onAddToFavoritesPrompt || m.onRemoveFromFavoritesPrompt):
+	case m.disableInput && !(m.onAddToFavoritesPrompt || m.onRemoveFromFavoritesPrompt || m.onMutePrompt):
 		title, desc = styleTitleAndDesc(title, s.MarkAsReadTitle.Italic(false), s.MarkAsReadDesc, domain,
-			desc, syntax.MarkAsRead, m.config.DisableHeadlineHighlighting, enableNerdFonts)
+			desc, syntax.MarkAsRead, watched, m.config.DisableHeadlineHighlighting, enableNerdFonts)
 
 	default:
 		title, desc = styleTitleAndDesc(title, s.NormalTitle, s.NormalDesc, domain,
-			desc, syntax.Unselected, m.config.DisableHeadlineHighlighting, enableNerdFonts)
+			desc, syntax.Unselected, watched, m.config.DisableHeadlineHighlighting, enableNerdFonts)
 	}
 
 	if d.ShowDescription {
@@ -223,9 +226,10 @@ func getAuthor(author string, enableNerdFonts bool) string {
 }
 
 func styleTitleAndDesc(title string, titleStyle lipgloss.Style, descStyle lipgloss.Style, domain string, desc string,
-	syntaxStyle int, disableHeadlineHighlighting bool, enableNerdFont bool,
+	syntaxStyle int, watched watchlist.Watchlist, disableHeadlineHighlighting bool, enableNerdFont bool,
 ) (string, string) {
 	title = titleStyle.Render(title)
+	title = syntax.HighlightWatchlist(title, watched, syntaxStyle)
 
 	if !disableHeadlineHighlighting {
 		title = syntax.HighlightYCStartupsInHeadlines(title, syntaxStyle, enableNerdFont)
@@ -234,7 +238,7 @@ func styleTitleAndDesc(title string, titleStyle lipgloss.Style, descStyle lipglo
 		title = syntax.HighlightSpecialContent(title, syntaxStyle, enableNerdFont)
 	}
 
-	title = title + " " + domain
+	title = title + syntax.WatchlistLabels(watched, syntaxStyle, enableNerdFont) + " " + domain
 	desc = descStyle.Render(desc)
 
 	return title, desc
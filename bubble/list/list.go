@@ -1,9 +1,12 @@
 package list
 
 import (
+	"context"
 	_ "embed"
+	"errors"
 	"fmt"
 	"io"
+	"os"
 	"strconv"
 	"strings"
 	"time"
@@ -17,31 +20,40 @@ import (
 	"clx/browser"
 	"clx/bubble/list/message"
 	"clx/bubble/ranking"
+	"clx/bubble/viewer"
 	"clx/cli"
 	"clx/constants/category"
 	"clx/constants/style"
 	"clx/favorites"
+	"clx/file"
 	"clx/header"
 	"clx/help"
 	"clx/history"
 	"clx/hn"
-	"clx/hn/services/hybrid"
-	"clx/hn/services/mock"
+	"clx/hn/services"
+	"clx/hn/services/cache"
+	"clx/hn/services/muted"
 	"clx/item"
+	"clx/keymaps"
+	"clx/killfile"
+	"clx/meta"
 	"clx/screen"
 	"clx/settings"
+	"clx/theme"
 	"clx/tree"
+	"clx/utils/ints"
 	"clx/validator"
 
 	"github.com/charmbracelet/bubbles/paginator"
 	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
 
-const (
-	numberOfCategories = 5
-)
+// collapsedAboutLines is how many lines of the about text of a profile are
+// shown until it is expanded.
+const collapsedAboutLines = 3
 
 // Item is an item that appears in the list.
 // type Item interface{}
@@ -88,6 +100,7 @@ type Model struct {
 	isVisible                   bool
 	onAddToFavoritesPrompt      bool
 	onRemoveFromFavoritesPrompt bool
+	onMutePrompt                bool
 
 	StatusMessageLifetime time.Duration
 
@@ -102,21 +115,292 @@ type Model struct {
 	history   history.History
 	config    *settings.Config
 	service   hn.Service
+	account   hn.Account
 	favorites *favorites.Favorites
+	killfile  *killfile.Killfile
 
 	isOnHelpScreen bool
 	viewport       viewport.Model
+
+	// viewer shows a comment section or an article when the built-in pager
+	// is used instead of less
+	viewer viewer.Viewer
+
+	// pane shows the selected story next to the list when the screen is wide
+	// enough and the split pane is enabled
+	pane *splitPane
+
+	onSearchPrompt       bool
+	searchInput          textinput.Model
+	search               hn.SearchQuery
+	categoryBeforeSearch int
+
+	user               *hn.User
+	userToShow         string
+	categoryBeforeUser int
+
+	// showFullAbout shows the about text of the profile in full instead of
+	// its first lines
+	showFullAbout bool
+
+	// pastDay is the day whose front page the Past category shows, or the
+	// zero time for yesterday
+	pastDay time.Time
+
+	// lastClick is when the story at lastClickIndex was last clicked, to
+	// tell double clicks from single clicks
+	lastClick      time.Time
+	lastClickIndex int
+
+	// nextOffset is where the next batch of stories of each category starts.
+	// Stories can be left out of a batch, so it is not the number of stories
+	// that have been fetched.
+	nextOffset     map[int]int
+	isFetchingMore map[int]bool
+	hasReachedEnd  map[int]bool
+
+	// cancelFetchingMore stops fetching more stories when the category is
+	// changed or the program quits
+	cancelFetchingMore context.CancelFunc
+
+	// repliesFetchedAt is when the replies to repliesUser that the Replies
+	// category shows were fetched. It becomes the time of the last check
+	// once the category is left, so that refreshing it shows the same replies.
+	repliesUser      string
+	repliesFetchedAt time.Time
 }
 
-func (m *Model) FetchFrontPageStories() tea.Cmd {
+// fetchStartupCategory fetches the category that is shown on startup, which
+// is the front page unless SetSearch or SetUser has been called.
+func (m *Model) fetchStartupCategory() tea.Cmd {
+	if m.userToShow != "" {
+		return m.showUser(m.userToShow)
+	}
+
+	cat := m.category
+
 	return func() tea.Msg {
-		itemsToFetch := m.getNumberOfItemsToFetch(m.category)
+		stories, nextOffset, err := m.fetchCategory(context.Background(), cat, 0)
+
+		m.items[cat] = stories
+
+		return message.FetchingFinished{NextOffset: nextOffset, Err: err}
+	}
+}
 
-		stories, errMsg := m.service.FetchItems(itemsToFetch, category.FrontPage)
+// fetchCategory fetches the batch of stories of cat that starts at offset and
+// returns where the next batch starts.
+func (m *Model) fetchCategory(ctx context.Context, cat int, offset int) ([]*item.Item, int, error) {
+	itemsToFetch := m.getNumberOfItemsToFetch(cat)
+	nextOffset := offset + itemsToFetch
+
+	var (
+		stories []*item.Item
+		err     error
+	)
+
+	switch cat {
+	case category.Search:
+		stories, err = m.service.Search(ctx, m.search, offset, itemsToFetch)
 
-		m.items[category.FrontPage] = stories
+	case category.User:
+		stories, err = m.service.Search(ctx, getUserQuery(m.user.ID), offset, itemsToFetch)
 
-		return message.FetchingFinished{Message: errMsg}
+	case category.Replies:
+		stories, err = m.fetchReplies(ctx, offset)
+
+	case category.Past:
+		stories, err = m.fetchPastFrontPage(ctx, offset, itemsToFetch)
+
+	default:
+		stories, err = m.service.FetchItems(ctx, offset, itemsToFetch, cat)
+	}
+
+	return stories, nextOffset, err
+}
+
+// fetchReplies returns the replies to the user in the settings, or else to
+// the logged-in user, that are new since the last check, from the command line
+// or from this category. All replies are fetched at once.
+func (m *Model) fetchReplies(ctx context.Context, offset int) ([]*item.Item, error) {
+	name := m.config.RepliesUser
+	if name == "" {
+		name = m.account.Username()
+	}
+
+	if name == "" {
+		return nil, &hn.Error{Kind: hn.ErrUnauthorized}
+	}
+
+	if offset > 0 {
+		hn.SetEndOfList(ctx)
+
+		return []*item.Item{}, nil
+	}
+
+	checked, err := history.LoadRepliesChecked(history.PathToRepliesFile())
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	replies, err := m.service.FetchReplies(ctx, name, checked.After(name, now))
+	if err != nil {
+		return nil, err
+	}
+
+	m.repliesUser = name
+	m.repliesFetchedAt = now
+
+	items := make([]*item.Item, 0, len(replies))
+
+	for _, reply := range replies {
+		items = append(items, &item.Item{
+			ID:        reply.StoryID,
+			Title:     reply.StoryTitle,
+			User:      reply.Author,
+			Time:      reply.Time,
+			Type:      "comment",
+			Content:   reply.Text,
+			CommentID: reply.ID,
+		})
+	}
+
+	return items, nil
+}
+
+// markRepliesAsSeen stores when the replies that the Replies category shows
+// were fetched as the time of the last check. It is called when the category
+// is left, so that the replies are not new anymore the next time.
+func (m *Model) markRepliesAsSeen() {
+	if m.repliesFetchedAt.IsZero() {
+		return
+	}
+
+	if !m.config.DoNotMarkSubmissionsAsRead && !m.config.DebugMode {
+		// The replies have been seen, so failing to remember that is not
+		// worth an error
+		if checked, err := history.LoadRepliesChecked(history.PathToRepliesFile()); err == nil {
+			_ = checked.SetAndWriteToDisk(m.repliesUser, m.repliesFetchedAt)
+		}
+	}
+
+	m.repliesFetchedAt = time.Time{}
+}
+
+// fetchPastFrontPage returns the front page of the day that the Past category
+// shows. Yesterday goes through FetchItems, so that it is cached like the
+// other categories.
+func (m *Model) fetchPastFrontPage(ctx context.Context, offset int, itemsToFetch int) ([]*item.Item, error) {
+	day := m.getPastDay()
+	if day.Equal(hn.Yesterday()) {
+		return m.service.FetchItems(ctx, offset, itemsToFetch, category.Past)
+	}
+
+	return m.service.Search(ctx, hn.PastFrontPageQuery(day), offset, itemsToFetch)
+}
+
+func (m *Model) getPastDay() time.Time {
+	if m.pastDay.IsZero() {
+		return hn.Yesterday()
+	}
+
+	return m.pastDay
+}
+
+// changePastDay shows the front page of the day that is days after the one
+// that the Past category shows. Yesterday is the latest day, since the front
+// page of today is not over yet.
+func (m *Model) changePastDay(days int) tea.Cmd {
+	day := m.getPastDay().AddDate(0, 0, days)
+	if day.After(hn.Yesterday()) {
+		return m.NewStatusMessageWithDuration("Yesterday is the latest day", time.Second*3)
+	}
+
+	m.stopFetchingMore()
+	m.pastDay = day
+	m.items[category.Past] = []*item.Item{}
+	m.cursor = 0
+
+	m.SetDisabledInput(true)
+	startSpinnerCmd := m.StartSpinner()
+
+	changeCatCmd := func() tea.Msg {
+		return message.ChangeCategory{Category: category.Past, Cursor: 0}
+	}
+
+	return tea.Batch(startSpinnerCmd, changeCatCmd)
+}
+
+// getUserQuery returns the query for the latest stories and comments by name.
+func getUserQuery(name string) hn.SearchQuery {
+	return hn.SearchQuery{Author: name, ByDate: true}
+}
+
+// fetchMoreIfNearEnd fetches the next batch of stories in the background
+// when the last or second to last page of the current category is shown.
+// Input stays enabled while the stories are fetched, and the fetch is
+// cancelled if the category is changed in the meantime.
+func (m *Model) fetchMoreIfNearEnd() tea.Cmd {
+	cat := m.category
+
+	isNearEnd := m.Paginator.Page >= m.Paginator.TotalPages-2
+	canFetchMore := cat != category.Favorites && cat != category.Buffer &&
+		!m.isFetchingMore[cat] && !m.hasReachedEnd[cat] && m.categoryHasStories(cat)
+
+	if !isNearEnd || !canFetchMore {
+		return nil
+	}
+
+	m.isFetchingMore[cat] = true
+	offset := m.nextOffset[cat]
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancelFetchingMore = cancel
+
+	fetchCmd := func() tea.Msg {
+		ctx, reachedEnd := hn.WithEndOfList(ctx)
+		stories, nextOffset, err := m.fetchCategory(ctx, cat, offset)
+
+		return message.FetchingMoreFinished{
+			Category:   cat,
+			Offset:     offset,
+			NextOffset: nextOffset,
+			Items:      stories,
+			ReachedEnd: reachedEnd(),
+			Err:        err,
+		}
+	}
+
+	return tea.Batch(m.StartSpinner(), fetchCmd)
+}
+
+// stopFetchingMore cancels fetching more stories, if that is in progress.
+func (m *Model) stopFetchingMore() {
+	if m.cancelFetchingMore != nil {
+		m.cancelFetchingMore()
+		m.cancelFetchingMore = nil
+	}
+}
+
+// appendStories adds the stories that are not already in cat. Lists on
+// Hacker News change while they are paged through, so a story can show up
+// in two batches.
+func (m *Model) appendStories(cat int, stories []*item.Item) {
+	seen := make(map[int]bool, len(m.items[cat]))
+
+	for _, story := range m.items[cat] {
+		seen[story.ID] = true
+	}
+
+	for _, story := range stories {
+		if seen[story.ID] {
+			continue
+		}
+
+		seen[story.ID] = true
+		m.items[cat] = append(m.items[cat], story)
 	}
 }
 
@@ -128,6 +412,21 @@ func (m *Model) getNumberOfItemsToFetch(cat int) int {
 	case category.New:
 		return m.Paginator.PerPage * 3
 
+	case category.Search:
+		return m.Paginator.PerPage * 3
+
+	case category.User:
+		return m.Paginator.PerPage * 3
+
+	case category.Best:
+		return m.Paginator.PerPage * 3
+
+	case category.Past:
+		return m.Paginator.PerPage * 3
+
+	case category.Active:
+		return m.Paginator.PerPage * 3
+
 	case category.Ask:
 		return m.Paginator.PerPage
 
@@ -156,8 +455,23 @@ func New(delegate ItemDelegate, config *settings.Config, favorites *favorites.Fa
 	p.UsePgUpPgDownKeys = false
 	p.UseUpDownKeys = false
 
-	bufferCategory := 1
-	items := make([][]*item.Item, numberOfCategories+bufferCategory)
+	searchInput := textinput.New()
+	searchInput.Prompt = "/"
+	searchInput.Placeholder = "search, filter with author: type: points: after: before: sort:date"
+	searchInput.CharLimit = 200
+	searchInput.SetCursorMode(textinput.CursorStatic)
+
+	items := make([][]*item.Item, category.Count)
+
+	// A session file that can not be read only means that the user is not
+	// logged in, which is reported when they try to vote or reply
+	account, _ := services.NewAccount(config)
+
+	// An invalid killfile is reported before the list is started
+	kf, err := killfile.Load(file.PathToKillfile())
+	if err != nil {
+		kf = killfile.New(file.PathToKillfile())
+	}
 
 	m := Model{
 		showTitle:             true,
@@ -177,8 +491,16 @@ func New(delegate ItemDelegate, config *settings.Config, favorites *favorites.Fa
 		isVisible:    true,
 		disableInput: true,
 		config:       config,
-		service:      getService(config.DebugMode),
+		service:      muted.New(services.New(config), kf),
+		account:      account,
 		favorites:    favorites,
+		killfile:     kf,
+		searchInput:  searchInput,
+		pane:         newSplitPane(),
+
+		nextOffset:     make(map[int]int),
+		isFetchingMore: make(map[int]bool),
+		hasReachedEnd:  make(map[int]bool),
 	}
 
 	m.updatePagination()
@@ -186,6 +508,50 @@ func New(delegate ItemDelegate, config *settings.Config, favorites *favorites.Fa
 	return m
 }
 
+// SetSearch makes the list start with the results of query instead of the
+// front page.
+func (m *Model) SetSearch(query hn.SearchQuery) {
+	m.search = query
+	m.category = category.Search
+	m.categoryToDisplay = category.Search
+	m.categoryBeforeSearch = category.FrontPage
+}
+
+// SetUser makes the list start with the profile of name instead of the front
+// page.
+func (m *Model) SetUser(name string) {
+	m.userToShow = name
+	m.categoryToDisplay = category.User
+}
+
+// showUser fetches the profile and the latest stories and comments of name,
+// and shows them as a temporary category.
+func (m *Model) showUser(name string) tea.Cmd {
+	if m.category != category.User {
+		m.categoryBeforeUser = m.category
+	}
+
+	m.stopFetchingMore()
+	m.markRepliesAsSeen()
+	m.SetDisabledInput(true)
+	m.categoryToDisplay = category.User
+	m.showFullAbout = false
+	itemsToFetch := m.getNumberOfItemsToFetch(category.User)
+
+	fetchCmd := func() tea.Msg {
+		user, err := m.service.FetchUser(context.Background(), name)
+		if err != nil {
+			return message.UserFetchingFinished{Err: err}
+		}
+
+		stories, err := m.service.Search(context.Background(), getUserQuery(name), 0, itemsToFetch)
+
+		return message.UserFetchingFinished{User: user, Items: stories, NextOffset: itemsToFetch, Err: err}
+	}
+
+	return tea.Batch(m.StartSpinner(), fetchCmd)
+}
+
 func getHistory(debugMode bool, doNotMarkAsRead bool) history.History {
 	if debugMode {
 		return history.NewMockHistory()
@@ -198,14 +564,6 @@ func getHistory(debugMode bool, doNotMarkAsRead bool) history.History {
 	return history.NewPersistentHistory()
 }
 
-func getService(debugMode bool) hn.Service {
-	if debugMode {
-		return mock.Service{}
-	}
-
-	return &hybrid.Service{}
-}
-
 // SetShowTitle shows or hides the title bar.
 func (m *Model) SetShowTitle(v bool) {
 	m.showTitle = v
@@ -301,34 +659,40 @@ func (m *Model) CursorDown() {
 	m.cursor = itemsOnPage - 1
 }
 
-func (m *Model) getNextCategory() int {
-	isAtLastCategory := m.category == m.getNumberOfCategories()-1
-	if isAtLastCategory {
-		return category.FrontPage
+// getCategories returns the categories that Tab and Shift+Tab cycle through,
+// in the order they appear in the header.
+func (m *Model) getCategories() []int {
+	categories := append([]int{category.FrontPage}, m.config.Categories...)
+
+	if m.favorites.HasItems() {
+		categories = append(categories, category.Favorites)
 	}
 
-	return m.category + 1
+	return categories
 }
 
-func (m *Model) getNumberOfCategories() int {
-	if m.favorites.HasItems() {
-		return numberOfCategories
+func (m *Model) getNextCategory() int {
+	categories := m.getCategories()
+
+	for i, cat := range categories {
+		if cat == m.category {
+			return categories[(i+1)%len(categories)]
+		}
 	}
 
-	return numberOfCategories - 1
+	return category.FrontPage
 }
 
 func (m *Model) getPrevCategory() int {
-	isAtFirstCategory := m.category == category.FrontPage
-	if isAtFirstCategory && m.favorites.HasItems() {
-		return category.Favorites
-	}
+	categories := m.getCategories()
 
-	if isAtFirstCategory {
-		return category.Show
+	for i, cat := range categories {
+		if cat == m.category {
+			return categories[(i+len(categories)-1)%len(categories)]
+		}
 	}
 
-	return m.category - 1
+	return categories[len(categories)-1]
 }
 
 func (m *Model) ToggleSpinner() tea.Cmd {
@@ -387,6 +751,16 @@ func (m *Model) NewStatusMessageWithDuration(s string, d time.Duration) tea.Cmd
 	}
 }
 
+// newErrorStatusMessage shows a description of err in the status bar. A nil
+// error clears the status bar instead.
+func (m *Model) newErrorStatusMessage(err error) tea.Cmd {
+	if err == nil {
+		return m.NewStatusMessage("")
+	}
+
+	return m.NewStatusMessageWithDuration(hn.ErrorMessage(err), time.Second*3)
+}
+
 func (m *Model) SetPermanentStatusMessage(s string, faint bool) {
 	m.statusMessage = lipgloss.NewStyle().
 		Foreground(style.GetUnselectedItemFg()).
@@ -413,11 +787,14 @@ func (m *Model) updatePagination() {
 	if m.showTitle {
 		availHeight -= lipgloss.Height(m.titleView())
 	}
+	if profile := m.profileView(); profile != "" {
+		availHeight -= lipgloss.Height(profile)
+	}
 	if m.showStatusBar {
 		availHeight -= lipgloss.Height(m.statusView())
 	}
 
-	m.Paginator.PerPage = max(1, availHeight/(m.delegate.Height()+m.delegate.Spacing()))
+	m.Paginator.PerPage = ints.Max(1, availHeight/(m.delegate.Height()+m.delegate.Spacing()))
 
 	if pages := len(m.VisibleItems()); pages < 1 {
 		m.Paginator.SetTotalPages(1)
@@ -431,7 +808,7 @@ func (m *Model) updatePagination() {
 
 	// Make sure the page stays in bounds
 	if m.Paginator.Page >= m.Paginator.TotalPages-1 {
-		m.Paginator.Page = max(0, m.Paginator.TotalPages-1)
+		m.Paginator.Page = ints.Max(0, m.Paginator.TotalPages-1)
 	}
 }
 
@@ -467,7 +844,7 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 
 		m.items[category.Favorites] = m.favorites.GetItems()
 
-		fetchCmd := m.FetchFrontPageStories()
+		fetchCmd := m.fetchStartupCategory()
 		cmds = append(cmds, fetchCmd)
 
 		heightOfHeaderAndStatusLine := 2
@@ -479,7 +856,7 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		content := lipgloss.NewStyle().
 			Width(windowSizeMsg.Width).
 			AlignHorizontal(lipgloss.Center).
-			SetString(help.GetHelpScreen(m.config.EnableNerdFonts))
+			SetString(help.GetHelpScreen(m.config.EnableNerdFonts, m.config.Keymap))
 
 		m.viewport.SetContent(content.String())
 
@@ -498,13 +875,13 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		}
 
 	case message.FetchingFinished:
+		m.nextOffset[m.category] = msg.NextOffset
 		m.StopSpinner()
 		h, v := lipgloss.NewStyle().GetFrameSize()
 		m.setSize(screen.GetTerminalWidth()-h, screen.GetTerminalHeight()-v)
 		m.disableInput = false
-		m.NewStatusMessage(msg.Message)
 
-		return m, nil
+		return m, tea.Batch(m.newErrorStatusMessage(msg.Err), m.updatePane())
 
 	case message.StatusMessageTimeout:
 		m.hideStatusMessage()
@@ -534,28 +911,60 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		content := lipgloss.NewStyle().
 			Width(msg.Width).
 			AlignHorizontal(lipgloss.Center).
-			SetString(help.GetHelpScreen(m.config.EnableNerdFonts))
+			SetString(help.GetHelpScreen(m.config.EnableNerdFonts, m.config.Keymap))
 
 		m.viewport.SetContent(content.String())
 
+		if m.viewer != nil {
+			m.viewer.SetSize(msg.Width, msg.Height)
+		}
+
+		return m, m.updatePane()
+
+	case message.PreviewFetched:
+		m.handlePreviewFetched(msg)
+
 		return m, nil
 
 	case message.EnteringCommentSection:
+		m.StopSpinner()
+
+		if msg.Err != nil {
+			m.SetDisabledInput(false)
+
+			return m, m.newErrorStatusMessage(msg.Err)
+		}
+
+		story := msg.Story
 		lastVisited := m.history.GetLastVisited(msg.Id)
 
+		m.SetIsVisible(false)
 		m.history.MarkAsReadAndWriteToDisk(msg.Id, msg.CommentCount)
 
-		story := m.service.FetchComments(msg.Id)
-
 		if m.category == category.Favorites {
 			m.favorites.UpdateStoryAndWriteToDisk(story)
 		}
 
+		if m.config.Pager == settings.PagerNative {
+			comments := viewer.NewComments(story, m.config, lastVisited, m.width, m.height)
+			restored := comments.RestoreReadingPosition(m.history.GetReadingPosition(msg.Id))
+
+			// The last read comment takes precedence over the first new
+			// one, so that long threads can be read over several visits
+			if !comments.FocusComment(msg.CommentID) && !restored {
+				comments.FocusFirstNew()
+			}
+
+			m.viewer = comments
+
+			return m, nil
+		}
+
 		commentTree := tree.Print(story, m.config, m.width, lastVisited)
 
 		command := cli.Less(commentTree, m.config)
 
-		return m, tea.ExecProcess(command, func(err error) tea.Msg {
+		return m, m.execProcess(command, func(err error) tea.Msg {
 			return message.EditorFinishedMsg{Err: err}
 		})
 
@@ -571,27 +980,105 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 
 		article, err := reader.GetArticle(msg.Url, msg.Title, m.config.CommentWidth, m.config.IndentationSymbol)
 		if err != nil {
-			panic(err)
+			cmds = append(cmds, m.NewStatusMessageWithDuration("Could not fetch article", time.Second*3))
+			cmds = append(cmds, func() tea.Msg {
+				return message.EditorFinishedMsg{Err: nil}
+			})
+
+			return m, tea.Batch(cmds...)
+		}
+
+		if m.config.Pager == settings.PagerNative {
+			m.viewer = viewer.NewPager(article, m.config.Keymap, m.width, m.height)
+
+			return m, nil
 		}
 
 		command := cli.Less(article, m.config)
 
-		return m, tea.ExecProcess(command, func(err error) tea.Msg {
+		return m, m.execProcess(command, func(err error) tea.Msg {
 			return message.EditorFinishedMsg{Err: err}
 		})
 
 	case message.EditorFinishedMsg:
 		m.SetIsVisible(true)
 		m.SetDisabledInput(false)
+		cmds = append(cmds, m.enableMouse())
+
+	case viewer.Closed:
+		if comments, ok := m.viewer.(*viewer.Comments); ok {
+			m.history.SaveReadingPositionAndWriteToDisk(comments.StoryID(), comments.ReadingPosition())
+		}
+
+		m.viewer = nil
+		m.SetIsVisible(true)
+		m.SetDisabledInput(false)
+
+	case viewer.ReplyRequested:
+		return m, m.writeReply(msg.Item)
+
+	case viewer.UpvoteRequested:
+		id := msg.ID
+
+		return m, m.runAccountAction("Upvoted", func(ctx context.Context, account hn.Account) error {
+			return account.Upvote(ctx, id)
+		})
+
+	case message.ReplyWritten:
+		m.SetIsVisible(true)
+		m.SetDisabledInput(false)
+		enableMouse := m.enableMouse()
+
+		text, err := cli.ReadReply(msg.Path)
+		_ = os.Remove(msg.Path)
+
+		if msg.Err != nil || err != nil {
+			return m, tea.Batch(enableMouse, m.NewStatusMessageWithDuration("Could not open the editor", time.Second*3))
+		}
+
+		if text == "" {
+			return m, tea.Batch(enableMouse,
+				m.NewStatusMessageWithDuration("Reply is empty, nothing was posted", time.Second*3))
+		}
+
+		parentID := msg.ParentID
+
+		return m, tea.Batch(enableMouse,
+			m.runAccountAction("Reply posted", func(ctx context.Context, account hn.Account) error {
+				return account.Reply(ctx, parentID, text)
+			}))
+
+	case message.AccountActionFinished:
+		// Fetching a category shows its own spinner and disables input
+		if !m.disableInput {
+			m.StopSpinner()
+		}
+
+		if msg.Err != nil {
+			return m, m.newErrorStatusMessage(msg.Err)
+		}
+
+		return m, m.NewStatusMessageWithDuration(msg.Message, time.Second*2)
 
 	case message.ChangeCategory:
+		m.stopFetchingMore()
+
 		return m, func() tea.Msg {
-			itemsToFetch := m.getNumberOfItemsToFetch(msg.Category)
-			stories, errMsg := m.service.FetchItems(itemsToFetch, msg.Category)
+			ctx := context.Background()
+			if msg.Refresh {
+				ctx = cache.WithRefresh(ctx)
+			}
+
+			stories, nextOffset, err := m.fetchCategory(ctx, msg.Category, 0)
 
 			m.items[msg.Category] = stories
 
-			return message.CategoryFetchingFinished{Category: msg.Category, Cursor: msg.Cursor, Message: errMsg}
+			return message.CategoryFetchingFinished{
+				Category:   msg.Category,
+				Cursor:     msg.Cursor,
+				NextOffset: nextOffset,
+				Err:        err,
+			}
 		}
 
 	case message.CategoryFetchingFinished:
@@ -599,13 +1086,95 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		m.SetDisabledInput(false)
 		m.StopSpinner()
 		m.category = msg.Category
+		m.nextOffset[msg.Category] = msg.NextOffset
+
+		if msg.Category != category.Replies {
+			m.markRepliesAsSeen()
+		}
+		m.hasReachedEnd[msg.Category] = false
 
 		itemsOnPage := m.Paginator.ItemsOnPage(len(m.VisibleItems()))
-		m.cursor = min(msg.Cursor, itemsOnPage-1)
+		m.cursor = ints.Min(msg.Cursor, itemsOnPage-1)
+
+		if msg.Category == category.Search && msg.Err == nil {
+			cmds = append(cmds, m.NewStatusMessageWithDuration(getSearchResultsMessage(len(m.items[msg.Category])),
+				time.Second*3))
+		} else if msg.Category == category.Past && msg.Err == nil {
+			cmds = append(cmds, m.NewStatusMessageWithDuration("Front page of "+m.getPastDay().Format("Monday, 2 January 2006"),
+				time.Second*3))
+		} else {
+			cmds = append(cmds, m.newErrorStatusMessage(msg.Err))
+		}
+
+		m.updatePagination()
+
+	case message.UserFetchingFinished:
+		m.SetDisabledInput(false)
+		m.StopSpinner()
+		m.userToShow = ""
+
+		if msg.Err != nil {
+			m.categoryToDisplay = m.category
+			cmds = append(cmds, m.newErrorStatusMessage(msg.Err))
+
+			// Started with 'clx user', so the front page has not been
+			// fetched yet
+			if !m.categoryHasStories(m.category) {
+				cmds = append(cmds, m.switchToCategory(m.category))
+			}
+
+			return m, tea.Batch(cmds...)
+		}
+
+		m.user = msg.User
+		m.items[category.User] = msg.Items
+		m.nextOffset[category.User] = msg.NextOffset
+		m.hasReachedEnd[category.User] = false
+		m.category = category.User
+		m.Paginator.Page = 0
+		m.cursor = 0
+		m.updatePagination()
+
+		return m, m.updatePane()
 
-		m.NewStatusMessage(msg.Message)
+	case message.FetchingMoreFinished:
+		m.isFetchingMore[msg.Category] = false
+
+		// Fetching a category shows its own spinner and disables input
+		if !m.disableInput {
+			m.StopSpinner()
+		}
+
+		// The category has been changed or fetched again in the meantime
+		if errors.Is(msg.Err, context.Canceled) || msg.Offset != m.nextOffset[msg.Category] {
+			return m, nil
+		}
+
+		m.stopFetchingMore()
+
+		if msg.Err != nil {
+			return m, m.newErrorStatusMessage(msg.Err)
+		}
+
+		m.nextOffset[msg.Category] = msg.NextOffset
+		m.hasReachedEnd[msg.Category] = msg.ReachedEnd
+		m.appendStories(msg.Category, msg.Items)
+
+		if msg.Category != m.category {
+			return m, nil
+		}
 
 		m.updatePagination()
+
+		// The batch can be empty or short because stories were left out, so
+		// the list may still end on the current page
+		return m, m.fetchMoreIfNearEnd()
+	}
+
+	if m.viewer != nil {
+		cmds = append(cmds, m.viewer.Update(msg))
+
+		return m, tea.Batch(cmds...)
 	}
 
 	if m.isOnHelpScreen {
@@ -613,6 +1182,7 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	}
 
 	cmds = append(cmds, m.handleBrowsing(msg))
+	cmds = append(cmds, m.updatePane())
 
 	return m, tea.Batch(cmds...)
 }
@@ -625,7 +1195,14 @@ func (m Model) updateHelpScreen(msg tea.Msg) (Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		if k := msg.String(); k == "ctrl+c" || k == "q" || k == "esc" || k == "i" || k == "?" {
+		switch m.config.Keymap.Action(keymaps.ContextList, msg.String()) {
+		case keymaps.ListQuit, keymaps.ListBack, keymaps.ListHelp:
+			m.isOnHelpScreen = false
+
+			return m, nil
+		}
+
+		if msg.String() == "ctrl+c" {
 			m.isOnHelpScreen = false
 
 			return m, nil
@@ -648,7 +1225,7 @@ func (m Model) updateHelpScreen(msg tea.Msg) (Model, tea.Cmd) {
 		content := lipgloss.NewStyle().
 			Width(msg.Width).
 			AlignHorizontal(lipgloss.Center).
-			SetString(help.GetHelpScreen(m.config.EnableNerdFonts))
+			SetString(help.GetHelpScreen(m.config.EnableNerdFonts, m.config.Keymap))
 
 		m.viewport.SetContent(content.String())
 
@@ -664,7 +1241,7 @@ func (m Model) updateHelpScreen(msg tea.Msg) (Model, tea.Cmd) {
 }
 
 func (m *Model) updateCursor() {
-	m.cursor = min(m.cursor, m.Paginator.ItemsOnPage(len(m.VisibleItems()))-1)
+	m.cursor = ints.Min(m.cursor, m.Paginator.ItemsOnPage(len(m.VisibleItems()))-1)
 }
 
 func (m *Model) categoryHasStories(cat int) bool {
@@ -672,10 +1249,16 @@ func (m *Model) categoryHasStories(cat int) bool {
 }
 
 func (m *Model) changeToCategory(cat int) {
+	m.stopFetchingMore()
+
+	if cat != category.Replies {
+		m.markRepliesAsSeen()
+	}
+
 	m.category = cat
 	m.categoryToDisplay = m.category
 	m.Paginator.Page = 0
-	m.cursor = min(m.cursor, len(m.items[m.category])-1)
+	m.cursor = ints.Min(m.cursor, len(m.items[m.category])-1)
 	m.updatePagination()
 }
 
@@ -683,10 +1266,19 @@ func (m *Model) handleBrowsing(msg tea.Msg) tea.Cmd {
 	var cmds []tea.Cmd
 	numItems := len(m.VisibleItems())
 
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && m.onSearchPrompt {
+		return m.updateSearchPrompt(keyMsg)
+	}
+
 	switch msg := msg.(type) {
+	case tea.MouseMsg:
+		return m.handleMouse(msg)
+
 	case tea.KeyMsg:
+		action := m.config.Keymap.Action(keymaps.ContextList, msg.String())
+
 		switch {
-		case msg.String() == "i" || msg.String() == "?":
+		case action == keymaps.ListHelp:
 			m.isOnHelpScreen = true
 
 			return nil
@@ -695,12 +1287,24 @@ func (m *Model) handleBrowsing(msg tea.Msg) tea.Cmd {
 			m.onAddToFavoritesPrompt = false
 			m.disableInput = false
 
+			selectedItem := m.SelectedItem()
+
 			addToFavorites := func() tea.Msg {
-				return message.AddToFavorites{Item: m.SelectedItem()}
+				return message.AddToFavorites{Item: selectedItem}
 			}
 
 			cmds = append(cmds, addToFavorites)
-			cmds = append(cmds, m.NewStatusMessageWithDuration("Item added", time.Second*2))
+
+			if m.account.Username() == "" {
+				cmds = append(cmds, m.NewStatusMessageWithDuration("Item added", time.Second*2))
+
+				return tea.Batch(cmds...)
+			}
+
+			cmds = append(cmds, m.runAccountAction("Item added and favorited on Hacker News",
+				func(ctx context.Context, account hn.Account) error {
+					return account.Favorite(ctx, selectedItem.ID)
+				}))
 
 			return tea.Batch(cmds...)
 
@@ -708,6 +1312,8 @@ func (m *Model) handleBrowsing(msg tea.Msg) tea.Cmd {
 			m.onRemoveFromFavoritesPrompt = false
 			m.disableInput = false
 
+			removedID := m.SelectedItem().ID
+
 			//
 			m.favorites.Remove(m.Index())
 			m.items[category.Favorites] = m.favorites.GetItems()
@@ -718,7 +1324,14 @@ func (m *Model) handleBrowsing(msg tea.Msg) tea.Cmd {
 			isOnLastItem := m.Index() == len(m.items[category.Favorites])
 			hasOnlyOneItem := len(m.items[category.Favorites]) == 0
 
-			itemRemovedMessage := "Item removed"
+			itemRemovedCmd := m.NewStatusMessageWithDuration("Item removed", time.Second*2)
+
+			if m.account.Username() != "" {
+				itemRemovedCmd = m.runAccountAction("Item removed and unfavorited on Hacker News",
+					func(ctx context.Context, account hn.Account) error {
+						return account.Unfavorite(ctx, removedID)
+					})
+			}
 
 			if hasOnlyOneItem {
 				m.cursor = 0
@@ -726,7 +1339,7 @@ func (m *Model) handleBrowsing(msg tea.Msg) tea.Cmd {
 				cmds = append(cmds, func() tea.Msg {
 					return message.ChangeCategory{Category: category.FrontPage, Cursor: m.cursor}
 				})
-				cmds = append(cmds, m.NewStatusMessageWithDuration(itemRemovedMessage, time.Second*2))
+				cmds = append(cmds, itemRemovedCmd)
 
 				return tea.Batch(cmds...)
 			}
@@ -737,11 +1350,18 @@ func (m *Model) handleBrowsing(msg tea.Msg) tea.Cmd {
 
 			m.updatePagination()
 
-			return m.NewStatusMessageWithDuration(itemRemovedMessage, time.Second*2)
+			return itemRemovedCmd
+
+		case m.onMutePrompt && (msg.String() == "d" || msg.String() == "a"):
+			m.onMutePrompt = false
+			m.disableInput = false
+
+			return m.mute(msg.String())
 
-		case m.onAddToFavoritesPrompt || m.onRemoveFromFavoritesPrompt:
+		case m.onAddToFavoritesPrompt || m.onRemoveFromFavoritesPrompt || m.onMutePrompt:
 			m.onAddToFavoritesPrompt = false
 			m.onRemoveFromFavoritesPrompt = false
+			m.onMutePrompt = false
 			m.disableInput = false
 
 			m.hideStatusMessage()
@@ -751,88 +1371,78 @@ func (m *Model) handleBrowsing(msg tea.Msg) tea.Cmd {
 		case m.disableInput:
 			return nil
 
-		case msg.String() == "q" || msg.String() == "esc" || msg.String() == "ctrl+c":
-			return tea.Quit
+		case action == keymaps.ListBack && m.category == category.User:
+			return m.switchToCategory(m.categoryBeforeUser)
 
-		case msg.String() == "up" || msg.String() == "k":
-			m.CursorUp()
+		case action == keymaps.ListProfile && m.SelectedItem().User != "":
+			return m.showUser(m.SelectedItem().User)
 
-			return nil
-
-		case msg.String() == "down" || msg.String() == "j":
-			m.CursorDown()
+		case action == keymaps.ListAbout && m.category == category.User:
+			m.showFullAbout = !m.showFullAbout
+			m.updatePagination()
 
 			return nil
 
-		case msg.String() == "left" || msg.String() == "h":
-			m.Paginator.PrevPage()
-			m.updateCursor()
-
-			return nil
+		case action == keymaps.ListBack && m.category == category.Search:
+			return m.switchToCategory(m.categoryBeforeSearch)
 
-		case msg.String() == "right" || msg.String() == "l":
-			m.Paginator.NextPage()
-			m.updateCursor()
+		case action == keymaps.ListQuit || action == keymaps.ListBack || msg.String() == "ctrl+c":
+			m.stopFetchingMore()
+			m.markRepliesAsSeen()
 
-			return nil
-
-		case msg.String() == "tab":
-			nextCat := m.getNextCategory()
+			return tea.Quit
 
-			if m.categoryHasStories(nextCat) {
-				m.changeToCategory(nextCat)
+		case action == keymaps.ListSearch:
+			m.onSearchPrompt = true
+			m.searchInput.CursorEnd()
 
-				return nil
-			}
-
-			m.SetDisabledInput(true)
-			startSpinnerCmd := m.StartSpinner()
+			return m.searchInput.Focus()
 
-			m.categoryToDisplay = nextCat
+		case action == keymaps.ListUp:
+			m.CursorUp()
 
-			changeCatCmd := func() tea.Msg {
-				return message.ChangeCategory{Category: nextCat, Cursor: m.cursor}
-			}
+			return nil
 
-			cmds = append(cmds, startSpinnerCmd)
-			cmds = append(cmds, changeCatCmd)
+		case action == keymaps.ListDown:
+			m.CursorDown()
 
-			return tea.Batch(cmds...)
+			return m.fetchMoreIfNearEnd()
 
-		case msg.String() == "shift+tab":
-			prevCat := m.getPrevCategory()
+		case action == keymaps.ListPrevPage:
+			m.Paginator.PrevPage()
+			m.updateCursor()
 
-			if m.categoryHasStories(prevCat) {
-				m.changeToCategory(prevCat)
+			return nil
 
-				return nil
-			}
+		case action == keymaps.ListNextPage:
+			m.Paginator.NextPage()
+			m.updateCursor()
 
-			m.SetDisabledInput(true)
-			startSpinnerCmd := m.StartSpinner()
+			return m.fetchMoreIfNearEnd()
 
-			m.categoryToDisplay = prevCat
+		case action == keymaps.ListNextCategory:
+			return m.switchToCategory(m.getNextCategory())
 
-			changeCatCmd := func() tea.Msg {
-				return message.ChangeCategory{Category: prevCat, Cursor: m.cursor}
-			}
+		case action == keymaps.ListPrevCategory:
+			return m.switchToCategory(m.getPrevCategory())
 
-			cmds = append(cmds, startSpinnerCmd)
-			cmds = append(cmds, changeCatCmd)
+		case action == keymaps.ListPrevDay && m.category == category.Past:
+			return m.changePastDay(-1)
 
-			return tea.Batch(cmds...)
+		case action == keymaps.ListNextDay && m.category == category.Past:
+			return m.changePastDay(1)
 
-		case msg.String() == "g":
+		case action == keymaps.ListTop:
 			m.cursor = 0
 
 			return nil
 
-		case msg.String() == "G":
+		case action == keymaps.ListBottom:
 			m.cursor = m.Paginator.ItemsOnPage(numItems) - 1
 
-			return nil
+			return m.fetchMoreIfNearEnd()
 
-		case msg.String() == "o":
+		case action == keymaps.ListOpenLink:
 			if m.SelectedItem().URL == "" {
 				url := "https://news.ycombinator.com/item?id=" + strconv.Itoa(m.SelectedItem().ID)
 				browser.Open(url)
@@ -844,33 +1454,34 @@ func (m *Model) handleBrowsing(msg tea.Msg) tea.Cmd {
 
 			return nil
 
-		case msg.String() == "c":
+		case action == keymaps.ListOpenComments:
 			url := "https://news.ycombinator.com/item?id=" + strconv.Itoa(m.SelectedItem().ID)
 			browser.Open(url)
 
 			return nil
 
-		case msg.String() == "r" && m.category != category.Favorites:
+		case action == keymaps.ListRefresh && m.category != category.Favorites:
 			currentCategory := m.category
 			currentPage := m.Paginator.Page
 
 			m.items[category.Buffer] = m.items[m.category]
 			m.category = category.Buffer
 			m.Paginator.Page = 0
-			m.cursor = min(m.cursor, len(m.items[m.category])-1)
+			m.cursor = ints.Min(m.cursor, len(m.items[m.category])-1)
 			m.updatePagination()
 
-			m.items[category.FrontPage] = []*item.Item{}
-			m.items[category.New] = []*item.Item{}
-			m.items[category.Ask] = []*item.Item{}
-			m.items[category.Show] = []*item.Item{}
+			for _, cat := range m.getCategories() {
+				if cat != category.Favorites {
+					m.items[cat] = []*item.Item{}
+				}
+			}
 
 			m.SetDisabledInput(true)
 			m.cursor = 0
 			m.Paginator.Page = currentPage
 
 			changeCatCmd := func() tea.Msg {
-				return message.ChangeCategory{Category: currentCategory, Cursor: m.cursor}
+				return message.ChangeCategory{Category: currentCategory, Cursor: m.cursor, Refresh: true}
 			}
 
 			cmds = append(cmds, m.StartSpinner())
@@ -878,34 +1489,41 @@ func (m *Model) handleBrowsing(msg tea.Msg) tea.Cmd {
 
 			return tea.Batch(cmds...)
 
-		case msg.String() == "f" || msg.String() == "V":
+		case action == keymaps.ListUpvote && m.SelectedItem().ID != 0:
+			id := m.SelectedItem().ID
+
+			return m.runAccountAction("Upvoted", func(ctx context.Context, account hn.Account) error {
+				return account.Upvote(ctx, id)
+			})
+
+		case action == keymaps.ListReply && m.SelectedItem().ID != 0:
+			return m.writeReply(m.SelectedItem())
+
+		case action == keymaps.ListAddFavorite:
 			m.SetPermanentStatusMessage(getAddItemConfirmationMessage(), false)
 			m.onAddToFavoritesPrompt = true
 			m.disableInput = true
 
 			return nil
 
-		case msg.String() == "x" && m.category == category.Favorites:
-			m.SetPermanentStatusMessage(getRemoveItemConfirmationMessage(), false)
-			m.onRemoveFromFavoritesPrompt = true
+		case action == keymaps.ListMute && m.SelectedItem().ID != 0:
+			m.SetPermanentStatusMessage(getMuteConfirmationMessage(m.SelectedItem()), false)
+			m.onMutePrompt = true
 			m.disableInput = true
 
 			return nil
 
-		case msg.String() == "enter":
-			m.SetIsVisible(false)
-			m.SetDisabledInput(true)
+		case action == keymaps.ListRemoveFavorite && m.category == category.Favorites:
+			m.SetPermanentStatusMessage(getRemoveItemConfirmationMessage(), false)
+			m.onRemoveFromFavoritesPrompt = true
+			m.disableInput = true
 
-			cmd := func() tea.Msg {
-				return message.EnteringCommentSection{
-					Id:           m.SelectedItem().ID,
-					CommentCount: m.SelectedItem().CommentsCount,
-				}
-			}
+			return nil
 
-			return cmd
+		case action == keymaps.ListComments:
+			return m.openComments()
 
-		case msg.String() == " ":
+		case action == keymaps.ListReader:
 			m.SetIsVisible(false)
 			m.SetDisabledInput(true)
 
@@ -925,26 +1543,217 @@ func (m *Model) handleBrowsing(msg tea.Msg) tea.Cmd {
 	// Keep the index in bounds when paginating
 	itemsOnPage := m.Paginator.ItemsOnPage(len(m.VisibleItems()))
 	if m.cursor > itemsOnPage-1 {
-		m.cursor = max(0, itemsOnPage-1)
+		m.cursor = ints.Max(0, itemsOnPage-1)
 	}
 
 	return tea.Batch(cmds...)
 }
 
+// mute adds the domain ("d") or the author ("a") of the selected story to the
+// killfile and removes the stories that are now muted from every category
+// except Favorites.
+func (m *Model) mute(key string) tea.Cmd {
+	selected := m.SelectedItem()
+
+	kind, value := killfile.KindDomain, selected.Domain
+	if key == "a" {
+		kind, value = killfile.KindUser, selected.User
+	}
+
+	if value == "" {
+		return m.NewStatusMessageWithDuration("Nothing to mute", time.Second*2)
+	}
+
+	if err := m.killfile.Mute(kind, value); err != nil {
+		return m.NewStatusMessageWithDuration("Could not write the killfile", time.Second*3)
+	}
+
+	// The cursor moves to the first story after the selected one that is
+	// still shown
+	index := len(m.killfile.Stories(m.items[m.category][:m.Index()]))
+
+	for cat := range m.items {
+		if cat != category.Favorites {
+			m.items[cat] = m.killfile.Stories(m.items[cat])
+		}
+	}
+
+	status := m.NewStatusMessageWithDuration("Muted "+value, time.Second*2)
+
+	if len(m.items[m.category]) == 0 {
+		m.cursor = 0
+		m.Paginator.Page = 0
+		m.SetDisabledInput(true)
+
+		return tea.Batch(m.StartSpinner(), status, func() tea.Msg {
+			return message.ChangeCategory{Category: category.FrontPage, Cursor: 0}
+		})
+	}
+
+	m.Paginator.Page = 0
+	m.cursor = ints.Min(index, len(m.items[m.category])-1)
+	m.updatePagination()
+
+	return status
+}
+
+// openComments fetches the comment section of the selected story in the
+// background and opens it once it has been fetched.
+func (m *Model) openComments() tea.Cmd {
+	m.SetDisabledInput(true)
+
+	selected := m.SelectedItem()
+	service := m.service
+
+	return tea.Batch(m.StartSpinner(), func() tea.Msg {
+		story, err := service.FetchComments(context.Background(), selected.ID)
+
+		return message.EnteringCommentSection{
+			Id:           selected.ID,
+			CommentCount: selected.CommentsCount,
+			CommentID:    selected.CommentID,
+			Story:        story,
+			Err:          err,
+		}
+	})
+}
+
+// runAccountAction runs action in the background if the user is logged in and
+// shows done in the status bar once it has succeeded.
+func (m *Model) runAccountAction(done string, action func(ctx context.Context, account hn.Account) error) tea.Cmd {
+	if m.account.Username() == "" {
+		return m.newErrorStatusMessage(hn.ErrUnauthorized)
+	}
+
+	account := m.account
+
+	return tea.Batch(m.StartSpinner(), func() tea.Msg {
+		return message.AccountActionFinished{Message: done, Err: action(context.Background(), account)}
+	})
+}
+
+// writeReply opens the editor to write a reply to it, which is posted when
+// the editor is closed.
+func (m *Model) writeReply(it *item.Item) tea.Cmd {
+	if m.account.Username() == "" {
+		return m.newErrorStatusMessage(hn.ErrUnauthorized)
+	}
+
+	parentTitle := it.Title
+	if parentTitle == "" {
+		parentTitle = "comment by " + it.User
+	}
+
+	path, err := cli.NewReplyFile("Replying to " + parentTitle)
+	if err != nil {
+		return m.NewStatusMessageWithDuration("Could not create a file for the reply", time.Second*3)
+	}
+
+	m.SetIsVisible(false)
+	m.SetDisabledInput(true)
+
+	parentID := it.ID
+
+	return m.execProcess(cli.Editor(path), func(err error) tea.Msg {
+		return message.ReplyWritten{ParentID: parentID, Path: path, Err: err}
+	})
+}
+
+// switchToCategory shows cat right away if its stories have already been
+// fetched, and fetches them otherwise.
+func (m *Model) switchToCategory(cat int) tea.Cmd {
+	if m.categoryHasStories(cat) {
+		m.changeToCategory(cat)
+
+		return nil
+	}
+
+	m.SetDisabledInput(true)
+	startSpinnerCmd := m.StartSpinner()
+
+	m.categoryToDisplay = cat
+
+	changeCatCmd := func() tea.Msg {
+		return message.ChangeCategory{Category: cat, Cursor: m.cursor}
+	}
+
+	return tea.Batch(startSpinnerCmd, changeCatCmd)
+}
+
+// updateSearchPrompt handles key presses while the search prompt is open.
+func (m *Model) updateSearchPrompt(msg tea.KeyMsg) tea.Cmd {
+	switch msg.Type {
+	case tea.KeyEsc, tea.KeyCtrlC:
+		m.closeSearchPrompt()
+
+		return nil
+
+	case tea.KeyEnter:
+		m.closeSearchPrompt()
+
+		query, err := hn.ParseSearchQuery(m.searchInput.Value())
+		if err != nil {
+			return m.NewStatusMessageWithDuration(err.Error(), time.Second*3)
+		}
+
+		if query.IsEmpty() {
+			return nil
+		}
+
+		return m.startSearch(query)
+	}
+
+	var cmd tea.Cmd
+	m.searchInput, cmd = m.searchInput.Update(msg)
+
+	return cmd
+}
+
+func (m *Model) closeSearchPrompt() {
+	m.onSearchPrompt = false
+	m.searchInput.Blur()
+}
+
+func (m *Model) startSearch(query hn.SearchQuery) tea.Cmd {
+	if m.category != category.Search {
+		m.categoryBeforeSearch = m.category
+	}
+
+	m.search = query
+	m.items[category.Search] = []*item.Item{}
+	m.categoryToDisplay = category.Search
+	m.cursor = 0
+
+	m.SetDisabledInput(true)
+	startSpinnerCmd := m.StartSpinner()
+
+	changeCatCmd := func() tea.Msg {
+		return message.ChangeCategory{Category: category.Search, Cursor: 0}
+	}
+
+	return tea.Batch(startSpinnerCmd, changeCatCmd)
+}
+
 func (m *Model) showHelpScreen() tea.Cmd {
-	helpScreen := help.GetHelpScreen(m.config.EnableNerdFonts)
+	helpScreen := help.GetHelpScreen(m.config.EnableNerdFonts, m.config.Keymap)
 
 	command := cli.Less(helpScreen, m.config)
 
-	return tea.ExecProcess(command, func(err error) tea.Msg {
+	return m.execProcess(command, func(err error) tea.Msg {
 		return message.EditorFinishedMsg{Err: err}
 	})
 }
 
 // View renders the component.
 func (m Model) View() string {
+	if m.viewer != nil {
+		m.viewer.SetStatusMessage(m.statusMessage)
+
+		return m.viewer.View()
+	}
+
 	if m.isOnHelpScreen {
-		return fmt.Sprintf("%s\n%s\n%s", header.GetHeader(m.categoryToDisplay, m.favorites.HasItems(), m.width),
+		return fmt.Sprintf("%s\n%s\n%s", header.GetHeader(m.categoryToDisplay, m.getCategories()[1:], m.width),
 			m.viewport.View(),
 			m.statusAndPaginationView())
 	}
@@ -964,17 +1773,26 @@ func (m Model) View() string {
 		availHeight -= lipgloss.Height(v)
 	}
 
+	if v := m.profileView(); v != "" {
+		sections = append(sections, v)
+		availHeight -= lipgloss.Height(v)
+	}
+
 	if m.showStatusBar {
 		v := m.statusView()
 		availHeight -= lipgloss.Height(v)
 	}
 
-	content := lipgloss.NewStyle().Height(availHeight).Render(m.populatedView())
-	rankings := ranking.GetRankings(false, m.Paginator.PerPage, len(m.items[m.category]), m.cursor,
-		m.Paginator.Page, m.Paginator.TotalPages)
+	if m.isSplit() {
+		sections = append(sections, m.splitView(availHeight))
+	} else {
+		content := lipgloss.NewStyle().Height(availHeight).Render(m.populatedView())
+		rankings := ranking.GetRankings(false, m.Paginator.PerPage, len(m.items[m.category]), m.cursor,
+			m.Paginator.Page, m.Paginator.TotalPages)
 
-	rankingsAndContent := lipgloss.JoinHorizontal(lipgloss.Top, rankings, content)
-	sections = append(sections, rankingsAndContent)
+		rankingsAndContent := lipgloss.JoinHorizontal(lipgloss.Top, rankings, content)
+		sections = append(sections, rankingsAndContent)
+	}
 
 	if m.showStatusBar {
 		v := m.statusAndPaginationView()
@@ -985,7 +1803,28 @@ func (m Model) View() string {
 }
 
 func (m Model) titleView() string {
-	return header.GetHeader(m.categoryToDisplay, m.favorites.HasItems(), m.width) + "\n"
+	return header.GetHeader(m.categoryToDisplay, m.getCategories()[1:], m.width) + "\n"
+}
+
+// profileView returns the profile of the user whose stories and comments are
+// shown, or an empty string in other categories.
+func (m Model) profileView() string {
+	if m.category != category.User || m.user == nil {
+		return ""
+	}
+
+	lineWidth := ints.Min(m.config.CommentWidth, m.width-4)
+
+	// The full about text can take up to half of the screen, so that the
+	// stories and comments can still be seen
+	maxAboutLines := collapsedAboutLines
+	if m.showFullAbout {
+		maxAboutLines = ints.Max(collapsedAboutLines, m.height/2)
+	}
+
+	return lipgloss.NewStyle().
+		MarginLeft(1).
+		Render(meta.GetUserMetaBlock(m.user, m.config, lineWidth, maxAboutLines))
 }
 
 func (m Model) statusAndPaginationView() string {
@@ -1015,6 +1854,12 @@ func (m Model) statusAndPaginationView() string {
 		Background(style.GetStatusBarBg()).
 		Width(m.width - 5 - 5).Align(lipgloss.Center).Render(centerContent)
 
+	if m.onSearchPrompt {
+		center = lipgloss.NewStyle().Inline(true).
+			Background(style.GetStatusBarBg()).
+			Width(m.width - 5 - 5).Render(m.searchInput.View())
+	}
+
 	right := lipgloss.NewStyle().Inline(true).
 		Background(style.GetPaginatorBg()).
 		Width(5).Align(lipgloss.Center).Render(rightContent)
@@ -1098,12 +1943,23 @@ func (m Model) spinnerView() string {
 	return m.spinner.View()
 }
 
+func getSearchResultsMessage(results int) string {
+	switch results {
+	case 0:
+		return "No results"
+	case 1:
+		return "1 result"
+	default:
+		return strconv.Itoa(results) + " results"
+	}
+}
+
 func getAddItemConfirmationMessage() string {
 	normal := lipgloss.NewStyle().
 		Foreground(style.GetUnselectedItemFg()).
 		Background(style.GetStatusBarBg())
 	green := normal.Copy().
-		Foreground(lipgloss.Color("2"))
+		Foreground(theme.Current().Content.Green.Lipgloss())
 	bold := normal.Copy().
 		Foreground(style.GetBlue()).
 		Bold(true)
@@ -1117,7 +1973,7 @@ func getRemoveItemConfirmationMessage() string {
 		Foreground(style.GetUnselectedItemFg()).
 		Background(style.GetStatusBarBg())
 	red := normal.Copy().
-		Foreground(lipgloss.Color("1"))
+		Foreground(theme.Current().Content.Red.Lipgloss())
 	bold := normal.Copy().
 		Foreground(style.GetBlue()).
 		Bold(true)
@@ -1126,18 +1982,21 @@ func getRemoveItemConfirmationMessage() string {
 		normal.Render(" to confirm")
 }
 
-func max(a, b int) int {
-	if a > b {
-		return a
-	}
+func getMuteConfirmationMessage(story *item.Item) string {
+	normal := lipgloss.NewStyle().
+		Foreground(style.GetUnselectedItemFg()).
+		Background(style.GetStatusBarBg())
+	red := normal.Copy().
+		Foreground(theme.Current().Content.Red.Lipgloss())
+	bold := normal.Copy().
+		Foreground(style.GetBlue()).
+		Bold(true)
 
-	return b
-}
+	message := red.Render("Mute") + normal.Render("? Press ")
 
-func min(a, b int) int {
-	if a < b {
-		return a
+	if story.Domain != "" {
+		message += bold.Render("d") + normal.Render(" for "+story.Domain+", ")
 	}
 
-	return b
+	return message + bold.Render("a") + normal.Render(" for "+story.User)
 }
@@ -1,14 +1,26 @@
 package message
 
-import "clx/item"
+import (
+	"clx/hn"
+	"clx/item"
+)
 
 type EditorFinishedMsg struct {
 	Err error
 }
 
+// EnteringCommentSection is sent when the comments of the story with Id have
+// been fetched in the background.
 type EnteringCommentSection struct {
 	Id           int
 	CommentCount int
+
+	// CommentID is the comment to focus instead of the first new comment, or
+	// 0
+	CommentID int
+
+	Story *item.Item
+	Err   error
 }
 
 type EnteringReaderMode struct {
@@ -19,21 +31,69 @@ type EnteringReaderMode struct {
 
 type StatusMessageTimeout struct{}
 
+// FetchingFinished is sent when the category that is shown on startup has
+// been fetched. NextOffset is where its next batch of stories starts.
 type FetchingFinished struct {
-	Message string
+	NextOffset int
+	Err        error
 }
 
 type ChangeCategory struct {
 	Category int
 	Cursor   int
+	Refresh  bool
 }
 
 type CategoryFetchingFinished struct {
-	Category int
-	Cursor   int
-	Message  string
+	Category   int
+	Cursor     int
+	NextOffset int
+	Err        error
+}
+
+// FetchingMoreFinished is sent when the batch of stories of Category that
+// starts at Offset has been fetched. ReachedEnd is set if there are no more
+// stories after it. Err is context.Canceled if the category was changed
+// first.
+type FetchingMoreFinished struct {
+	Category   int
+	Offset     int
+	NextOffset int
+	Items      []*item.Item
+	ReachedEnd bool
+	Err        error
+}
+
+type UserFetchingFinished struct {
+	User       *hn.User
+	Items      []*item.Item
+	NextOffset int
+	Err        error
 }
 
 type AddToFavorites struct {
 	Item *item.Item
 }
+
+// ReplyWritten is sent when the editor that the reply to ParentID was written
+// in has been closed. Path is the file that holds the reply.
+type ReplyWritten struct {
+	ParentID int
+	Path     string
+	Err      error
+}
+
+// AccountActionFinished is sent when an upvote, favorite or reply has been
+// sent to Hacker News. Message is shown if it succeeded.
+type AccountActionFinished struct {
+	Message string
+	Err     error
+}
+
+// PreviewFetched is sent when the story with ID has been fetched for the
+// split pane. Err is context.Canceled if another story was selected first.
+type PreviewFetched struct {
+	ID    int
+	Story *item.Item
+	Err   error
+}
@@ -0,0 +1,153 @@
+package list
+
+import (
+	"os/exec"
+	"time"
+
+	"clx/header"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// doubleClickInterval is the longest time between two clicks on the same
+// story that opens its comment section
+const doubleClickInterval = 400 * time.Millisecond
+
+// handleMouse selects the story that was clicked and opens its comment
+// section on a double click, moves the cursor with the scroll wheel and
+// switches to the category that was clicked in the header.
+func (m *Model) handleMouse(msg tea.MouseMsg) tea.Cmd {
+	if m.disableInput || m.onSearchPrompt {
+		return nil
+	}
+
+	switch msg.Type {
+	case tea.MouseWheelUp:
+		return m.scrollUp()
+
+	case tea.MouseWheelDown:
+		return m.scrollDown()
+
+	case tea.MouseLeft:
+		if m.showTitle && msg.Y == 0 {
+			return m.clickHeader(msg.X)
+		}
+
+		return m.clickStory(msg.X, msg.Y)
+	}
+
+	return nil
+}
+
+func (m *Model) clickHeader(x int) tea.Cmd {
+	cat, ok := header.CategoryAt(x, m.getCategories()[1:])
+	if !ok || cat == m.category {
+		return nil
+	}
+
+	return m.switchToCategory(cat)
+}
+
+func (m *Model) clickStory(x, y int) tea.Cmd {
+	if m.isSplit() && x >= m.width/2 {
+		return nil
+	}
+
+	index, ok := m.itemAt(y)
+	if !ok {
+		return nil
+	}
+
+	isDoubleClick := index == m.lastClickIndex && time.Since(m.lastClick) < doubleClickInterval
+
+	m.cursor = index
+	m.lastClick = time.Now()
+	m.lastClickIndex = index
+
+	if isDoubleClick {
+		m.lastClick = time.Time{}
+
+		return m.openComments()
+	}
+
+	return nil
+}
+
+// itemAt returns the position on the current page of the story that is drawn
+// at line y, or false if y is above or below the stories or in the spacing
+// between them.
+func (m *Model) itemAt(y int) (int, bool) {
+	top := 0
+
+	if m.showTitle {
+		top += lipgloss.Height(m.titleView())
+	}
+
+	if profile := m.profileView(); profile != "" {
+		top += lipgloss.Height(profile)
+	}
+
+	if y < top {
+		return 0, false
+	}
+
+	rowHeight := m.delegate.Height() + m.delegate.Spacing()
+	index := (y - top) / rowHeight
+
+	isOnStory := (y-top)%rowHeight < m.delegate.Height()
+	isOnPage := index < m.Paginator.ItemsOnPage(len(m.VisibleItems()))
+
+	return index, isOnStory && isOnPage
+}
+
+// scrollUp moves the cursor up, or to the last story of the previous page
+// from the top of the page.
+func (m *Model) scrollUp() tea.Cmd {
+	if m.cursor > 0 || m.Paginator.Page == 0 {
+		m.CursorUp()
+
+		return nil
+	}
+
+	m.Paginator.PrevPage()
+	m.cursor = m.Paginator.ItemsOnPage(len(m.VisibleItems())) - 1
+
+	return nil
+}
+
+// scrollDown moves the cursor down, or to the first story of the next page
+// from the bottom of the page.
+func (m *Model) scrollDown() tea.Cmd {
+	isOnLastStory := m.cursor == m.Paginator.ItemsOnPage(len(m.VisibleItems()))-1
+
+	if !isOnLastStory || m.Paginator.OnLastPage() {
+		m.CursorDown()
+
+		return m.fetchMoreIfNearEnd()
+	}
+
+	m.Paginator.NextPage()
+	m.cursor = 0
+
+	return m.fetchMoreIfNearEnd()
+}
+
+// execProcess runs command in the foreground with mouse reporting turned off,
+// so that clicks are not sent to less or the editor as escape sequences.
+// enableMouse turns it back on.
+func (m *Model) execProcess(command *exec.Cmd, fn tea.ExecCallback) tea.Cmd {
+	if m.config.DisableMouse {
+		return tea.ExecProcess(command, fn)
+	}
+
+	return tea.Sequence(tea.DisableMouse, tea.ExecProcess(command, fn))
+}
+
+func (m *Model) enableMouse() tea.Cmd {
+	if m.config.DisableMouse {
+		return nil
+	}
+
+	return tea.EnableMouseCellMotion
+}
@@ -0,0 +1,37 @@
+package list
+
+import (
+	"strings"
+
+	"clx/constants/category"
+	"clx/header"
+	"clx/history"
+	"clx/item"
+	"clx/settings"
+)
+
+// Preview returns the header and stories as they are drawn in the list, with
+// the first story selected and the stories in the mock history marked as read.
+// It is used to preview themes.
+func Preview(stories []*item.Item, config *settings.Config, width int) string {
+	m := Model{
+		width:    width,
+		delegate: NewDefaultDelegate(),
+		history:  history.NewMockHistory(),
+		config:   config,
+		items:    make([][]*item.Item, category.Count),
+	}
+
+	m.items[category.FrontPage] = stories
+
+	var b strings.Builder
+
+	b.WriteString(header.GetHeader(category.FrontPage, config.Categories, width) + "\n\n")
+
+	for i, story := range stories {
+		m.delegate.Render(&b, m, i, story)
+		b.WriteString(strings.Repeat("\n", m.delegate.Spacing()+1))
+	}
+
+	return b.String()
+}
@@ -0,0 +1,224 @@
+package list
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"clx/bubble/list/message"
+	"clx/bubble/ranking"
+	"clx/item"
+	"clx/meta"
+	"clx/theme"
+	"clx/tree"
+	"clx/utils/ints"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	// previewDelay is how long the cursor has to rest on a story before it is
+	// fetched, so that scrolling past stories does not fetch each of them
+	previewDelay = 150 * time.Millisecond
+
+	previewComments  = 3
+	previewCacheSize = 50
+	rankingsWidth    = 7
+)
+
+// splitPane holds the state of the pane that shows the selected story next to
+// the list on wide screens. It is shared between copies of the model.
+type splitPane struct {
+	// id is the story that is shown, or 0 before the first story is selected
+	id     int
+	err    error
+	cancel context.CancelFunc
+
+	// stories holds the last fetched stories, oldest first in order
+	stories map[int]*item.Item
+	order   []int
+
+	// rendered is the pane as it was last drawn for key
+	rendered    string
+	renderedKey paneKey
+}
+
+type paneKey struct {
+	id, width, height int
+	story             *item.Item
+}
+
+func newSplitPane() *splitPane {
+	return &splitPane{stories: make(map[int]*item.Item)}
+}
+
+func (p *splitPane) add(story *item.Item) {
+	if _, exists := p.stories[story.ID]; !exists {
+		p.order = append(p.order, story.ID)
+	}
+
+	p.stories[story.ID] = story
+
+	if len(p.order) > previewCacheSize {
+		delete(p.stories, p.order[0])
+		p.order = p.order[1:]
+	}
+}
+
+// stop cancels the fetch that is in progress, if any.
+func (p *splitPane) stop() {
+	if p.cancel != nil {
+		p.cancel()
+		p.cancel = nil
+	}
+}
+
+// isSplit reports whether the list is shown next to the split pane.
+func (m Model) isSplit() bool {
+	return m.pane != nil && m.config.SplitPane && m.width >= m.config.SplitPaneMinWidth
+}
+
+// updatePane fetches the selected story in the background if it has changed
+// and has not been fetched before. The fetch of the previously selected story
+// is cancelled.
+func (m *Model) updatePane() tea.Cmd {
+	if m.pane == nil {
+		return nil
+	}
+
+	if !m.isSplit() {
+		m.pane.stop()
+		m.pane.id = 0
+
+		return nil
+	}
+
+	id := m.SelectedItem().ID
+	if id == 0 || id == m.pane.id {
+		return nil
+	}
+
+	m.pane.stop()
+	m.pane.id = id
+	m.pane.err = nil
+
+	if _, ok := m.pane.stories[id]; ok {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.pane.cancel = cancel
+
+	return func() tea.Msg {
+		select {
+		case <-ctx.Done():
+			return message.PreviewFetched{ID: id, Err: ctx.Err()}
+
+		case <-time.After(previewDelay):
+		}
+
+		story, err := m.service.FetchComments(ctx, id)
+
+		return message.PreviewFetched{ID: id, Story: story, Err: err}
+	}
+}
+
+func (m *Model) handlePreviewFetched(msg message.PreviewFetched) {
+	if errors.Is(msg.Err, context.Canceled) {
+		return
+	}
+
+	if msg.ID == m.pane.id {
+		m.pane.cancel = nil
+		m.pane.err = msg.Err
+	}
+
+	if msg.Err == nil {
+		m.pane.add(msg.Story)
+	}
+}
+
+// splitView returns the list on the left and the selected story on the right.
+func (m Model) splitView(height int) string {
+	listWidth := m.width / 2
+
+	list := m
+	list.width = listWidth - rankingsWidth
+
+	content := lipgloss.NewStyle().Width(list.width).Height(height).Render(list.populatedView())
+	rankings := ranking.GetRankings(false, m.Paginator.PerPage, len(m.items[m.category]), m.cursor,
+		m.Paginator.Page, m.Paginator.TotalPages)
+
+	paneStyle := lipgloss.NewStyle().
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderLeft(true).
+		BorderForeground(theme.Current().FaintText.Lipgloss()).
+		PaddingLeft(2)
+	paneWidth := m.width - listWidth - paneStyle.GetHorizontalFrameSize()
+
+	pane := paneStyle.Render(m.paneView(paneWidth, height))
+
+	return lipgloss.JoinHorizontal(lipgloss.Top, rankings, content, pane)
+}
+
+// paneView returns the meta block, self-text and top comments of the selected
+// story, cut off at height.
+func (m Model) paneView(width, height int) string {
+	story := m.pane.stories[m.pane.id]
+	key := paneKey{id: m.pane.id, width: width, height: height, story: story}
+
+	if story != nil && key == m.pane.renderedKey {
+		return m.pane.rendered
+	}
+
+	var content string
+
+	switch {
+	case story != nil:
+		content = m.renderPane(story, width)
+
+	case m.pane.err != nil:
+		content = theme.Current().Content.Red.Render("Could not fetch the comments", theme.Faint)
+
+	default:
+		content = lipgloss.NewStyle().Faint(true).Render("Loading comments...")
+	}
+
+	view := lipgloss.NewStyle().Width(width).Height(height).MaxHeight(height).Render(content)
+
+	if story != nil {
+		m.pane.rendered = view
+		m.pane.renderedKey = key
+	}
+
+	return view
+}
+
+func (m Model) renderPane(story *item.Item, width int) string {
+	config := *m.config
+	config.CommentWidth = ints.Min(config.CommentWidth, width-2)
+
+	lastVisited := m.history.GetLastVisited(story.ID)
+
+	var b strings.Builder
+
+	b.WriteString(meta.GetCommentSectionMetaBlock(story, &config, tree.NewCommentsCount(story, lastVisited)))
+
+	for i, comment := range story.Comments {
+		if i == previewComments {
+			break
+		}
+
+		b.WriteString("\n\n")
+
+		if i > 0 {
+			b.WriteString(tree.Separator(config.CommentWidth) + "\n\n")
+		}
+
+		b.WriteString(tree.PrintComment(comment, &config, width, story.User, "", lastVisited))
+	}
+
+	return b.String()
+}
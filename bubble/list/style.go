@@ -46,8 +46,7 @@ type Styles struct {
 // DefaultStyles returns a set of default style definitions for this list
 // component.
 func DefaultStyles() (s Styles) {
-	verySubduedColor := lipgloss.AdaptiveColor{Light: "#DDDADA", Dark: "#3C3C3C"}
-	subduedColor := lipgloss.AdaptiveColor{Light: "#9B9B9B", Dark: "#5C5C5C"}
+	subduedColor := style.GetUnselectedPageFg()
 
 	s.TitleBar = lipgloss.NewStyle().Padding(0, 0, 1, 2)
 
@@ -62,10 +61,10 @@ func DefaultStyles() (s Styles) {
 	// Faint(true)
 
 	s.FilterPrompt = lipgloss.NewStyle().
-		Foreground(lipgloss.AdaptiveColor{Light: "#04B575", Dark: "#ECFD65"})
+		Foreground(style.GetGreen())
 
 	s.FilterCursor = lipgloss.NewStyle().
-		Foreground(lipgloss.AdaptiveColor{Light: "#EE6FF8", Dark: "#EE6FF8"})
+		Foreground(style.GetMagenta())
 
 	s.DefaultFilterCharacterMatch = lipgloss.NewStyle().Underline(true)
 
@@ -76,12 +75,12 @@ func DefaultStyles() (s Styles) {
 	s.StatusEmpty = lipgloss.NewStyle().Foreground(subduedColor)
 
 	s.StatusBarActiveFilter = lipgloss.NewStyle().
-		Foreground(lipgloss.AdaptiveColor{Light: "#1a1a1a", Dark: "#dddddd"})
+		Foreground(style.GetUnselectedItemFg())
 
-	s.StatusBarFilterCount = lipgloss.NewStyle().Foreground(verySubduedColor)
+	s.StatusBarFilterCount = lipgloss.NewStyle().Foreground(subduedColor)
 
 	s.NoItems = lipgloss.NewStyle().
-		Foreground(lipgloss.AdaptiveColor{Light: "#909090", Dark: "#626262"})
+		Foreground(subduedColor)
 
 	s.ArabicPagination = lipgloss.NewStyle().Foreground(subduedColor)
 
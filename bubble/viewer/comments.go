@@ -0,0 +1,981 @@
+package viewer
+
+import (
+	"fmt"
+	"strings"
+
+	"clx/constants/style"
+	"clx/constants/unicode"
+	"clx/history"
+	"clx/item"
+	"clx/keymaps"
+	"clx/settings"
+	"clx/thread"
+	"clx/tree"
+	"clx/utils/ints"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var orderDescriptions = map[string]string{
+	thread.OrderOriginal: "Sorted in the original order",
+	thread.OrderNewest:   "Sorted by newest first",
+	thread.OrderReplies:  "Sorted by most replies first",
+	thread.OrderNew:      "Sorted by new comments first",
+}
+
+type node struct {
+	item     *item.Item
+	parent   *node
+	children []*node
+
+	// parentPoster is the author of the top-level comment of the thread, as
+	// in tree.PrintComment
+	parentPoster string
+	collapsed    bool
+
+	// revealed is set when a muted comment is shown in full
+	revealed bool
+
+	// rendered is the comment at the current width, or nil if it has to be
+	// rendered again
+	rendered []string
+}
+
+// Comments shows the comment section of a story. Every thread can be
+// collapsed and expanded on its own, and the comment that the cursor is on
+// is marked in the left margin.
+type Comments struct {
+	frame
+
+	story       *item.Item
+	config      *settings.Config
+	lastVisited int64
+	order       string
+	filter      thread.Filter
+
+	// isFiltering is set while the prompt is open for the text to filter by
+	// instead of the search query
+	isFiltering bool
+
+	// header is the headline and info box at the current width, or nil if
+	// it has to be rendered again
+	header []string
+	roots  []*node
+
+	// all holds every comment in the order they appear when expanded, and
+	// visible the comments that are not hidden by a collapsed parent. starts
+	// and ends hold the first line and the line after the last line of each
+	// visible comment.
+	all     []*node
+	visible []*node
+	starts  []int
+	ends    []int
+	focus   int
+
+	matches []*node
+
+	// newComments holds the comments that were posted after lastVisited, in
+	// the order they appear when expanded
+	newComments []*node
+
+	// collapsed, revealed and focusedID keep the state of the comments
+	// between builds, so that it survives a filter that hides them
+	collapsed map[int]bool
+	revealed  map[int]bool
+	focusedID int
+
+	// expandedByFilter is set while the threads are expanded to show the
+	// matches of a filter, whose state is then not kept
+	expandedByFilter bool
+}
+
+// NewComments returns a viewer for the comments of story. Replies are
+// collapsed unless AutoExpandComments is set, and comments that were posted
+// after lastVisited are marked as new.
+func NewComments(story *item.Item, config *settings.Config, lastVisited int64, width, height int) *Comments {
+	c := &Comments{
+		frame: newFrame(width, height, hint(config.Keymap,
+			hintEntry{"back", []string{keymaps.CommentsQuit}},
+			hintEntry{"next/prev", []string{keymaps.CommentsDown, keymaps.CommentsUp}},
+			hintEntry{"collapse/expand", []string{keymaps.CommentsCollapse, keymaps.CommentsExpand}},
+			hintEntry{"search", []string{keymaps.CommentsSearch}},
+			hintEntry{"sort", []string{keymaps.CommentsSort}},
+			hintEntry{"filter", []string{keymaps.CommentsFilter}})),
+		story:       story,
+		config:      config,
+		lastVisited: lastVisited,
+		order:       thread.OrderOriginal,
+		collapsed:   make(map[int]bool),
+		revealed:    make(map[int]bool),
+	}
+
+	c.build(false)
+
+	return c
+}
+
+// SetOrder sorts the comments in one of thread.Orders. The threads stay
+// expanded if they are filtered.
+func (c *Comments) SetOrder(order string) {
+	c.order = order
+	c.message = orderDescriptions[order]
+
+	c.build(c.expandedByFilter)
+}
+
+// SetFilter only shows the comments that match filter together with their
+// parents. All threads are expanded, so that every match can be seen.
+func (c *Comments) SetFilter(filter thread.Filter) {
+	c.filter = filter
+
+	c.build(!filter.IsEmpty())
+	c.message = c.filterDescription()
+}
+
+// build creates the nodes from the comments of the story in the current order
+// and with the current filter. Comments that were shown before keep their
+// state unless expandAll is set, and the cursor stays on the same comment if
+// it is still shown. Comments that the filter hides keep their state for when
+// they are shown again, and threads that are expanded for a filter return to
+// their state from before it.
+func (c *Comments) build(expandAll bool) {
+	for _, n := range c.all {
+		if !c.expandedByFilter {
+			c.collapsed[n.item.ID] = n.collapsed
+		}
+
+		c.revealed[n.item.ID] = n.revealed
+	}
+
+	c.expandedByFilter = expandAll
+
+	if focused := c.Focused(); focused != nil {
+		c.focusedID = focused.ID
+	}
+
+	c.roots = nil
+	c.all = nil
+	c.matches = nil
+	c.newComments = nil
+
+	for _, reply := range thread.Sort(c.filter.Apply(c.story.Comments), c.order, c.lastVisited) {
+		if n := c.addNode(reply, nil, ""); n != nil {
+			c.roots = append(c.roots, n)
+		}
+	}
+
+	for _, n := range c.all {
+		if c.lastVisited < n.item.Time {
+			c.newComments = append(c.newComments, n)
+		}
+
+		n.revealed = c.revealed[n.item.ID]
+
+		state, ok := c.collapsed[n.item.ID]
+
+		switch {
+		case expandAll:
+			n.collapsed = false
+
+		case ok:
+			n.collapsed = state && len(n.children) != 0
+
+		case n.parent == nil && !c.config.AutoExpandComments:
+			n.collapsed = len(n.children) != 0
+		}
+	}
+
+	if c.query != "" {
+		c.search()
+	}
+
+	c.focus = 0
+	c.offset = 0
+	c.layout()
+
+	for _, n := range c.all {
+		if n.item.ID == c.focusedID {
+			c.focusNode(n)
+
+			break
+		}
+	}
+}
+
+// filterDescription returns how many comments are shown with the current
+// filter.
+func (c *Comments) filterDescription() string {
+	if c.filter.IsEmpty() {
+		return "Showing all comments"
+	}
+
+	matches := 0
+
+	for _, n := range c.all {
+		if c.filter.Matches(n.item) {
+			matches++
+		}
+	}
+
+	description := fmt.Sprintf("Showing %d comments", matches)
+	if matches == 1 {
+		description = "Showing 1 comment"
+	}
+
+	if c.filter.Author != "" {
+		description += " by " + c.filter.Author
+	}
+
+	if c.filter.Text != "" {
+		description += fmt.Sprintf(" containing %q", c.filter.Text)
+	}
+
+	return description
+}
+
+func (c *Comments) addNode(it *item.Item, parent *node, parentPoster string) *node {
+	n := &node{item: it, parent: parent, parentPoster: parentPoster}
+	c.all = append(c.all, n)
+
+	if parent == nil {
+		parentPoster = it.User
+	}
+
+	for _, reply := range it.Comments {
+		if child := c.addNode(reply, n, parentPoster); child != nil {
+			n.children = append(n.children, child)
+		}
+	}
+
+	// Deleted comments are only shown if they have replies. Since they have
+	// none, n is still the last node that was added.
+	if it.Content == "[deleted]" && len(n.children) == 0 {
+		c.all = c.all[:len(c.all)-1]
+
+		return nil
+	}
+
+	return n
+}
+
+func (c *Comments) SetSize(width, height int) {
+	focused := c.focusedNode()
+
+	if width != c.width {
+		c.header = nil
+
+		for _, n := range c.all {
+			n.rendered = nil
+		}
+	}
+
+	c.width = width
+	c.height = height
+
+	c.layout()
+	c.focusNode(focused)
+}
+
+func (c *Comments) Update(msg tea.Msg) tea.Cmd {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return nil
+	}
+
+	if c.isPrompting {
+		value, submitted, cmd := c.updatePrompt(keyMsg)
+
+		switch {
+		case submitted && c.isFiltering:
+			c.SetFilter(thread.Filter{Author: c.filter.Author, Text: value})
+
+		case submitted && value != "":
+			c.query = value
+			c.search()
+			c.jumpToMatch(1)
+		}
+
+		return cmd
+	}
+
+	c.message = ""
+
+	return c.handleKey(keyMsg)
+}
+
+func (c *Comments) handleKey(msg tea.KeyMsg) tea.Cmd {
+	focused := c.focusedNode()
+	height := c.contentHeight()
+
+	if msg.String() == "ctrl+c" {
+		return closeViewer
+	}
+
+	switch c.config.Keymap.Action(keymaps.ContextComments, msg.String()) {
+	case keymaps.CommentsQuit:
+		return closeViewer
+
+	case keymaps.CommentsBack:
+		if c.query != "" {
+			c.query = ""
+			c.matches = nil
+
+			return nil
+		}
+
+		if !c.filter.IsEmpty() {
+			c.SetFilter(thread.Filter{})
+
+			return nil
+		}
+
+		return closeViewer
+
+	case keymaps.CommentsDown:
+		// Long comments are scrolled through before moving on
+		if c.focus < len(c.visible) && c.ends[c.focus] > c.offset+height {
+			c.scrollBy(1)
+
+			return nil
+		}
+
+		c.focusIndex(c.focus + 1)
+
+	case keymaps.CommentsUp:
+		if c.focus < len(c.visible) && c.starts[c.focus] < c.offset {
+			c.scrollBy(-1)
+
+			return nil
+		}
+
+		c.focusIndex(c.focus - 1)
+
+	case keymaps.CommentsHalfPageDown:
+		c.scrollBy(height / 2)
+		c.focusFirstInView()
+
+	case keymaps.CommentsHalfPageUp:
+		c.scrollBy(-height / 2)
+		c.focusFirstInView()
+
+	case keymaps.CommentsPageDown:
+		c.scrollBy(height)
+		c.focusFirstInView()
+
+	case keymaps.CommentsPageUp:
+		c.scrollBy(-height)
+		c.focusFirstInView()
+
+	case keymaps.CommentsTop:
+		c.focusIndex(0)
+		c.scrollTo(0)
+
+	case keymaps.CommentsBottom:
+		c.focusIndex(len(c.visible) - 1)
+		c.scrollTo(len(c.lines))
+
+	case keymaps.CommentsCollapse:
+		if focused == nil {
+			return nil
+		}
+
+		if len(focused.children) == 0 || focused.collapsed {
+			c.focusNode(focused.parent)
+
+			return nil
+		}
+
+		c.setCollapsed(focused, true)
+
+	case keymaps.CommentsExpand:
+		if focused != nil && focused.item.Muted && !focused.revealed {
+			c.reveal(focused)
+
+			return nil
+		}
+
+		if focused != nil && focused.collapsed {
+			c.setCollapsed(focused, false)
+		}
+
+	case keymaps.CommentsToggle:
+		if focused != nil && focused.item.Muted && !focused.revealed {
+			c.reveal(focused)
+
+			return nil
+		}
+
+		if focused != nil && len(focused.children) != 0 {
+			c.setCollapsed(focused, !focused.collapsed)
+		}
+
+	case keymaps.CommentsCollapseAll:
+		for _, root := range c.roots {
+			root.collapsed = len(root.children) != 0
+		}
+
+		c.layout()
+		c.focusNode(rootOf(focused))
+
+	case keymaps.CommentsExpandAll:
+		for _, n := range c.all {
+			n.collapsed = false
+		}
+
+		c.layout()
+		c.focusNode(focused)
+
+	case keymaps.CommentsParent:
+		if focused != nil {
+			c.focusNode(focused.parent)
+		}
+
+	case keymaps.CommentsRoot:
+		c.focusNode(rootOf(focused))
+
+	case keymaps.CommentsNextSibling:
+		c.focusNode(sibling(focused, c.roots, 1))
+
+	case keymaps.CommentsPrevSibling:
+		c.focusNode(sibling(focused, c.roots, -1))
+
+	case keymaps.CommentsNextTopLevel:
+		if c.query != "" {
+			c.jumpToMatch(1)
+
+			return nil
+		}
+
+		c.focusNode(sibling(rootOf(focused), c.roots, 1))
+
+	case keymaps.CommentsPrevTopLevel:
+		if c.query != "" {
+			c.jumpToMatch(-1)
+
+			return nil
+		}
+
+		c.focusNode(sibling(rootOf(focused), c.roots, -1))
+
+	case keymaps.CommentsSearch:
+		c.isFiltering = false
+
+		return c.openSearch()
+
+	case keymaps.CommentsFilter:
+		c.isFiltering = true
+
+		return c.openPrompt("&", c.filter.Text)
+
+	case keymaps.CommentsNextNew:
+		c.jumpToNew(1)
+
+	case keymaps.CommentsPrevNew:
+		c.jumpToNew(-1)
+
+	case keymaps.CommentsSort:
+		c.SetOrder(thread.NextOrder(c.order))
+
+	case keymaps.CommentsOnlyOP:
+		c.toggleAuthorFilter(c.story.User)
+
+	case keymaps.CommentsOnlyAuthor:
+		if focused != nil && focused.item.User != "" {
+			c.toggleAuthorFilter(focused.item.User)
+		}
+
+	case keymaps.CommentsReply:
+		if focused != nil {
+			return func() tea.Msg { return ReplyRequested{Item: focused.item} }
+		}
+
+	case keymaps.CommentsUpvote:
+		if focused != nil {
+			return func() tea.Msg { return UpvoteRequested{ID: focused.item.ID} }
+		}
+	}
+
+	return nil
+}
+
+func (c *Comments) View() string {
+	marker := lipgloss.NewStyle().Foreground(style.GetOrange()).Render("▎") + " "
+
+	gutter := func(line int) string {
+		// The header has its own margin, like in less
+		if line < len(c.header) {
+			return ""
+		}
+
+		if c.focus < len(c.visible) && line >= c.starts[c.focus] && line < c.ends[c.focus] {
+			return marker
+		}
+
+		return "  "
+	}
+
+	var position []string
+
+	if c.order != thread.OrderOriginal {
+		position = append(position, c.order+" first")
+	}
+
+	if !c.filter.IsEmpty() {
+		position = append(position, "filtered")
+	}
+
+	if len(c.newComments) != 0 {
+		position = append(position, fmt.Sprintf("%d new", len(c.newComments)))
+	}
+
+	if focused := c.focusedNode(); focused != nil {
+		position = append(position, fmt.Sprintf("comment %d of %d", indexOf(c.all, focused)+1, len(c.all)))
+	}
+
+	return c.frame.view(gutter, strings.Join(position, " • "))
+}
+
+// layout lays out the visible comments below the header. It has to be called
+// whenever a comment is collapsed or expanded or the width changes.
+func (c *Comments) layout() {
+	c.visible = c.visible[:0]
+	c.starts = c.starts[:0]
+	c.ends = c.ends[:0]
+
+	var addVisible func(nodes []*node)
+	addVisible = func(nodes []*node) {
+		for _, n := range nodes {
+			c.visible = append(c.visible, n)
+
+			if !n.collapsed {
+				addVisible(n.children)
+			}
+		}
+	}
+
+	addVisible(c.roots)
+
+	if c.header == nil {
+		c.header = splitLines(tree.PrintHeader(c.story, c.config, c.width, c.lastVisited))
+	}
+
+	lines := append([]string{}, c.header...)
+	lines = append(lines, "")
+
+	for i, n := range c.visible {
+		switch {
+		case i == 0:
+
+		case n.parent == nil:
+			lines = append(lines, "", tree.Separator(c.config.CommentWidth), "")
+
+		default:
+			lines = append(lines, "")
+		}
+
+		if len(c.newComments) != 0 && n == c.newComments[0] {
+			lines = append(lines, tree.NewCommentsDivider(n.item.Level, c.config.CommentWidth))
+		}
+
+		c.starts = append(c.starts, len(lines))
+		lines = append(lines, c.render(n)...)
+
+		if n.collapsed && len(n.children) != 0 {
+			lines = append(lines, c.collapsedView(n))
+		}
+
+		c.ends = append(c.ends, len(lines))
+	}
+
+	c.lines = append(lines, "")
+	c.focus = ints.Clamp(c.focus, 0, ints.Max(0, len(c.visible)-1))
+	c.scrollTo(c.offset)
+}
+
+func (c *Comments) render(n *node) []string {
+	if n.rendered == nil {
+		it := n.item
+		if it.Muted && n.revealed {
+			unmuted := *it
+			unmuted.Muted = false
+			it = &unmuted
+		}
+
+		comment := tree.PrintComment(it, c.config, c.width, c.story.User, n.parentPoster, c.lastVisited)
+
+		// The zero-width space marks top-level comments for less
+		n.rendered = splitLines(strings.ReplaceAll(comment, unicode.ZeroWidthSpace, ""))
+	}
+
+	return n.rendered
+}
+
+// collapsedView returns the line that stands in for the replies of a
+// collapsed comment.
+func (c *Comments) collapsedView(n *node) string {
+	replies := countReplies(n)
+	label := fmt.Sprintf("▸ %d replies hidden", replies)
+
+	if replies == 1 {
+		label = "▸ 1 reply hidden"
+	}
+
+	if newReplies := tree.NewCommentsCount(n.item, c.lastVisited); newReplies != 0 {
+		label += lipgloss.NewStyle().Foreground(style.GetCyan()).Render(fmt.Sprintf(" (%d new)", newReplies))
+	}
+
+	indentation := strings.Repeat(" ", n.item.Level+1)
+
+	return indentation + lipgloss.NewStyle().Faint(true).Render(label)
+}
+
+// toggleAuthorFilter only shows the comments by author, or all comments if
+// they are already filtered by author.
+func (c *Comments) toggleAuthorFilter(author string) {
+	filter := c.filter
+	filter.Author = author
+
+	if strings.EqualFold(c.filter.Author, author) {
+		filter.Author = ""
+	}
+
+	c.SetFilter(filter)
+}
+
+func (c *Comments) setCollapsed(n *node, collapsed bool) {
+	n.collapsed = collapsed
+
+	c.layout()
+	c.focusNode(n)
+}
+
+// reveal shows the text of a muted comment instead of the placeholder.
+func (c *Comments) reveal(n *node) {
+	n.revealed = true
+	n.rendered = nil
+
+	c.layout()
+	c.focusNode(n)
+}
+
+// Focused returns the comment that the cursor is on, or nil if there are no
+// comments.
+func (c *Comments) Focused() *item.Item {
+	if n := c.focusedNode(); n != nil {
+		return n.item
+	}
+
+	return nil
+}
+
+// StoryID returns the ID of the story that the comments belong to.
+func (c *Comments) StoryID() int {
+	return c.story.ID
+}
+
+// ReadingPosition returns which threads are collapsed and the comment that
+// the cursor is on, to be restored with RestoreReadingPosition on the next
+// visit.
+func (c *Comments) ReadingPosition() history.ReadingPosition {
+	position := history.ReadingPosition{CollapsedComments: make(map[int]bool, len(c.roots))}
+
+	for _, root := range c.roots {
+		position.CollapsedComments[root.item.ID] = root.collapsed
+	}
+
+	// A filter expands every thread and hides others, so the threads keep
+	// the state that they had before it was set
+	if c.expandedByFilter {
+		for _, reply := range c.story.Comments {
+			if collapsed, ok := c.collapsed[reply.ID]; ok {
+				position.CollapsedComments[reply.ID] = collapsed
+			}
+		}
+	}
+
+	if focused := c.focusedNode(); focused != nil {
+		position.LastReadComment = focused.item.ID
+	}
+
+	return position
+}
+
+// RestoreReadingPosition collapses and expands the threads as they were and
+// scrolls the last read comment to the top. Threads that were posted since
+// keep their default state, and comments that have been removed are ignored.
+// It reports whether the last read comment was found.
+func (c *Comments) RestoreReadingPosition(position history.ReadingPosition) bool {
+	for _, root := range c.roots {
+		if collapsed, ok := position.CollapsedComments[root.item.ID]; ok {
+			root.collapsed = collapsed && len(root.children) != 0
+		}
+	}
+
+	c.layout()
+
+	for _, n := range c.all {
+		if n.item.ID == position.LastReadComment {
+			c.focusNode(n)
+
+			if c.focus != 0 {
+				c.scrollTo(c.starts[c.focus] - 1)
+			}
+
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *Comments) focusedNode() *node {
+	if len(c.visible) == 0 {
+		return nil
+	}
+
+	return c.visible[c.focus]
+}
+
+// focusIndex moves the cursor to the visible comment at index i and scrolls
+// it into view.
+func (c *Comments) focusIndex(i int) {
+	if len(c.visible) == 0 {
+		return
+	}
+
+	c.focus = ints.Clamp(i, 0, len(c.visible)-1)
+	height := c.contentHeight()
+	start, end := c.starts[c.focus], c.ends[c.focus]
+
+	switch {
+	// Show the header together with the first comment
+	case c.focus == 0 && end <= height:
+		c.scrollTo(0)
+
+	case start < c.offset:
+		c.scrollTo(start - 1)
+
+	case end > c.offset+height:
+		c.scrollTo(ints.Min(start-1, end-height))
+	}
+}
+
+// focusNode moves the cursor to n, expanding its parents if they are
+// collapsed. A nil node leaves the cursor where it is.
+func (c *Comments) focusNode(n *node) {
+	if n == nil {
+		return
+	}
+
+	isHidden := false
+
+	for parent := n.parent; parent != nil; parent = parent.parent {
+		if parent.collapsed {
+			parent.collapsed = false
+			isHidden = true
+		}
+	}
+
+	if isHidden {
+		c.layout()
+	}
+
+	c.focusIndex(indexOf(c.visible, n))
+}
+
+// focusFirstInView moves the cursor to the first comment that starts on the
+// screen, or to the comment that fills it.
+func (c *Comments) focusFirstInView() {
+	for i := range c.visible {
+		if c.ends[i] <= c.offset {
+			continue
+		}
+
+		c.focus = i
+
+		if c.starts[i] < c.offset && i+1 < len(c.visible) && c.starts[i+1] < c.offset+c.contentHeight() {
+			c.focus = i + 1
+		}
+
+		return
+	}
+}
+
+// FocusFirstNew moves the cursor to the first comment that was posted since
+// the last visit and scrolls it to the top together with the divider above
+// it. It reports whether there was a new comment.
+func (c *Comments) FocusFirstNew() bool {
+	if len(c.newComments) == 0 {
+		return false
+	}
+
+	c.focusNode(c.newComments[0])
+
+	if c.focus != 0 {
+		c.scrollTo(c.starts[c.focus] - 2)
+	}
+
+	return true
+}
+
+// FocusComment moves the cursor to the comment with the given ID and expands
+// the threads it is in. It reports whether the comment was found.
+func (c *Comments) FocusComment(id int) bool {
+	for _, n := range c.all {
+		if n.item.ID != id {
+			continue
+		}
+
+		c.focusNode(n)
+
+		if c.focus != 0 {
+			c.scrollTo(c.starts[c.focus] - 1)
+		}
+
+		return true
+	}
+
+	return false
+}
+
+// jumpToNew moves the cursor to the next new comment in the given direction,
+// at any depth.
+func (c *Comments) jumpToNew(direction int) {
+	if len(c.newComments) == 0 {
+		c.message = "There are no new comments"
+
+		return
+	}
+
+	current := indexOf(c.all, c.focusedNode())
+
+	for i := range c.newComments {
+		j := i
+		if direction < 0 {
+			j = len(c.newComments) - 1 - i
+		}
+
+		position := indexOf(c.all, c.newComments[j])
+		if (direction > 0 && position > current) || (direction < 0 && position < current) {
+			c.focusNode(c.newComments[j])
+			c.message = fmt.Sprintf("New comment %d of %d", j+1, len(c.newComments))
+
+			return
+		}
+	}
+
+	c.message = "No more new comments below"
+	if direction < 0 {
+		c.message = "No more new comments above"
+	}
+}
+
+// search finds the comments whose author or text contain the query,
+// including those in collapsed threads.
+func (c *Comments) search() {
+	c.matches = nil
+
+	for _, n := range c.all {
+		if thread.Contains(n.item, c.query) {
+			c.matches = append(c.matches, n)
+		}
+	}
+}
+
+// jumpToMatch moves the cursor to the next match in the given direction,
+// wrapping around at the ends.
+func (c *Comments) jumpToMatch(direction int) {
+	if len(c.matches) == 0 {
+		c.message = fmt.Sprintf("No comments match %q", c.query)
+
+		return
+	}
+
+	current := indexOf(c.all, c.focusedNode())
+	match := -1
+
+	for i := range c.matches {
+		j := i
+		if direction < 0 {
+			j = len(c.matches) - 1 - i
+		}
+
+		position := indexOf(c.all, c.matches[j])
+		if (direction > 0 && position > current) || (direction < 0 && position < current) {
+			match = j
+
+			break
+		}
+	}
+
+	if match == -1 {
+		match = 0
+		if direction < 0 {
+			match = len(c.matches) - 1
+		}
+	}
+
+	c.focusNode(c.matches[match])
+	c.message = fmt.Sprintf("Match %d of %d for %q", match+1, len(c.matches), c.query)
+}
+
+func rootOf(n *node) *node {
+	for n != nil && n.parent != nil {
+		n = n.parent
+	}
+
+	return n
+}
+
+// sibling returns the sibling of n that is offset positions away, or nil if
+// there is none.
+func sibling(n *node, roots []*node, offset int) *node {
+	if n == nil {
+		return nil
+	}
+
+	siblings := roots
+	if n.parent != nil {
+		siblings = n.parent.children
+	}
+
+	i := indexOf(siblings, n) + offset
+	if i < 0 || i >= len(siblings) {
+		return nil
+	}
+
+	return siblings[i]
+}
+
+func countReplies(n *node) int {
+	count := len(n.children)
+
+	for _, child := range n.children {
+		count += countReplies(child)
+	}
+
+	return count
+}
+
+func indexOf(nodes []*node, n *node) int {
+	for i, candidate := range nodes {
+		if candidate == n {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// splitLines splits s into lines without the trailing empty lines.
+func splitLines(s string) []string {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+
+	for len(lines) > 0 && strings.TrimSpace(plainText(lines[len(lines)-1])) == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	return lines
+}
@@ -0,0 +1,309 @@
+package viewer_test
+
+import (
+	"testing"
+
+	"clx/bubble/viewer"
+	"clx/history"
+	"clx/item"
+	"clx/settings"
+	"clx/thread"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newComments returns a viewer for a story with these comments:
+//
+//	1 alfa
+//	  2 beta
+//	    3 gamma
+//	  4 delta
+//	5 epsilon
+//	6 [deleted]
+func newComments(t *testing.T, autoExpand bool) *viewer.Comments {
+	t.Helper()
+
+	story := &item.Item{
+		ID:    100,
+		Title: "Lorem ipsum",
+		User:  "op",
+		Comments: []*item.Item{
+			{ID: 1, User: "alfa", Content: "<p>Lorem ipsum", Comments: []*item.Item{
+				{ID: 2, User: "beta", Level: 1, Content: "<p>Dolor sit amet", Comments: []*item.Item{
+					{ID: 3, User: "gamma", Level: 2, Content: "<p>Consectetur needle adipiscing"},
+				}},
+				{ID: 4, User: "delta", Level: 1, Content: "<p>Sed do eiusmod"},
+			}},
+			{ID: 5, User: "epsilon", Content: "<p>Tempor incididunt"},
+			{ID: 6, Content: "[deleted]"},
+		},
+	}
+
+	config := settings.Default()
+	config.AutoExpandComments = autoExpand
+
+	return viewer.NewComments(story, config, 0, 100, 40)
+}
+
+func press(c *viewer.Comments, keys ...string) tea.Cmd {
+	var cmd tea.Cmd
+
+	for _, key := range keys {
+		switch key {
+		case "enter":
+			cmd = c.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+		case "esc":
+			cmd = c.Update(tea.KeyMsg{Type: tea.KeyEsc})
+
+		default:
+			cmd = c.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+		}
+	}
+
+	return cmd
+}
+
+func TestCommentsCollapseAndNavigate(t *testing.T) {
+	t.Parallel()
+
+	c := newComments(t, false)
+
+	// Replies are collapsed, so the next comment is the next thread
+	assert.Equal(t, 1, c.Focused().ID)
+	assert.Contains(t, c.View(), "3 replies hidden")
+	press(c, "j")
+	assert.Equal(t, 5, c.Focused().ID)
+
+	// The deleted comment without replies is left out
+	press(c, "j")
+	assert.Equal(t, 5, c.Focused().ID)
+
+	press(c, "k", "l", "j")
+	assert.Equal(t, 2, c.Focused().ID)
+	assert.NotContains(t, c.View(), "replies hidden")
+
+	press(c, "j")
+	assert.Equal(t, 3, c.Focused().ID)
+
+	press(c, "p")
+	assert.Equal(t, 2, c.Focused().ID)
+
+	press(c, "]")
+	assert.Equal(t, 4, c.Focused().ID)
+
+	press(c, "P")
+	assert.Equal(t, 1, c.Focused().ID)
+
+	press(c, "n")
+	assert.Equal(t, 5, c.Focused().ID)
+
+	// Collapsing a single thread keeps the other threads as they are
+	press(c, "N", "j", "h")
+	assert.Equal(t, 2, c.Focused().ID)
+	assert.Contains(t, c.View(), "1 reply hidden")
+
+	press(c, "j")
+	assert.Equal(t, 4, c.Focused().ID)
+}
+
+func TestCommentsCollapseAll(t *testing.T) {
+	t.Parallel()
+
+	c := newComments(t, true)
+
+	press(c, "j", "j")
+	assert.Equal(t, 3, c.Focused().ID)
+
+	press(c, "H")
+	assert.Equal(t, 1, c.Focused().ID)
+	assert.Contains(t, c.View(), "3 replies hidden")
+
+	press(c, "L", "G")
+	assert.Equal(t, 5, c.Focused().ID)
+}
+
+func TestCommentsSearch(t *testing.T) {
+	t.Parallel()
+
+	c := newComments(t, false)
+
+	// Matches in collapsed threads are expanded
+	press(c, "/", "n", "e", "e", "d", "l", "e", "enter")
+	assert.Equal(t, 3, c.Focused().ID)
+	assert.Contains(t, c.View(), "Match 1 of 1")
+
+	press(c, "esc", "n")
+	assert.Equal(t, 5, c.Focused().ID)
+
+	press(c, "/", "x", "y", "z", "enter")
+	assert.Equal(t, 5, c.Focused().ID)
+	assert.Contains(t, c.View(), "No comments match")
+}
+
+func TestCommentsActions(t *testing.T) {
+	t.Parallel()
+
+	c := newComments(t, false)
+
+	cmd := press(c, "j", "R")
+	require.NotNil(t, cmd)
+	assert.Equal(t, viewer.ReplyRequested{Item: c.Focused()}, cmd())
+
+	cmd = press(c, "v")
+	require.NotNil(t, cmd)
+	assert.Equal(t, viewer.UpvoteRequested{ID: 5}, cmd())
+
+	cmd = press(c, "q")
+	require.NotNil(t, cmd)
+	assert.Equal(t, viewer.Closed{}, cmd())
+}
+
+func TestCommentsReadingPosition(t *testing.T) {
+	t.Parallel()
+
+	c := newComments(t, false)
+	press(c, "j", "k", "l", "j", "j")
+	require.Equal(t, 3, c.Focused().ID)
+
+	position := c.ReadingPosition()
+	assert.Equal(t, history.ReadingPosition{
+		CollapsedComments: map[int]bool{1: false, 5: false},
+		LastReadComment:   3,
+	}, position)
+
+	restored := newComments(t, false)
+	assert.True(t, restored.RestoreReadingPosition(position))
+	assert.Equal(t, 3, restored.Focused().ID)
+	assert.NotContains(t, restored.View(), "replies hidden")
+
+	// Threads that are not in the reading position keep their default state
+	restored = newComments(t, false)
+	assert.True(t, restored.RestoreReadingPosition(history.ReadingPosition{LastReadComment: 5}))
+	assert.Equal(t, 5, restored.Focused().ID)
+	press(restored, "k")
+	assert.Contains(t, restored.View(), "3 replies hidden")
+
+	// Removed comments leave the cursor where it is
+	restored = newComments(t, false)
+	assert.False(t, restored.RestoreReadingPosition(history.ReadingPosition{LastReadComment: 99}))
+	assert.Equal(t, 1, restored.Focused().ID)
+}
+
+func TestCommentsReadingPositionWithFilter(t *testing.T) {
+	t.Parallel()
+
+	c := newComments(t, false)
+	c.RestoreReadingPosition(history.ReadingPosition{CollapsedComments: map[int]bool{1: true, 5: false}})
+
+	// The filter expands the thread of the match, but the threads keep the
+	// state that they had before it
+	c.SetFilter(thread.Filter{Text: "needle"})
+	c.SetOrder(thread.OrderNewest)
+	assert.Contains(t, c.View(), "Consectetur needle adipiscing")
+	assert.Equal(t, map[int]bool{1: true, 5: false}, c.ReadingPosition().CollapsedComments)
+
+	c.SetFilter(thread.Filter{})
+	assert.Equal(t, map[int]bool{1: true, 5: false}, c.ReadingPosition().CollapsedComments)
+}
+
+func TestCommentsSortAndFilter(t *testing.T) {
+	t.Parallel()
+
+	c := newComments(t, false)
+
+	// Matches are shown with their parents, which are expanded
+	press(c, "&", "n", "e", "e", "d", "l", "e", "enter")
+	assert.Contains(t, c.View(), `Showing 1 comment containing "needle"`)
+	press(c, "G")
+	assert.Equal(t, 3, c.Focused().ID)
+	assert.Contains(t, c.View(), "filtered • comment 3 of 3")
+
+	// The cursor stays on the same comment when the filter is cleared
+	press(c, "esc")
+	assert.Equal(t, 3, c.Focused().ID)
+	assert.Contains(t, c.View(), "comment 3 of 5")
+
+	press(c, "a")
+	assert.Contains(t, c.View(), "Showing 1 comment by gamma")
+	press(c, "a")
+	assert.Contains(t, c.View(), "Showing all comments")
+
+	press(c, "o")
+	assert.Nil(t, c.Focused())
+	assert.Contains(t, c.View(), "Showing 0 comments by op")
+	press(c, "o")
+
+	press(c, "s")
+	assert.Contains(t, c.View(), "Sorted by newest first")
+	assert.Contains(t, c.View(), "newest first • comment 3 of 5")
+}
+
+func TestCommentsNewComments(t *testing.T) {
+	t.Parallel()
+
+	story := &item.Item{
+		ID:   100,
+		User: "op",
+		Comments: []*item.Item{
+			{ID: 1, User: "alfa", Time: 10, Content: "<p>Lorem ipsum", Comments: []*item.Item{
+				{ID: 2, User: "beta", Level: 1, Time: 30, Content: "<p>Dolor sit amet"},
+			}},
+			{ID: 3, User: "gamma", Time: 20, Content: "<p>Consectetur", Comments: []*item.Item{
+				{ID: 4, User: "delta", Level: 1, Time: 40, Content: "<p>Adipiscing elit"},
+			}},
+		},
+	}
+
+	c := viewer.NewComments(story, settings.Default(), 25, 100, 40)
+
+	// The section opens at the first new comment, whose thread is expanded
+	assert.True(t, c.FocusFirstNew())
+	assert.Equal(t, 2, c.Focused().ID)
+	assert.Contains(t, c.View(), "new since your last visit")
+	assert.Contains(t, c.View(), "2 new • comment 2 of 4")
+
+	press(c, ".")
+	assert.Equal(t, 4, c.Focused().ID)
+	assert.Contains(t, c.View(), "New comment 2 of 2")
+
+	press(c, ".")
+	assert.Equal(t, 4, c.Focused().ID)
+	assert.Contains(t, c.View(), "No more new comments below")
+
+	press(c, ",")
+	assert.Equal(t, 2, c.Focused().ID)
+
+	c = viewer.NewComments(story, settings.Default(), 50, 100, 40)
+	assert.False(t, c.FocusFirstNew())
+	assert.NotContains(t, c.View(), "new since your last visit")
+}
+
+func TestCommentsRevealMuted(t *testing.T) {
+	t.Parallel()
+
+	story := &item.Item{
+		ID:   100,
+		User: "op",
+		Comments: []*item.Item{
+			{ID: 1, User: "alfa", Muted: true, Content: "<p>Lorem ipsum", Comments: []*item.Item{
+				{ID: 2, User: "beta", Level: 1, Content: "<p>Dolor sit amet"},
+			}},
+		},
+	}
+
+	config := settings.Default()
+	config.AutoExpandComments = true
+
+	c := viewer.NewComments(story, config, 0, 100, 40)
+	assert.Contains(t, c.View(), "muted comment by alfa")
+	assert.NotContains(t, c.View(), "Lorem ipsum")
+	assert.Contains(t, c.View(), "Dolor sit amet")
+
+	press(c, "l")
+	assert.NotContains(t, c.View(), "muted comment by alfa")
+	assert.Contains(t, c.View(), "Lorem ipsum")
+	assert.Contains(t, c.View(), "Dolor sit amet")
+}
@@ -0,0 +1,205 @@
+package viewer
+
+import (
+	"fmt"
+	"strings"
+
+	"clx/constants/unicode"
+	"clx/keymaps"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Pager shows text that has already been rendered, such as an article in
+// Reader Mode.
+type Pager struct {
+	frame
+
+	keymap *keymaps.Keymap
+
+	// headlines holds the lines that are marked with a zero-width space,
+	// which is what less jumps between with n and N
+	headlines []int
+	matches   []int
+}
+
+// NewPager returns a viewer for content with the keys of Reader Mode in
+// keymap.
+func NewPager(content string, keymap *keymaps.Keymap, width, height int) *Pager {
+	p := &Pager{
+		frame: newFrame(width, height, hint(keymap,
+			hintEntry{"back", []string{keymaps.ReaderQuit}},
+			hintEntry{"next/prev headline", []string{keymaps.ReaderNextHeadline, keymaps.ReaderPrevHeadline}},
+			hintEntry{"search", []string{keymaps.ReaderSearch}})),
+		keymap: keymap,
+	}
+	p.lines = splitLines(content)
+
+	for i, line := range p.lines {
+		if strings.Contains(line, unicode.ZeroWidthSpace) {
+			p.headlines = append(p.headlines, i)
+		}
+	}
+
+	return p
+}
+
+func (p *Pager) SetSize(width, height int) {
+	p.width = width
+	p.height = height
+
+	p.scrollTo(p.offset)
+}
+
+func (p *Pager) Update(msg tea.Msg) tea.Cmd {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return nil
+	}
+
+	if p.isPrompting {
+		query, submitted, cmd := p.updatePrompt(keyMsg)
+		if submitted && query != "" {
+			p.query = query
+			p.search()
+			p.jumpToMatch(1)
+		}
+
+		return cmd
+	}
+
+	p.message = ""
+	height := p.contentHeight()
+
+	if keyMsg.String() == "ctrl+c" {
+		return closeViewer
+	}
+
+	switch p.keymap.Action(keymaps.ContextReader, keyMsg.String()) {
+	case keymaps.ReaderQuit:
+		return closeViewer
+
+	case keymaps.ReaderBack:
+		if p.query != "" {
+			p.query = ""
+			p.matches = nil
+
+			return nil
+		}
+
+		return closeViewer
+
+	case keymaps.ReaderDown:
+		p.scrollBy(1)
+
+	case keymaps.ReaderUp:
+		p.scrollBy(-1)
+
+	case keymaps.ReaderHalfPageDown:
+		p.scrollBy(height / 2)
+
+	case keymaps.ReaderHalfPageUp:
+		p.scrollBy(-height / 2)
+
+	case keymaps.ReaderPageDown:
+		p.scrollBy(height)
+
+	case keymaps.ReaderPageUp:
+		p.scrollBy(-height)
+
+	case keymaps.ReaderTop:
+		p.scrollTo(0)
+
+	case keymaps.ReaderBottom:
+		p.scrollTo(len(p.lines))
+
+	case keymaps.ReaderNextHeadline:
+		if p.query != "" {
+			p.jumpToMatch(1)
+
+			return nil
+		}
+
+		p.jumpTo(p.headlines, 1)
+
+	case keymaps.ReaderPrevHeadline:
+		if p.query != "" {
+			p.jumpToMatch(-1)
+
+			return nil
+		}
+
+		p.jumpTo(p.headlines, -1)
+
+	case keymaps.ReaderSearch:
+		return p.openSearch()
+	}
+
+	return nil
+}
+
+func (p *Pager) View() string {
+	position := "top"
+
+	switch {
+	case len(p.lines) <= p.contentHeight():
+		position = "all"
+
+	case p.offset+p.contentHeight() >= len(p.lines):
+		position = "end"
+
+	case p.offset > 0:
+		position = fmt.Sprintf("%d%%", (p.offset+p.contentHeight())*100/len(p.lines))
+	}
+
+	return p.frame.view(func(int) string { return "" }, position)
+}
+
+func (p *Pager) search() {
+	query := strings.ToLower(p.query)
+	p.matches = nil
+
+	for i, line := range p.lines {
+		if strings.Contains(strings.ToLower(plainText(line)), query) {
+			p.matches = append(p.matches, i)
+		}
+	}
+}
+
+func (p *Pager) jumpToMatch(direction int) {
+	if len(p.matches) == 0 {
+		p.message = fmt.Sprintf("No lines match %q", p.query)
+
+		return
+	}
+
+	p.jumpTo(p.matches, direction)
+}
+
+// jumpTo scrolls to the next line in lines in the given direction, wrapping
+// around at the ends.
+func (p *Pager) jumpTo(lines []int, direction int) {
+	if len(lines) == 0 {
+		return
+	}
+
+	target := lines[0]
+	if direction < 0 {
+		target = lines[len(lines)-1]
+	}
+
+	for i := range lines {
+		j := i
+		if direction < 0 {
+			j = len(lines) - 1 - i
+		}
+
+		if (direction > 0 && lines[j] > p.offset) || (direction < 0 && lines[j] < p.offset) {
+			target = lines[j]
+
+			break
+		}
+	}
+
+	p.scrollTo(target)
+}
@@ -0,0 +1,209 @@
+// Package viewer shows comment sections and articles inside the TUI, as an
+// alternative to piping them into less.
+package viewer
+
+import (
+	"regexp"
+	"strings"
+
+	"clx/constants/style"
+	"clx/item"
+	"clx/keymaps"
+	"clx/utils/ints"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	text "github.com/MichaelMure/go-term-text"
+)
+
+var ansiPattern = regexp.MustCompile("\x1b\\[[0-9;]*[a-zA-Z]|\x1b\\]8;[^\x1b]*\x1b\\\\")
+
+// Viewer is implemented by Comments and Pager. The methods have pointer
+// receivers, so the viewer is updated in place.
+type Viewer interface {
+	Update(msg tea.Msg) tea.Cmd
+	View() string
+	SetSize(width, height int)
+
+	// SetStatusMessage shows a message from the surrounding program in the
+	// status bar, such as the result of an upvote. An empty message clears
+	// it.
+	SetStatusMessage(message string)
+}
+
+// Closed is sent when the user leaves the viewer.
+type Closed struct{}
+
+// ReplyRequested is sent when the user wants to reply to Item.
+type ReplyRequested struct {
+	Item *item.Item
+}
+
+// UpvoteRequested is sent when the user wants to upvote the item with ID.
+type UpvoteRequested struct {
+	ID int
+}
+
+func closeViewer() tea.Msg {
+	return Closed{}
+}
+
+type hintEntry struct {
+	description string
+	actions     []string
+}
+
+// hint returns what the status bar shows when there is nothing else to show,
+// such as "q: back • j/k: next/prev", with the first key of each action.
+// Entries with unbound actions are left out.
+func hint(keymap *keymaps.Keymap, entries ...hintEntry) string {
+	var parts []string
+
+	for _, entry := range entries {
+		var keys []string
+
+		for _, action := range entry.actions {
+			if bound := keymap.Keys(action); len(bound) != 0 {
+				keys = append(keys, keymaps.Name(bound[0]))
+			}
+		}
+
+		if len(keys) == len(entry.actions) {
+			parts = append(parts, strings.Join(keys, "/")+": "+entry.description)
+		}
+	}
+
+	return strings.Join(parts, " • ")
+}
+
+// frame holds what Comments and Pager have in common: the lines that are
+// scrolled through, the prompt for searching and the status bar.
+type frame struct {
+	width  int
+	height int
+	offset int
+	lines  []string
+
+	// hint is shown in the status bar when there is nothing else to show.
+	// message is set by the viewer itself and cleared on the next key press,
+	// statusMessage is set by the surrounding program.
+	hint          string
+	message       string
+	statusMessage string
+
+	prompt      textinput.Model
+	isPrompting bool
+	query       string
+}
+
+func newFrame(width, height int, hint string) frame {
+	prompt := textinput.New()
+	prompt.CharLimit = 200
+	prompt.SetCursorMode(textinput.CursorStatic)
+
+	return frame{width: width, height: height, hint: hint, prompt: prompt}
+}
+
+func (f *frame) SetStatusMessage(message string) {
+	f.statusMessage = message
+}
+
+// contentHeight returns the number of lines that fit above the status bar.
+func (f *frame) contentHeight() int {
+	return ints.Max(1, f.height-1)
+}
+
+func (f *frame) scrollTo(offset int) {
+	f.offset = ints.Clamp(offset, 0, ints.Max(0, len(f.lines)-f.contentHeight()))
+}
+
+func (f *frame) scrollBy(lines int) {
+	f.scrollTo(f.offset + lines)
+}
+
+// openPrompt shows a prompt that starts with symbol and value in the status
+// bar.
+func (f *frame) openPrompt(symbol string, value string) tea.Cmd {
+	f.isPrompting = true
+	f.prompt.Prompt = symbol
+	f.prompt.SetValue(value)
+	f.prompt.CursorEnd()
+
+	return f.prompt.Focus()
+}
+
+// openSearch opens the prompt for the search query.
+func (f *frame) openSearch() tea.Cmd {
+	return f.openPrompt("/", f.query)
+}
+
+// updatePrompt handles key presses while the prompt is open. It returns the
+// value and true when the value was submitted.
+func (f *frame) updatePrompt(msg tea.KeyMsg) (string, bool, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		f.isPrompting = false
+		f.prompt.Blur()
+
+		return strings.TrimSpace(f.prompt.Value()), true, nil
+
+	case "esc", "ctrl+c":
+		f.isPrompting = false
+		f.prompt.Blur()
+
+		return "", false, nil
+	}
+
+	var cmd tea.Cmd
+	f.prompt, cmd = f.prompt.Update(msg)
+
+	return "", false, cmd
+}
+
+// view renders the visible lines and the status bar. gutter returns what is
+// shown to the left of each line, and position is shown at the right of the
+// status bar.
+func (f *frame) view(gutter func(line int) string, position string) string {
+	var sb strings.Builder
+
+	for i := f.offset; i < f.offset+f.contentHeight(); i++ {
+		if i < len(f.lines) {
+			sb.WriteString(gutter(i) + f.lines[i])
+		}
+
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString(f.statusBarView(position))
+
+	return sb.String()
+}
+
+func (f *frame) statusBarView(position string) string {
+	faint := lipgloss.NewStyle().Foreground(style.GetUnselectedItemFg()).Faint(true)
+
+	left := faint.Render(f.hint)
+
+	switch {
+	case f.isPrompting:
+		left = f.prompt.View()
+
+	case f.statusMessage != "":
+		left = f.statusMessage
+
+	case f.message != "":
+		left = lipgloss.NewStyle().Foreground(style.GetUnselectedItemFg()).Render(f.message)
+	}
+
+	right := faint.Render(position)
+	spacing := ints.Max(1, f.width-text.Len(left)-text.Len(right)-1)
+
+	return " " + left + strings.Repeat(" ", spacing) + right
+}
+
+// plainText returns s without escape codes, for searching.
+func plainText(s string) string {
+	return ansiPattern.ReplaceAllString(s, "")
+}
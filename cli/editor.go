@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// scissors separates the reply from the instructions below it, the same way
+// as in git commit messages. Everything from this line on is ignored, so
+// replies can contain lines that start with '#'.
+const scissors = "# ------------------------ >8 ------------------------"
+
+const replyInstructions = `
+` + scissors + `
+# Do not modify or remove the line above. Write your reply above it;
+# everything below it is ignored. An empty reply is not posted. Separate
+# paragraphs with a blank line.
+`
+
+// Editor returns a command that opens path in the editor set in $VISUAL or
+// $EDITOR, falling back to vi.
+func Editor(path string) *exec.Cmd {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+
+	// The variable may include arguments, such as 'code --wait'
+	args := strings.Fields(editor)
+	if len(args) == 0 {
+		args = []string{"vi"}
+	}
+
+	command := exec.Command(args[0], append(args[1:], path)...)
+	command.Stdin = os.Stdin
+	command.Stdout = os.Stdout
+	command.Stderr = os.Stderr
+
+	return command
+}
+
+// NewReplyFile creates a temporary file to write a reply in. context is shown
+// below the instructions, commented out, to remind the user what they are
+// replying to. The caller should remove the file when done.
+func NewReplyFile(context string) (string, error) {
+	file, err := os.CreateTemp("", "clx-reply-*.txt")
+	if err != nil {
+		return "", fmt.Errorf("could not create reply file: %w", err)
+	}
+
+	defer file.Close()
+
+	content := replyInstructions
+	if context != "" {
+		content += "#\n# " + strings.ReplaceAll(strings.TrimSpace(context), "\n", "\n# ") + "\n"
+	}
+
+	if _, err := file.WriteString(content); err != nil {
+		return "", fmt.Errorf("could not write reply file: %w", err)
+	}
+
+	return file.Name(), nil
+}
+
+// ReadReply returns the reply that the user wrote in the file from
+// NewReplyFile, without the instructions below the scissors line.
+func ReadReply(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("could not read reply file: %w", err)
+	}
+
+	var lines []string
+
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimRight(line, " \t\r")
+		if line == scissors {
+			break
+		}
+
+		lines = append(lines, line)
+	}
+
+	return strings.TrimSpace(strings.Join(lines, "\n")), nil
+}
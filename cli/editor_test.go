@@ -0,0 +1,43 @@
+package cli_test
+
+import (
+	"os"
+	"testing"
+
+	"clx/cli"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadReply(t *testing.T) {
+	t.Parallel()
+
+	path, err := cli.NewReplyFile("Replying to comment by pg\n> #1 reason")
+	require.NoError(t, err)
+
+	defer os.Remove(path)
+
+	template, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	reply := "#1 reason: it is faster  \n\n#include <stdio.h> and #hashtags stay\n"
+	require.NoError(t, os.WriteFile(path, []byte(reply+string(template)), 0o600))
+
+	text, err := cli.ReadReply(path)
+	require.NoError(t, err)
+	assert.Equal(t, "#1 reason: it is faster\n\n#include <stdio.h> and #hashtags stay", text)
+}
+
+func TestReadReplyWithoutText(t *testing.T) {
+	t.Parallel()
+
+	path, err := cli.NewReplyFile("Replying to comment by pg")
+	require.NoError(t, err)
+
+	defer os.Remove(path)
+
+	text, err := cli.ReadReply(path)
+	require.NoError(t, err)
+	assert.Empty(t, text)
+}
@@ -4,7 +4,7 @@ import (
 	"strconv"
 
 	"clx/favorites"
-	"clx/hn/services/hybrid"
+	"clx/hn/services"
 
 	"github.com/spf13/cobra"
 )
@@ -19,11 +19,16 @@ func addCmd() *cobra.Command {
 		Run: func(cmd *cobra.Command, args []string) {
 			id, err := strconv.Atoi(args[0])
 			if err != nil {
-				panic("ID format error")
+				exitWithError("Argument must be a valid ID")
 			}
 
-			service := hybrid.Service{}
-			submission := service.FetchItem(id)
+			config := getConfig(cmd)
+			service := services.New(config)
+
+			submission, err := service.FetchItem(cmd.Context(), id)
+			if err != nil {
+				exitWithServiceError(err)
+			}
 
 			fav := favorites.New()
 			fav.Add(submission)
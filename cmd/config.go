@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"fmt"
+
+	"clx/file"
+	"clx/settings"
+
+	"github.com/spf13/cobra"
+)
+
+func configCmd() *cobra.Command {
+	configCmd := &cobra.Command{
+		Use:   "config",
+		Short: "Show, create or validate the config file",
+		Long: "Show, create or validate the config file at ~/.config/circumflex/config.env.\n\n" +
+			"Settings are read from the config file first, then from CLX_* environment variables\n" +
+			"and finally from flags, with later sources taking precedence.",
+		Args: cobra.NoArgs,
+	}
+
+	configCmd.AddCommand(configShowCmd())
+	configCmd.AddCommand(configInitCmd())
+	configCmd.AddCommand(configValidateCmd())
+
+	return configCmd
+}
+
+func configShowCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "show",
+		Short: "Print the effective config",
+		Long: "Print the effective config after the config file, environment variables and flags " +
+			"have been applied.",
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			config := getConfig(cmd)
+
+			fmt.Print(settings.Format(config))
+		},
+	}
+}
+
+func configInitCmd() *cobra.Command {
+	var force bool
+
+	initCmd := &cobra.Command{
+		Use:   "init",
+		Short: "Write a config file with the default settings",
+		Long: "Write a config file to ~/.config/circumflex/config.env where every setting is " +
+			"commented out and set to its default value.",
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			path := file.PathToConfigFile()
+
+			if file.Exists(path) && !force {
+				exitWithError(path + " already exists. Use --force to overwrite it.")
+			}
+
+			if err := file.WriteToFile(path, settings.DefaultFile()); err != nil {
+				exitWithError("Could not write config file: " + err.Error())
+			}
+
+			println("Config file written to " + path)
+		},
+	}
+
+	initCmd.Flags().BoolVar(&force, "force", false, "overwrite an existing config file")
+
+	return initCmd
+}
+
+func configValidateCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "validate [path]",
+		Short: "Check a config file for errors",
+		Long: "Check a config file for errors. If no path is given, the config file at " +
+			"~/.config/circumflex/config.env is checked.",
+		Args:                  cobra.MaximumNArgs(1),
+		DisableFlagsInUseLine: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			path := file.PathToConfigFile()
+			if len(args) == 1 {
+				path = args[0]
+			}
+
+			if !file.Exists(path) {
+				exitWithError(path + " does not exist")
+			}
+
+			if err := settings.LoadFile(path, settings.Default()); err != nil {
+				exitWithError(path + ":\n" + err.Error())
+			}
+
+			println(path + " is valid")
+		},
+	}
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"os"
+	"strconv"
+	"strings"
+
+	"clx/export"
+	"clx/hn/services"
+
+	"github.com/spf13/cobra"
+)
+
+func exportCmd() *cobra.Command {
+	var format string
+
+	exportCmd := &cobra.Command{
+		Use:   "export <id>",
+		Short: "Print a story and its comments as Markdown, HTML, JSON or plain text",
+		Long: "Print a story and its comments with authors, times and permalinks to standard output.\n\n" +
+			"The formats are md (Markdown), html (a standalone page), json and txt (plain text).",
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			id, err := strconv.Atoi(args[0])
+			if err != nil {
+				exitWithError("Argument must be a valid ID")
+			}
+
+			if !isFormat(format) {
+				exitWithError("Format must be one of " + strings.Join(export.Formats, ", "))
+			}
+
+			config := getConfig(cmd)
+			service := services.New(config)
+
+			story, err := service.FetchComments(cmd.Context(), id)
+			if err != nil {
+				exitWithServiceError(err)
+			}
+
+			if err := export.Write(os.Stdout, story, format, config); err != nil {
+				exitWithError("Could not export: " + err.Error())
+			}
+		},
+	}
+
+	exportCmd.Flags().StringVarP(&format, "format", "f", export.FormatMarkdown,
+		"output format: "+strings.Join(export.Formats, ", "))
+
+	return exportCmd
+}
+
+func isFormat(format string) bool {
+	for _, f := range export.Formats {
+		if f == format {
+			return true
+		}
+	}
+
+	return false
+}
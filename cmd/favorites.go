@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"fmt"
+
+	"clx/favorites"
+	"clx/hn"
+	"clx/hn/services"
+
+	"github.com/spf13/cobra"
+)
+
+func favoritesCmd() *cobra.Command {
+	favoritesCmd := &cobra.Command{
+		Use:   "favorites",
+		Short: "Manage the list of favorites",
+		Long:  "Manage the list of favorites in ~/.config/circumflex/favorites.json.",
+		Args:  cobra.NoArgs,
+	}
+
+	favoritesCmd.AddCommand(favoritesSyncCmd())
+
+	return favoritesCmd
+}
+
+func favoritesSyncCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "sync",
+		Short: "Sync favorites with Hacker News",
+		Long: "Add the stories that are favorited on Hacker News to the local favorites and favorite the\n" +
+			"local favorites on Hacker News. Nothing is removed on either side. Requires 'clx login'.",
+		Args:                  cobra.NoArgs,
+		DisableFlagsInUseLine: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			config := getConfig(cmd)
+			account := getAccount(config)
+			service := services.New(config)
+
+			remoteIDs, err := account.FetchFavorites(cmd.Context())
+			if err != nil {
+				exitWithServiceError(err)
+			}
+
+			fav := favorites.New()
+
+			var localIDs []int
+			for _, it := range fav.GetItems() {
+				localIDs = append(localIDs, it.ID)
+			}
+
+			added, favorited, failed := 0, 0, 0
+
+			for _, id := range missingIDs(remoteIDs, localIDs) {
+				it, err := service.FetchItem(cmd.Context(), id)
+				if err != nil {
+					fmt.Printf("Could not fetch item %d: %s\n", id, hn.ErrorMessage(err))
+					failed++
+
+					continue
+				}
+
+				fav.Add(it)
+				added++
+			}
+
+			if added > 0 {
+				fav.Write()
+			}
+
+			for _, id := range missingIDs(localIDs, remoteIDs) {
+				if err := account.Favorite(cmd.Context(), id); err != nil {
+					fmt.Printf("Could not favorite item %d on Hacker News: %s\n", id, hn.ErrorMessage(err))
+					failed++
+
+					continue
+				}
+
+				favorited++
+			}
+
+			fmt.Printf("Added %d favorites from Hacker News and favorited %d stories on Hacker News\n",
+				added, favorited)
+
+			if failed > 0 {
+				exitWithError(fmt.Sprintf("%d items could not be synced", failed))
+			}
+		},
+	}
+}
+
+// missingIDs returns the IDs in ids that are not in other, in order.
+func missingIDs(ids []int, other []int) []int {
+	existing := make(map[int]bool, len(other))
+	for _, id := range other {
+		existing[id] = true
+	}
+
+	var missing []int
+
+	for _, id := range ids {
+		if !existing[id] {
+			missing = append(missing, id)
+		}
+	}
+
+	return missing
+}
@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"bytes"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"clx/constants/category"
+	"clx/favorites"
+	"clx/feed"
+	"clx/hn"
+	"clx/hn/services"
+	"clx/hn/services/muted"
+	"clx/item"
+	"clx/watchlist"
+
+	"github.com/spf13/cobra"
+)
+
+// categoryPages are the pages on Hacker News that the categories follow.
+var categoryPages = map[int]string{
+	category.FrontPage: "news",
+	category.New:       "newest",
+	category.Ask:       "ask",
+	category.Show:      "show",
+	category.Best:      "best",
+	category.Jobs:      "jobs",
+	category.Past:      "front",
+	category.Active:    "active",
+}
+
+func feedCmd() *cobra.Command {
+	var (
+		categoryName string
+		search       string
+		user         string
+		useFavorites bool
+		limit        int
+		format       string
+		output       string
+		onlyWatched  bool
+	)
+
+	feedCmd := &cobra.Command{
+		Use:   "feed",
+		Short: "Print a category, search, user or the favorites as a feed",
+		Long: "Print the stories of a category, a search, the submissions of a user or the favorites as an\n" +
+			"RSS 2.0, Atom or JSON Feed document, to subscribe to them in a feed reader.\n\n" +
+			"Muted stories are left out, except from the favorites, and the labels of matching watchlist\n" +
+			"rules are added to the entries as categories.",
+		Example: "  clx feed --category best --limit 50 --format atom --output ~/feeds/best.xml\n" +
+			"  clx feed --search 'go points:100' --format json\n" +
+			"  clx feed --category new --watched",
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			cat, ok := parseListCategory(categoryName)
+			if !ok {
+				exitWithError("Category must be one of " + strings.Join(listCategories(), ", "))
+			}
+
+			if limit < 1 || limit > maxListLimit {
+				exitWithError("Limit must be between 1 and " + strconv.Itoa(maxListLimit))
+			}
+
+			if !isFeedFormat(format) {
+				exitWithError("Format must be one of " + strings.Join(feed.Formats, ", "))
+			}
+
+			config := getConfig(cmd)
+			service := muted.New(services.New(config), loadKillfile())
+			webURL := strings.TrimRight(config.WebURL, "/")
+
+			var (
+				f   *feed.Feed
+				err error
+			)
+
+			switch {
+			case useFavorites:
+				f = &feed.Feed{Title: "circumflex: favorites", Link: webURL, Stories: favorites.New().GetItems()}
+
+			case search != "":
+				query, parseErr := hn.ParseSearchQuery(search)
+				if parseErr != nil {
+					exitWithError("Invalid search: " + parseErr.Error())
+				}
+
+				// Comments in the results would be entries without a title
+				query.Type = hn.SearchTypeStory
+
+				f = &feed.Feed{Title: "Hacker News: " + search, Link: webURL}
+				f.Stories, err = service.Search(cmd.Context(), query, 0, limit)
+
+			case user != "":
+				query := hn.SearchQuery{Author: user, ByDate: true, Type: hn.SearchTypeStory}
+
+				f = &feed.Feed{Title: "Hacker News: " + user, Link: webURL + "/submitted?id=" + url.QueryEscape(user)}
+				f.Stories, err = service.Search(cmd.Context(), query, 0, limit)
+
+			default:
+				f = &feed.Feed{Title: "Hacker News: " + categoryName, Link: webURL + "/" + categoryPages[cat]}
+				f.Stories, err = service.FetchItems(cmd.Context(), 0, limit, cat)
+			}
+
+			if err != nil {
+				exitWithServiceError(err)
+			}
+
+			f.WebURL = webURL
+			f.Watchlist = config.Watchlist
+
+			if onlyWatched {
+				f.Stories = watchedStories(config.Watchlist, f.Stories)
+			}
+
+			if len(f.Stories) > limit {
+				f.Stories = f.Stories[:limit]
+			}
+
+			var buf bytes.Buffer
+			if err := f.Write(&buf, format); err != nil {
+				exitWithError("Could not write the feed: " + err.Error())
+			}
+
+			if output == "" {
+				_, _ = os.Stdout.Write(buf.Bytes())
+
+				return
+			}
+
+			if err := os.WriteFile(output, buf.Bytes(), 0o644); err != nil {
+				exitWithError("Could not write the feed: " + err.Error())
+			}
+		},
+	}
+
+	feedCmd.Flags().StringVar(&categoryName, "category", "top",
+		"category to follow: "+strings.Join(listCategories(), ", "))
+	feedCmd.Flags().StringVar(&search, "search", "",
+		"follow the stories that match a search, with the same filters as the search prompt")
+	feedCmd.Flags().StringVar(&user, "user", "", "follow the stories submitted by a user")
+	feedCmd.Flags().BoolVar(&useFavorites, "favorites", false, "follow the favorites")
+	feedCmd.Flags().IntVar(&limit, "limit", 30, "number of entries, at most "+strconv.Itoa(maxListLimit))
+	feedCmd.Flags().StringVarP(&format, "format", "f", feed.FormatRSS,
+		"feed format: "+strings.Join(feed.Formats, ", "))
+	feedCmd.Flags().StringVar(&output, "output", "", "write the feed to a file instead of standard output")
+	feedCmd.Flags().BoolVar(&onlyWatched, "watched", false, "only include stories that match the watchlist")
+
+	feedCmd.MarkFlagsMutuallyExclusive("category", "search", "user", "favorites")
+
+	return feedCmd
+}
+
+// watchedStories returns the stories whose titles match a rule in w.
+func watchedStories(w watchlist.Watchlist, stories []*item.Item) []*item.Item {
+	var watched []*item.Item
+
+	for _, story := range stories {
+		if len(w.Matching(story.Title)) != 0 {
+			watched = append(watched, story)
+		}
+	}
+
+	return watched
+}
+
+func isFeedFormat(format string) bool {
+	for _, f := range feed.Formats {
+		if f == format {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,188 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"clx/constants/category"
+	"clx/export"
+	"clx/history"
+	"clx/hn"
+	"clx/hn/services"
+	"clx/hn/services/muted"
+	"clx/item"
+
+	"github.com/spf13/cobra"
+)
+
+// maxListLimit is the most stories that are fetched at once.
+const maxListLimit = 500
+
+func listCmd() *cobra.Command {
+	var (
+		categoryName string
+		limit        int
+		format       string
+		date         string
+	)
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "Print the stories of a category",
+		Long: "Print the stories of a category to standard output, for use in scripts and pipes.\n\n" +
+			"The formats are json (an array), jsonl (one object per line), tsv (with a header line)\n" +
+			"and plain. Stories are fetched with the same backends and cache as the main view, and muted\n" +
+			"stories are left out. The past category shows yesterday's front page unless --date is given.",
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			cat, ok := parseListCategory(categoryName)
+			if !ok {
+				exitWithError("Category must be one of " + strings.Join(listCategories(), ", "))
+			}
+
+			if limit < 1 || limit > maxListLimit {
+				exitWithError("Limit must be between 1 and " + strconv.Itoa(maxListLimit))
+			}
+
+			if !isListFormat(format) {
+				exitWithError("Format must be one of " + strings.Join(export.ListFormats, ", "))
+			}
+
+			day := parseListDate(date, cat)
+
+			config := getConfig(cmd)
+			service := muted.New(services.New(config), loadKillfile())
+
+			stories, err := fetchList(cmd.Context(), service, cat, day, limit)
+			if err != nil {
+				exitWithServiceError(err)
+			}
+
+			isRead := func(int) bool { return false }
+			if !config.DoNotMarkSubmissionsAsRead && !config.DebugMode {
+				isRead = history.NewPersistentHistory().Contains
+			}
+
+			if err := export.WriteList(os.Stdout, stories, format, isRead, config.WebURL); err != nil {
+				exitWithError("Could not print stories: " + err.Error())
+			}
+		},
+	}
+
+	listCmd.Flags().StringVar(&categoryName, "category", "top",
+		"category to print: "+strings.Join(listCategories(), ", "))
+	listCmd.Flags().IntVar(&limit, "limit", 30, "number of stories to print, at most "+strconv.Itoa(maxListLimit))
+	listCmd.Flags().StringVarP(&format, "format", "f", export.ListFormatPlain,
+		"output format: "+strings.Join(export.ListFormats, ", "))
+	listCmd.Flags().StringVar(&date, "date", "", "day of the past category as YYYY-MM-DD, yesterday by default")
+
+	return listCmd
+}
+
+// fetchList returns the first limit stories of cat, or of the front page of
+// day if it is set. Muted stories are left out, so batches are fetched until
+// there are limit stories or the list ends.
+func fetchList(ctx context.Context, service hn.Service, cat int, day time.Time, limit int) ([]*item.Item, error) {
+	stories := []*item.Item{}
+	seen := make(map[int]bool)
+
+	for offset := 0; len(stories) < limit; {
+		itemsToFetch := limit - len(stories)
+		batchCtx, reachedEnd := hn.WithEndOfList(ctx)
+
+		var (
+			batch []*item.Item
+			err   error
+		)
+
+		if day.IsZero() {
+			batch, err = service.FetchItems(batchCtx, offset, itemsToFetch, cat)
+		} else {
+			batch, err = service.Search(batchCtx, hn.PastFrontPageQuery(day), offset, itemsToFetch)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		// Lists change while they are paged through, so a story can show
+		// up in two batches
+		for _, story := range batch {
+			if !seen[story.ID] && len(stories) < limit {
+				seen[story.ID] = true
+				stories = append(stories, story)
+			}
+		}
+
+		if reachedEnd() {
+			break
+		}
+
+		offset += itemsToFetch
+	}
+
+	return stories, nil
+}
+
+// parseListDate returns the day of the past category given as YYYY-MM-DD, or
+// the zero time if no date is given.
+func parseListDate(date string, cat int) time.Time {
+	if date == "" {
+		return time.Time{}
+	}
+
+	if cat != category.Past {
+		exitWithError("Date can only be given for the past category")
+	}
+
+	day, err := hn.ParseDay(date)
+	if err != nil {
+		exitWithError("Date must be a date such as 2022-12-31")
+	}
+
+	if day.After(hn.Yesterday()) {
+		exitWithError("Date must be yesterday or earlier, since the front page of today is not over yet")
+	}
+
+	return day
+}
+
+// listCategories returns the names of the categories that can be printed.
+// The front page is called top, like on Hacker News.
+func listCategories() []string {
+	names := []string{"top"}
+
+	for _, name := range category.Selectable() {
+		if name != category.Name(category.Replies) {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+func parseListCategory(name string) (int, bool) {
+	if strings.EqualFold(strings.TrimSpace(name), "top") {
+		return category.FrontPage, true
+	}
+
+	cat, ok := category.Parse(name)
+	if !ok || cat == category.Replies {
+		return 0, false
+	}
+
+	return cat, true
+}
+
+func isListFormat(format string) bool {
+	for _, f := range export.ListFormats {
+		if f == format {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"clx/constants/category"
+	"clx/hn"
+	"clx/hn/services/muted"
+	"clx/item"
+	"clx/killfile"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pagedService serves the stories in pages. The other methods of hn.Service
+// are not used.
+type pagedService struct {
+	hn.Service
+
+	stories []*item.Item
+	calls   int
+}
+
+func (s *pagedService) FetchItems(ctx context.Context, offset int, itemsToFetch int, _ int) ([]*item.Item, error) {
+	s.calls++
+
+	if offset+itemsToFetch >= len(s.stories) {
+		hn.SetEndOfList(ctx)
+
+		return s.stories[offset:], nil
+	}
+
+	return s.stories[offset : offset+itemsToFetch], nil
+}
+
+func ids(stories []*item.Item) []int {
+	result := make([]int, 0, len(stories))
+
+	for _, story := range stories {
+		result = append(result, story.ID)
+	}
+
+	return result
+}
+
+func TestFetchListLeavesOutMutedStories(t *testing.T) {
+	t.Parallel()
+
+	next := &pagedService{stories: []*item.Item{
+		{ID: 1, User: "alfa"},
+		{ID: 2, User: "beta"},
+		{ID: 3, User: "alfa"},
+		{ID: 4, User: "gamma"},
+		{ID: 5, User: "delta"},
+	}}
+
+	k := killfile.New(filepath.Join(t.TempDir(), "killfile"))
+	require.NoError(t, k.Mute(killfile.KindUser, "alfa"))
+
+	service := muted.New(next, k)
+
+	stories, err := fetchList(context.Background(), service, category.New, time.Time{}, 3)
+	require.NoError(t, err)
+	assert.Equal(t, []int{2, 4, 5}, ids(stories))
+	assert.Equal(t, 2, next.calls)
+
+	// The list ends before the limit is reached
+	stories, err = fetchList(context.Background(), service, category.New, time.Time{}, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []int{2, 4, 5}, ids(stories))
+}
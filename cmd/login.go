@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"clx/file"
+	"clx/hn"
+	"clx/hn/services"
+	"clx/hn/services/web"
+	"clx/settings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+func loginCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "login [username]",
+		Short: "Log in to Hacker News",
+		Long: "Log in to Hacker News to upvote, favorite and reply from circumflex. The password is only\n" +
+			"sent to Hacker News; the session cookie is stored in ~/.config/circumflex/session.json.",
+		Args:                  cobra.MaximumNArgs(1),
+		DisableFlagsInUseLine: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			config := getConfig(cmd)
+
+			username := ""
+			if len(args) == 1 {
+				username = args[0]
+			}
+
+			if username == "" {
+				fmt.Print("Username: ")
+
+				line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+				if err != nil {
+					exitWithError("Could not read username: " + err.Error())
+				}
+
+				username = strings.TrimSpace(line)
+			}
+
+			fmt.Print("Password: ")
+
+			password, err := term.ReadPassword(int(os.Stdin.Fd()))
+			fmt.Println()
+
+			if err != nil {
+				exitWithError("Could not read password: " + err.Error())
+			}
+
+			session, err := web.New(config.WebURL, nil).Login(cmd.Context(), username, string(password))
+			if err != nil {
+				exitWithServiceError(err)
+			}
+
+			if err := web.SaveSession(file.PathToSessionFile(), session); err != nil {
+				exitWithError(err.Error())
+			}
+
+			println("Logged in as " + session.Username)
+		},
+	}
+}
+
+func logoutCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:                   "logout",
+		Short:                 "Log out of Hacker News",
+		Long:                  "Remove the session cookie that 'clx login' stored.",
+		Args:                  cobra.NoArgs,
+		DisableFlagsInUseLine: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := web.RemoveSession(file.PathToSessionFile()); err != nil {
+				exitWithError(err.Error())
+			}
+
+			println("Logged out")
+		},
+	}
+}
+
+// getAccount returns the account of the logged in user and exits if there is
+// none.
+func getAccount(config *settings.Config) hn.Account {
+	account, err := services.NewAccount(config)
+	if err != nil {
+		exitWithError(err.Error())
+	}
+
+	if account.Username() == "" {
+		exitWithError(hn.ErrorMessage(hn.ErrUnauthorized))
+	}
+
+	return account
+}
@@ -2,13 +2,17 @@ package cmd
 
 import (
 	_ "embed"
-	"os"
 	"strconv"
 
+	"clx/bubble"
+	"clx/bubble/viewer"
+	"clx/keymaps"
 	"clx/less"
 	"clx/reader"
+	"clx/screen"
+	"clx/settings"
 
-	"clx/hn/services/hybrid"
+	"clx/hn/services"
 
 	"clx/cli"
 	"github.com/spf13/cobra"
@@ -23,30 +27,41 @@ func readCmd() *cobra.Command {
 		Run: func(cmd *cobra.Command, args []string) {
 			id, convErr := strconv.Atoi(args[0])
 			if convErr != nil {
-				println("Argument must be a valid ID")
-				os.Exit(1)
+				exitWithError("Argument must be a valid ID")
 			}
 
-			service := new(hybrid.Service)
+			config := getConfig(cmd)
+			service := services.New(config)
 
-			item := service.FetchItem(id)
+			item, err := service.FetchItem(cmd.Context(), id)
+			if err != nil {
+				exitWithServiceError(err)
+			}
 
 			if item.URL == "" {
-				println("Could not find any links associated with the ID " + args[0])
-				os.Exit(1)
+				exitWithError("Could not find any links associated with the ID " + args[0])
+			}
+
+			article, err := reader.GetArticle(item.URL, item.Title, config.CommentWidth, config.IndentationSymbol)
+			if err != nil {
+				exitWithError("Could not fetch article: " + err.Error())
 			}
 
-			config := getConfig()
+			if config.Pager == settings.PagerNative {
+				bubble.RunViewer(viewer.NewPager(article, config.Keymap, screen.GetTerminalWidth(),
+					screen.GetTerminalHeight()), nil)
 
-			article, _ := reader.GetArticle(item.URL, item.Title, config.CommentWidth, config.IndentationSymbol)
+				return
+			}
 
-			lesskey := less.NewLesskey()
+			lesskey := less.NewLesskey(config.Keymap, keymaps.ContextReader)
+			config.LesskeyPath = lesskey.GetPath()
+			defer lesskey.Remove()
 
 			command := cli.Less(article, config)
 
 			if err := command.Run(); err != nil {
-				defer lesskey.Remove()
-				panic(err)
+				exitWithError("Could not run less: " + err.Error())
 			}
 		},
 	}
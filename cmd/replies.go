@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"clx/export"
+	"clx/history"
+	"clx/hn"
+	"clx/hn/services"
+	"clx/hn/services/muted"
+
+	"github.com/spf13/cobra"
+)
+
+func repliesCmd() *cobra.Command {
+	var since time.Duration
+
+	repliesCmd := &cobra.Command{
+		Use:   "replies <username>",
+		Short: "Show new replies to a user's stories and comments",
+		Long: "Show the replies to the latest stories and comments of a user that were posted since the\n" +
+			"last check, with a link to each reply in its thread. The first check shows the replies of\n" +
+			"the last week. The time of the check is stored in ~/.cache/circumflex/replies.json.",
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			name := args[0]
+			config := getConfig(cmd)
+
+			checked, err := history.LoadRepliesChecked(history.PathToRepliesFile())
+			if err != nil {
+				exitWithError(err.Error())
+			}
+
+			now := time.Now()
+
+			after := checked.After(name, now)
+
+			if since != 0 {
+				after = now.Add(-since)
+			}
+
+			replies, err := muted.New(services.New(config), loadKillfile()).FetchReplies(cmd.Context(), name, after)
+			if err != nil {
+				exitWithServiceError(err)
+			}
+
+			printReplies(replies, name, after, config.WebURL, config.CommentWidth)
+
+			// Looking back with --since does not change which replies are new
+			if since != 0 || config.DoNotMarkSubmissionsAsRead {
+				return
+			}
+
+			if err := checked.SetAndWriteToDisk(name, now); err != nil {
+				exitWithError(err.Error())
+			}
+		},
+	}
+
+	repliesCmd.Flags().DurationVar(&since, "since", 0,
+		"show the replies of this period, such as 24h, instead of the ones since the last check")
+
+	return repliesCmd
+}
+
+func printReplies(replies []*hn.Reply, name string, after time.Time, webURL string, commentWidth int) {
+	checked := after.Format("2006-01-02 15:04")
+
+	switch len(replies) {
+	case 0:
+		fmt.Printf("No new replies to %s since %s\n", name, checked)
+
+		return
+
+	case 1:
+		fmt.Printf("1 new reply to %s since %s\n", name, checked)
+
+	default:
+		fmt.Printf("%d new replies to %s since %s\n", len(replies), name, checked)
+	}
+
+	for _, reply := range replies {
+		posted := time.Unix(reply.Time, 0).Format("2006-01-02 15:04")
+
+		fmt.Printf("\n%s · %s · %s\n", reply.Author, posted, reply.StoryTitle)
+		fmt.Println(export.PlainText(reply.Text, "    ", commentWidth))
+		fmt.Println("    " + threadURL(webURL, reply))
+	}
+}
+
+// threadURL links to the reply below the story or comment it answers.
+func threadURL(webURL string, reply *hn.Reply) string {
+	return webURL + "/item?id=" + strconv.Itoa(reply.ParentID) + "#" + strconv.Itoa(reply.ID)
+}
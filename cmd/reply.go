@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"os"
+	"strconv"
+
+	"clx/cli"
+	"clx/hn/services"
+
+	"github.com/spf13/cobra"
+)
+
+func replyCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "reply <id>",
+		Short: "Reply to a story or comment by ID",
+		Long: "Write a reply to a story or comment in $VISUAL or $EDITOR and post it. Requires " +
+			"'clx login'.",
+		Args:                  cobra.ExactArgs(1),
+		DisableFlagsInUseLine: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			id, err := strconv.Atoi(args[0])
+			if err != nil {
+				exitWithError("Argument must be a valid ID")
+			}
+
+			config := getConfig(cmd)
+			account := getAccount(config)
+
+			parent, err := services.New(config).FetchItem(cmd.Context(), id)
+			if err != nil {
+				exitWithServiceError(err)
+			}
+
+			parentTitle := parent.Title
+			if parentTitle == "" {
+				parentTitle = "comment by " + parent.User
+			}
+
+			path, err := cli.NewReplyFile("Replying to " + parentTitle)
+			if err != nil {
+				exitWithError(err.Error())
+			}
+
+			editorErr := cli.Editor(path).Run()
+			text, err := cli.ReadReply(path)
+			_ = os.Remove(path)
+
+			if editorErr != nil {
+				exitWithError("Could not run the editor: " + editorErr.Error())
+			}
+
+			if err != nil {
+				exitWithError(err.Error())
+			}
+
+			if text == "" {
+				exitWithError("Reply is empty, nothing was posted")
+			}
+
+			if err := account.Reply(cmd.Context(), id, text); err != nil {
+				exitWithServiceError(err)
+			}
+
+			println("Reply posted")
+		},
+	}
+}
@@ -7,9 +7,15 @@ import (
 	"clx/app"
 	"clx/bubble"
 	"clx/cli"
+	"clx/file"
+	"clx/hn"
 	"clx/indent"
+	"clx/keymaps"
+	"clx/killfile"
 	"clx/less"
 	"clx/settings"
+	"clx/theme"
+	"clx/watchlist"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/logrusorgru/aurora/v3"
@@ -29,6 +35,13 @@ var (
 	forceDarkMode               bool
 	autoExpandComments          bool
 	noLessVerify                bool
+	firebaseURL                 string
+	algoliaURL                  string
+	hackerWebURL                string
+	webURL                      string
+	offline                     bool
+	themeName                   string
+	splitPane                   bool
 )
 
 func Root() *cobra.Command {
@@ -37,16 +50,9 @@ func Root() *cobra.Command {
 		Short:   "\n" + aurora.Magenta("circumflex").Italic().String() + " is a command line tool for browsing Hacker News in your terminal",
 		Version: app.Version,
 		Run: func(cmd *cobra.Command, args []string) {
-			config := getConfig()
-			config.IndentationSymbol = indent.GetIndentSymbol(hideIndentSymbol)
+			config := getConfig(cmd)
 
-			verifyLess(noLessVerify)
-
-			lesskey := less.NewLesskey()
-			config.LesskeyPath = lesskey.GetPath()
-			defer lesskey.Remove()
-
-			bubble.Run(config)
+			runTUI(config, bubble.Run)
 		},
 	}
 
@@ -54,8 +60,21 @@ func Root() *cobra.Command {
 
 	rootCmd.AddCommand(addCmd())
 	rootCmd.AddCommand(clearCmd())
+	rootCmd.AddCommand(configCmd())
+	rootCmd.AddCommand(exportCmd())
+	rootCmd.AddCommand(favoritesCmd())
+	rootCmd.AddCommand(feedCmd())
+	rootCmd.AddCommand(listCmd())
+	rootCmd.AddCommand(loginCmd())
+	rootCmd.AddCommand(logoutCmd())
 	rootCmd.AddCommand(viewCmd())
 	rootCmd.AddCommand(readCmd())
+	rootCmd.AddCommand(replyCmd())
+	rootCmd.AddCommand(repliesCmd())
+	rootCmd.AddCommand(searchCmd())
+	rootCmd.AddCommand(themesCmd())
+	rootCmd.AddCommand(upvoteCmd())
+	rootCmd.AddCommand(userCmd())
 	rootCmd.AddCommand(versionCmd())
 
 	configureFlags(rootCmd)
@@ -82,29 +101,71 @@ func configureFlags(rootCmd *cobra.Command) {
 		"force use light color scheme")
 	rootCmd.PersistentFlags().BoolVar(&forceDarkMode, "force-dark-mode", false,
 		"force use dark color scheme")
+	rootCmd.PersistentFlags().StringVar(&themeName, "theme", settings.Default().Theme,
+		"set the color theme, see 'clx themes'")
+	rootCmd.PersistentFlags().BoolVar(&splitPane, "split-pane", false,
+		"show the selected story and its top comments next to the list on wide screens")
 	rootCmd.PersistentFlags().BoolVarP(&autoExpandComments, "auto-expand", "a", false,
 		"automatically expand all replies upon entering the comment section")
 	rootCmd.PersistentFlags().BoolVar(&noLessVerify, "no-less-verify", false,
 		"disable checking less version on startup")
 
+	rootCmd.PersistentFlags().StringVar(&firebaseURL, "firebase-url", "",
+		"set the base URL of the Firebase API")
+	rootCmd.PersistentFlags().StringVar(&algoliaURL, "algolia-url", "",
+		"set the base URL of the Algolia API")
+	rootCmd.PersistentFlags().StringVar(&hackerWebURL, "hackerweb-url", "",
+		"set the base URL of the hackerweb API")
+	rootCmd.PersistentFlags().StringVar(&webURL, "web-url", "",
+		"set the base URL of the Hacker News website")
+
+	rootCmd.PersistentFlags().BoolVar(&offline, "offline", false,
+		"only show stories and comments that have been cached")
+
 	rootCmd.PersistentFlags().BoolVarP(&debugMode, "debug-mode", "q", false,
 		"enable debug mode (offline mode) by using mock data for the endpoints")
 	rootCmd.Flag("debug-mode").Hidden = true
 }
 
-func getConfig() *settings.Config {
+// getConfig returns the settings from the config file, overridden by
+// environment variables and then by the flags that were set on cmd.
+func getConfig(cmd *cobra.Command) *settings.Config {
 	config := settings.Default()
 
-	config.CommentWidth = commentWidth
-	config.DisableHeadlineHighlighting = disableHeadlineHighlighting
-	config.DisableCommentHighlighting = disableCommentHighlighting
-	config.DoNotMarkSubmissionsAsRead = disableHistory
-	config.EnableNerdFonts = enableNerdFont
-	config.HideIndentSymbol = hideIndentSymbol
-	config.AutoExpandComments = autoExpandComments
-	config.DisableEmojis = disableEmojis
+	if err := settings.LoadFile(file.PathToConfigFile(), config); err != nil {
+		exitWithError("Could not load " + file.PathToConfigFile() + ":\n" + err.Error() +
+			"\n\nRun 'clx config validate' to check the config file")
+	}
+
+	if err := settings.ApplyEnvironment(config); err != nil {
+		exitWithError(err.Error())
+	}
+
+	for _, option := range settings.Options() {
+		if !cmd.Flags().Changed(option.Name) {
+			continue
+		}
+
+		if err := option.Set(config, cmd.Flags().Lookup(option.Name).Value.String()); err != nil {
+			exitWithError("Invalid value for --" + option.Name + ": " + err.Error())
+		}
+	}
+
+	rules, err := watchlist.Load(file.PathToWatchlist())
+	if err != nil {
+		exitWithError("Invalid watchlist " + file.PathToWatchlist() + ": " + err.Error())
+	}
+
+	config.Watchlist = rules
+
+	keymap, err := keymaps.Load(file.PathToKeymap())
+	if err != nil {
+		exitWithError("Invalid keymap " + file.PathToKeymap() + ": " + err.Error())
+	}
+
+	config.Keymap = keymap
 	config.DebugMode = debugMode
-	config.NoLessVerify = noLessVerify
+	config.IndentationSymbol = indent.GetIndentSymbol(config.HideIndentSymbol)
 
 	if forceLightMode {
 		lipgloss.SetHasDarkBackground(false)
@@ -114,9 +175,56 @@ func getConfig() *settings.Config {
 		lipgloss.SetHasDarkBackground(true)
 	}
 
+	theme.Set(loadTheme(config.Theme))
+
 	return config
 }
 
+// loadTheme returns the theme with the given name, or the monochrome theme if
+// NO_COLOR is set.
+func loadTheme(name string) *theme.Theme {
+	if theme.NoColor() {
+		name = theme.Monochrome
+	}
+
+	t, err := theme.Load(name, file.PathToThemesDirectory())
+	if err != nil {
+		exitWithError("Could not load the theme: " + err.Error())
+	}
+
+	return t
+}
+
+// runTUI prepares less if it is used as the pager and then calls run, which
+// starts the TUI.
+func runTUI(config *settings.Config, run func(config *settings.Config)) {
+	// The list loads the killfile again, this only reports mistakes in it
+	loadKillfile()
+
+	if config.Pager == settings.PagerNative {
+		run(config)
+
+		return
+	}
+
+	verifyLess(config.NoLessVerify)
+
+	lesskey := less.NewLesskey(config.Keymap, keymaps.ContextComments)
+	config.LesskeyPath = lesskey.GetPath()
+	defer lesskey.Remove()
+
+	run(config)
+}
+
+func loadKillfile() *killfile.Killfile {
+	k, err := killfile.Load(file.PathToKillfile())
+	if err != nil {
+		exitWithError("Invalid killfile " + file.PathToKillfile() + ": " + err.Error())
+	}
+
+	return k
+}
+
 func verifyLess(noLessVerify bool) {
 	if noLessVerify {
 		return
@@ -138,3 +246,12 @@ func verifyLess(noLessVerify bool) {
 		os.Exit(1)
 	}
 }
+
+func exitWithError(message string) {
+	println(message)
+	os.Exit(1)
+}
+
+func exitWithServiceError(err error) {
+	exitWithError(hn.ErrorMessage(err) + " (" + err.Error() + ")")
+}
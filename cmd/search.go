@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"strconv"
+	"strings"
+
+	"clx/bubble"
+	"clx/hn"
+	"clx/settings"
+
+	"github.com/spf13/cobra"
+)
+
+func searchCmd() *cobra.Command {
+	searchCmd := &cobra.Command{
+		Use:   "search <query>",
+		Short: "Search for stories and comments",
+		Long: "Search for stories and comments with Algolia and show the results in the main view.\n\n" +
+			"The query can contain the same filters as the search prompt, for example\n" +
+			"'clx search rust author:pg type:story points:100 after:2022-01-01 sort:date'.",
+		Args: cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			query, err := hn.ParseSearchQuery(strings.Join(args, " "))
+			if err != nil {
+				exitWithError("Invalid search: " + err.Error())
+			}
+
+			if err := applySearchFlags(cmd, &query); err != nil {
+				exitWithError("Invalid search: " + err.Error())
+			}
+
+			config := getConfig(cmd)
+
+			runTUI(config, func(config *settings.Config) {
+				bubble.RunSearch(config, query)
+			})
+		},
+	}
+
+	searchCmd.Flags().Bool("by-date", false, "sort the results by date instead of relevance")
+	searchCmd.Flags().String("type", "", "only show results of this type (story or comment)")
+	searchCmd.Flags().String("author", "", "only show results by this user")
+	searchCmd.Flags().Int("min-points", 0, "only show results with at least this many points")
+	searchCmd.Flags().String("after", "", "only show results from this date (YYYY-MM-DD) or later")
+	searchCmd.Flags().String("before", "", "only show results from before this date (YYYY-MM-DD)")
+
+	return searchCmd
+}
+
+// applySearchFlags sets the filters of query from the flags of cmd, which take
+// precedence over the filters in the query text. They are validated in the
+// same way as in the search prompt.
+func applySearchFlags(cmd *cobra.Command, query *hn.SearchQuery) error {
+	for _, name := range []string{"type", "author", "after", "before"} {
+		if !cmd.Flags().Changed(name) {
+			continue
+		}
+
+		value, _ := cmd.Flags().GetString(name)
+
+		if err := query.SetFilter(name, value); err != nil {
+			return err
+		}
+	}
+
+	if cmd.Flags().Changed("min-points") {
+		minPoints, _ := cmd.Flags().GetInt("min-points")
+
+		if err := query.SetFilter("points", strconv.Itoa(minPoints)); err != nil {
+			return err
+		}
+	}
+
+	if byDate, _ := cmd.Flags().GetBool("by-date"); byDate {
+		query.ByDate = true
+	}
+
+	return nil
+}
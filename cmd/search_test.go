@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"clx/hn"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplySearchFlags(t *testing.T) {
+	t.Parallel()
+
+	cmd := searchCmd()
+	require.NoError(t, cmd.Flags().Set("author", "pg"))
+	require.NoError(t, cmd.Flags().Set("min-points", "100"))
+	require.NoError(t, cmd.Flags().Set("after", "2022-01-01"))
+	require.NoError(t, cmd.Flags().Set("by-date", "true"))
+
+	query, err := hn.ParseSearchQuery("rust author:dang type:story")
+	require.NoError(t, err)
+	require.NoError(t, applySearchFlags(cmd, &query))
+
+	// The flags are filters and not part of the text
+	assert.Equal(t, hn.SearchQuery{
+		Text:      "rust",
+		ByDate:    true,
+		Type:      hn.SearchTypeStory,
+		Author:    "pg",
+		MinPoints: 100,
+		After:     time.Date(2022, 1, 1, 0, 0, 0, 0, time.Local),
+	}, query)
+
+	require.NoError(t, cmd.Flags().Set("type", "job"))
+	assert.Error(t, applySearchFlags(cmd, &query))
+}
@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"clx/bubble/list"
+	"clx/file"
+	"clx/item"
+	"clx/reader"
+	"clx/screen"
+	"clx/theme"
+	"clx/tree"
+
+	"github.com/logrusorgru/aurora/v3"
+	"github.com/spf13/cobra"
+)
+
+const (
+	sampleTime        = 1600000000
+	sampleLastVisited = sampleTime + 3600
+)
+
+func themesCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "themes [name]",
+		Short: "Preview the color themes",
+		Long: "Print a sample list, comment section and article in each built-in theme and each theme in\n" +
+			file.PathToThemesDirectory() + ", or only in the given theme.\n\n" +
+			"Choose a theme with THEME in the config file or with --theme.",
+		Args:                  cobra.MaximumNArgs(1),
+		DisableFlagsInUseLine: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			config := getConfig(cmd)
+			width := screen.GetTerminalWidth()
+
+			names := theme.Available(file.PathToThemesDirectory())
+			if len(args) == 1 {
+				names = args
+			}
+
+			if theme.NoColor() {
+				names = []string{theme.Monochrome}
+			}
+
+			for _, name := range names {
+				t, err := theme.Load(name, file.PathToThemesDirectory())
+				if err != nil {
+					exitWithError("Could not load the theme: " + err.Error())
+				}
+
+				theme.Set(t)
+
+				fmt.Println(aurora.Bold(name).String() + "\n")
+				fmt.Println(list.Preview(sampleStories(), config, width))
+				fmt.Println(tree.Print(sampleThread(), config, width, sampleLastVisited))
+				fmt.Println(reader.Render(sampleArticle, "https://example.com/themes", "Sample article",
+					config.CommentWidth, config.IndentationSymbol))
+				fmt.Println(strings.Repeat("─", width) + "\n")
+			}
+		},
+	}
+}
+
+func sampleStories() []*item.Item {
+	return []*item.Item{
+		{
+			ID: 1, Title: "Show HN: A terminal client for Hacker News (YC W22)", Points: 412, User: "alice",
+			Time: sampleTime, Domain: "github.com", CommentsCount: 128,
+		},
+		{
+			ID: 2, Title: "The history of the terminal (1978) [pdf]", Points: 96, User: "bob",
+			Time: sampleTime, Domain: "example.com", CommentsCount: 12,
+		},
+		{
+			ID: 3, Title: "Ask HN: What are you reading this week?", Points: 37, User: "carol",
+			Time: sampleTime, CommentsCount: 54,
+		},
+	}
+}
+
+func sampleThread() *item.Item {
+	return &item.Item{
+		ID: 1, Title: "Show HN: A terminal client for Hacker News (YC W22)", Points: 412, User: "alice",
+		Time: sampleTime, TimeAgo: "2 hours ago", URL: "https://github.com/example/clx", Domain: "github.com",
+		CommentsCount: 4,
+		Comments: []*item.Item{
+			{
+				ID: 2, User: "dang", Time: sampleTime, TimeAgo: "2 hours ago",
+				Content: "<p>Please keep the discussion on topic, see the guidelines at " +
+					"<a href=\"https://news.ycombinator.com/newsguidelines.html\">" +
+					"https://news.ycombinator.com/newsguidelines.html</a></p>",
+			},
+			{
+				ID: 3, User: "dave", Time: sampleTime, TimeAgo: "2 hours ago",
+				Content: "<p>Does it support `less` as the pager? I read threads with it [0].</p>" +
+					"<p>[0] <a href=\"https://example.com\">https://example.com</a></p>",
+				Comments: []*item.Item{
+					{
+						ID: 4, Level: 1, User: "alice", Time: sampleTime, TimeAgo: "2 hours ago",
+						Content: "<p>&gt; Does it support `less` as the pager?</p><p>Yes, set PAGER=less in the config file.</p>",
+						Comments: []*item.Item{
+							{
+								ID: 5, Level: 2, User: "dave", Time: sampleLastVisited + 60, TimeAgo: "1 minute ago",
+								Content: "<p>Thanks @alice, that works.</p>",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+const sampleArticle = `Circumflex shows articles in Reader Mode, with the same colors as comment sections.
+
+## Headings
+
+Each level of heading has its own color, and **bold**, *italic* and ` + "`code`" + ` are kept.
+
+> Quotes are indented.
+
+![A screenshot of the list](https://example.com/screenshot.png)
+
+- Lists
+- Links such as https://example.com
+
+` + "```go" + `
+// Code blocks are highlighted
+func main() {
+	fmt.Println("Hello, Hacker News", 2023)
+}
+` + "```" + `
+`
@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+func upvoteCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:                   "upvote <id>",
+		Short:                 "Upvote a story or comment by ID",
+		Long:                  "Upvote a story or comment by ID. Requires 'clx login'.",
+		Args:                  cobra.ExactArgs(1),
+		DisableFlagsInUseLine: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			id, err := strconv.Atoi(args[0])
+			if err != nil {
+				exitWithError("Argument must be a valid ID")
+			}
+
+			config := getConfig(cmd)
+
+			if err := getAccount(config).Upvote(cmd.Context(), id); err != nil {
+				exitWithServiceError(err)
+			}
+
+			println("Upvoted item " + args[0])
+		},
+	}
+}
@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"clx/bubble"
+	"clx/settings"
+
+	"github.com/spf13/cobra"
+)
+
+func userCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "user <name>",
+		Short: "Show the profile of a user",
+		Long: "Show the karma, account age and about text of a user together with their latest stories " +
+			"and comments",
+		Args:                  cobra.ExactArgs(1),
+		DisableFlagsInUseLine: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			config := getConfig(cmd)
+
+			runTUI(config, func(config *settings.Config) {
+				bubble.RunUser(config, args[0])
+			})
+		},
+	}
+}
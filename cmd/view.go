@@ -3,11 +3,19 @@ package cmd
 import (
 	_ "embed"
 	"strconv"
-	"time"
+	"strings"
 
+	"clx/bubble"
+	"clx/bubble/viewer"
+	"clx/history"
+	"clx/item"
+	"clx/keymaps"
 	"clx/less"
+	"clx/settings"
+	"clx/thread"
 
-	"clx/hn/services/hybrid"
+	"clx/hn/services"
+	"clx/hn/services/muted"
 
 	"clx/cli"
 	"clx/screen"
@@ -17,34 +25,111 @@ import (
 )
 
 func viewCmd() *cobra.Command {
-	return &cobra.Command{
-		Use:   "view",
+	var (
+		order    string
+		onlyOP   bool
+		author   string
+		contains string
+	)
+
+	viewCmd := &cobra.Command{
+		Use:   "view <id>",
 		Short: "Go directly to the comment section by ID",
 		Long: "Directly enter the comment section for a given item without going through the main " +
 			"view first",
-		Args:                  cobra.ExactArgs(1),
-		DisableFlagsInUseLine: true,
+		Args: cobra.ExactArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
-			id, _ := strconv.Atoi(args[0])
+			id, err := strconv.Atoi(args[0])
+			if err != nil {
+				exitWithError("Argument must be a valid ID")
+			}
+
+			if !thread.IsOrder(order) {
+				exitWithError("Sort order must be one of " + strings.Join(thread.Orders, ", "))
+			}
+
+			if onlyOP && author != "" {
+				exitWithError("--op and --author cannot be used together")
+			}
+
+			config := getConfig(cmd)
+			service := muted.New(services.New(config), loadKillfile())
 
-			service := new(hybrid.Service)
+			comments, err := service.FetchComments(cmd.Context(), id)
+			if err != nil {
+				exitWithServiceError(err)
+			}
+
+			filter := thread.Filter{Author: author, Text: contains}
+			if onlyOP {
+				filter.Author = comments.User
+			}
 
-			comments := service.FetchComments(id)
+			// Comments are only marked as new when the story has been opened
+			// in the main view before
+			his := history.NewNonPersistentHistory()
+			if !config.DoNotMarkSubmissionsAsRead {
+				his = history.NewPersistentHistory()
+			}
 
-			config := getConfig()
+			lastVisited := his.GetLastVisited(id)
+
+			if config.Pager == settings.PagerNative {
+				v := newCommentsViewer(comments, config, lastVisited, his.GetReadingPosition(id), order, filter,
+					screen.GetTerminalWidth(), screen.GetTerminalHeight())
+
+				bubble.RunViewer(v, his)
+
+				return
+			}
+
+			comments.Comments = thread.Sort(filter.Apply(comments.Comments), order, lastVisited)
 
 			screenWidth := screen.GetTerminalWidth()
-			commentTree := tree.Print(comments, config, screenWidth, time.Now().Unix())
+			commentTree := tree.Print(comments, config, screenWidth, lastVisited)
 
-			lesskey := less.NewLesskey()
+			lesskey := less.NewLesskey(config.Keymap, keymaps.ContextComments)
 			config.LesskeyPath = lesskey.GetPath()
+			defer lesskey.Remove()
 
 			command := cli.Less(commentTree, config)
 
 			if err := command.Run(); err != nil {
-				defer lesskey.Remove()
-				panic(err)
+				exitWithError("Could not run less: " + err.Error())
 			}
 		},
 	}
+
+	viewCmd.Flags().StringVar(&order, "sort", thread.OrderOriginal,
+		"sort the replies: "+strings.Join(thread.Orders, ", "))
+	viewCmd.Flags().BoolVar(&onlyOP, "op", false, "only show the comments by the submitter and their parents")
+	viewCmd.Flags().StringVar(&author, "author", "", "only show the comments by this user and their parents")
+	viewCmd.Flags().StringVar(&contains, "contains", "",
+		"only show the comments that contain this text and their parents")
+
+	return viewCmd
+}
+
+// newCommentsViewer returns the built-in viewer for the comments of story at
+// the last read comment, or at the first new comment without one. The order
+// and filter are applied after the reading position is restored, so that the
+// threads with matches are expanded.
+func newCommentsViewer(story *item.Item, config *settings.Config, lastVisited int64,
+	position history.ReadingPosition, order string, filter thread.Filter, width, height int,
+) *viewer.Comments {
+	v := viewer.NewComments(story, config, lastVisited, width, height)
+
+	if !v.RestoreReadingPosition(position) {
+		v.FocusFirstNew()
+	}
+
+	if order != thread.OrderOriginal {
+		v.SetOrder(order)
+	}
+
+	if !filter.IsEmpty() {
+		v.SetFilter(filter)
+	}
+
+	return v
 }
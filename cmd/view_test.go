@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"testing"
+
+	"clx/history"
+	"clx/item"
+	"clx/settings"
+	"clx/thread"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCommentsViewerWithFilter(t *testing.T) {
+	t.Parallel()
+
+	story := &item.Item{
+		ID:   100,
+		User: "op",
+		Comments: []*item.Item{
+			{ID: 1, User: "alfa", Content: "<p>Lorem ipsum", Comments: []*item.Item{
+				{ID: 2, User: "op", Level: 1, Content: "<p>Dolor sit amet"},
+			}},
+			{ID: 3, User: "beta", Content: "<p>Consectetur"},
+		},
+	}
+
+	position := history.ReadingPosition{CollapsedComments: map[int]bool{1: true}, LastReadComment: 3}
+
+	// The saved state does not hide the matches inside collapsed threads
+	v := newCommentsViewer(story, settings.Default(), 0, position, thread.OrderOriginal,
+		thread.Filter{Author: "op"}, 80, 24)
+	assert.Contains(t, v.View(), "Dolor sit amet")
+	assert.Equal(t, map[int]bool{1: true, 3: false}, v.ReadingPosition().CollapsedComments)
+
+	v = newCommentsViewer(story, settings.Default(), 0, position, thread.OrderOriginal, thread.Filter{}, 80, 24)
+	assert.NotContains(t, v.View(), "Dolor sit amet")
+	assert.Equal(t, 3, v.Focused().ID)
+}
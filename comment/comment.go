@@ -5,6 +5,7 @@ import (
 
 	"clx/settings"
 	"clx/syntax"
+	"clx/watchlist"
 
 	"github.com/logrusorgru/aurora/v3"
 
@@ -83,32 +84,21 @@ func Print(c string, config *settings.Config, commentWidth int, availableScreenW
 
 		case s.isCodeBlock:
 			paragraph = syntax.ReplaceHTML(paragraph)
-			wrappedComment, _ := text.Wrap(paragraph, availableScreenWidth)
 
-			codeLines := strings.Split(wrappedComment, "\n")
-			formattedCodeLines := ""
-
-			for j, codeLine := range codeLines {
-				isOnLastLine := j == len(codeLines)-1
-
-				if isOnLastLine {
-					formattedCodeLines += dimmed + codeLine + reset
-
-					break
-				}
-
-				formattedCodeLines += dimmed + codeLine + reset + "\n"
+			if config.DisableCommentHighlighting {
+				paragraph = syntax.DimCode(paragraph, availableScreenWidth)
+			} else {
+				paragraph = syntax.HighlightCode(paragraph, "", availableScreenWidth)
 			}
 
-			paragraph = formattedCodeLines
-
 		default:
 			paragraph = syntax.ReplaceSymbols(paragraph)
 			paragraph = convertToEmojis(paragraph, config.DisableEmojis)
 
 			paragraph = syntax.ReplaceHTML(paragraph)
 			paragraph = strings.TrimLeft(paragraph, " ")
-			paragraph = highlightCommentSyntax(paragraph, config.DisableCommentHighlighting, config.EnableNerdFonts)
+			paragraph = highlightCommentSyntax(paragraph, config.Watchlist, config.DisableCommentHighlighting,
+				config.EnableNerdFonts)
 
 			paragraph = syntax.TrimURLs(paragraph, config.DisableCommentHighlighting)
 			paragraph = syntax.RemoveUnwantedNewLines(paragraph)
@@ -154,7 +144,13 @@ func getParagraphSeparator(index int, sliceLength int) string {
 	return "\n\n"
 }
 
-func highlightCommentSyntax(input string, disableCommentHighlighting bool, enableNerdFonts bool) string {
+func highlightCommentSyntax(input string, rules watchlist.Watchlist, disableCommentHighlighting bool,
+	enableNerdFonts bool,
+) string {
+	// The watchlist is set up by the user, so it is applied even without
+	// syntax highlighting
+	input = syntax.HighlightWatchlistInComment(input, rules, syntax.Unselected)
+
 	if disableCommentHighlighting {
 		return input
 	}
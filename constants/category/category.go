@@ -1,5 +1,7 @@
 package category
 
+import "strings"
+
 const (
 	FrontPage = 0
 	New       = 1
@@ -7,4 +9,53 @@ const (
 	Show      = 3
 	Favorites = 4
 	Buffer    = 5
+	Search    = 6
+	Best      = 7
+	Jobs      = 8
+	Past      = 9
+	Active    = 10
+	User      = 11
+	Replies   = 12
+
+	// Count is the number of categories, including Buffer, Search and User.
+	Count = 13
 )
+
+// names are the names of the categories that can be shown in the header.
+var names = map[int]string{
+	New:       "new",
+	Ask:       "ask",
+	Show:      "show",
+	Best:      "best",
+	Jobs:      "jobs",
+	Past:      "past",
+	Active:    "active",
+	Replies:   "replies",
+	Favorites: "favorites",
+}
+
+// Name returns the name of cat as shown in the header, or an empty string if
+// cat is not shown in the header.
+func Name(cat int) string {
+	return names[cat]
+}
+
+// Parse returns the category with the given name. Favorites cannot be parsed,
+// since it is only shown when there are favorites.
+func Parse(name string) (int, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+
+	for cat, n := range names {
+		if n == name && cat != Favorites {
+			return cat, true
+		}
+	}
+
+	return 0, false
+}
+
+// Selectable returns the names of the categories that can be shown in the
+// header in their default order.
+func Selectable() []string {
+	return []string{"new", "ask", "show", "best", "jobs", "past", "active", "replies"}
+}
@@ -1,75 +1,53 @@
 package style
 
 import (
-	"github.com/charmbracelet/lipgloss"
-	"github.com/muesli/termenv"
-)
+	"clx/theme"
 
-const (
-	magentaDark = "200"
-	yellowDark  = "214"
-	blueDark    = "33"
-	pinkDark    = "219"
-
-	orange      = "214"
-	orangeFaint = "94"
-
-	logoBgDark           = "#0f1429"
-	headerBgDark         = "#2d3454"
-	unselectedItemFgDark = "251"
-	paginatorBgDark      = logoBgDark
-	selectedPageFgDark   = unselectedItemFgDark
-	unselectedPageFgDark = "239"
-
-	magentaLight = magentaDark
-	yellowLight  = "208"
-	blueLight    = blueDark
-	pinkLight    = pinkDark
-
-	logoBgLight           = "252"
-	headerBgLight         = "254"
-	unselectedItemFgLight = "235"
-	paginatorBgLight      = logoBgLight
-	selectedPageFgLight   = unselectedItemFgLight
-	unselectedPageFgLight = "247"
+	"github.com/charmbracelet/lipgloss"
 )
 
 func GetMagenta() lipgloss.TerminalColor {
-	return lipgloss.AdaptiveColor{Light: magentaLight, Dark: magentaDark}
+	return theme.Current().Magenta.Lipgloss()
 }
 
 func GetYellow() lipgloss.TerminalColor {
-	return lipgloss.AdaptiveColor{Light: yellowLight, Dark: yellowDark}
+	return theme.Current().Yellow.Lipgloss()
 }
 
 func GetBlue() lipgloss.TerminalColor {
-	return lipgloss.AdaptiveColor{Light: blueLight, Dark: blueDark}
+	return theme.Current().Blue.Lipgloss()
 }
 
 func GetPink() lipgloss.TerminalColor {
-	return lipgloss.AdaptiveColor{Light: pinkLight, Dark: pinkDark}
+	return theme.Current().Pink.Lipgloss()
+}
+
+func GetGreen() lipgloss.TerminalColor {
+	return theme.Current().Green.Lipgloss()
+}
+
+func GetCyan() lipgloss.TerminalColor {
+	return theme.Current().Cyan.Lipgloss()
+}
+
+func GetRed() lipgloss.TerminalColor {
+	return theme.Current().Red.Lipgloss()
 }
 
 func GetOrange() lipgloss.TerminalColor {
-	return lipgloss.AdaptiveColor{Light: orange, Dark: orange}
+	return theme.Current().Orange.Lipgloss()
 }
 
 func GetOrangeFaint() lipgloss.TerminalColor {
-	return lipgloss.AdaptiveColor{Light: orangeFaint, Dark: orangeFaint}
+	return theme.Current().FaintOrange.Lipgloss()
 }
 
 func GetLogoBg() lipgloss.TerminalColor {
-	return lipgloss.AdaptiveColor{Light: logoBgLight, Dark: logoBgDark}
+	return theme.Current().LogoBackground.Lipgloss()
 }
 
 func GetHeaderBg() lipgloss.TerminalColor {
-	profile := termenv.ColorProfile()
-
-	if profile != termenv.TrueColor {
-		return lipgloss.AdaptiveColor{Light: headerBgLight, Dark: "237"}
-	}
-
-	return lipgloss.AdaptiveColor{Light: headerBgLight, Dark: headerBgDark}
+	return theme.Current().HeaderBackground.Lipgloss()
 }
 
 func GetStatusBarBg() lipgloss.TerminalColor {
@@ -77,17 +55,33 @@ func GetStatusBarBg() lipgloss.TerminalColor {
 }
 
 func GetPaginatorBg() lipgloss.TerminalColor {
-	return lipgloss.AdaptiveColor{Light: paginatorBgLight, Dark: paginatorBgDark}
+	return theme.Current().PaginatorBackground.Lipgloss()
 }
 
 func GetUnselectedItemFg() lipgloss.TerminalColor {
-	return lipgloss.AdaptiveColor{Light: unselectedItemFgLight, Dark: unselectedItemFgDark}
+	return theme.Current().Text.Lipgloss()
 }
 
 func GetSelectedPageFg() lipgloss.TerminalColor {
-	return lipgloss.AdaptiveColor{Light: selectedPageFgLight, Dark: selectedPageFgDark}
+	return GetUnselectedItemFg()
 }
 
 func GetUnselectedPageFg() lipgloss.TerminalColor {
-	return lipgloss.AdaptiveColor{Light: unselectedPageFgLight, Dark: unselectedPageFgDark}
+	return theme.Current().FaintText.Lipgloss()
+}
+
+func GetLabelFg() lipgloss.TerminalColor {
+	return theme.Current().LabelText.Lipgloss()
+}
+
+func GetFaintLabelFg() lipgloss.TerminalColor {
+	return theme.Current().FaintLabelText.Lipgloss()
+}
+
+func GetYear() lipgloss.TerminalColor {
+	return theme.Current().Year.Lipgloss()
+}
+
+func GetFaintYear() lipgloss.TerminalColor {
+	return theme.Current().FaintYear.Lipgloss()
 }
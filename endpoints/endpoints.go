@@ -24,6 +24,18 @@ type HN struct {
 	Title       string `json:"title"`
 	Type        string `json:"type"`
 	Url         string `json:"url"`
+	Text        string `json:"text"`
+	Parent      int    `json:"parent"`
+	Deleted     bool   `json:"deleted"`
+	Dead        bool   `json:"dead"`
+}
+
+type HNUser struct {
+	ID        string `json:"id"`
+	Karma     int    `json:"karma"`
+	Created   int64  `json:"created"`
+	About     string `json:"about"`
+	Submitted []int  `json:"submitted"`
 }
 
 type Comments struct {
@@ -43,48 +55,62 @@ type Comments struct {
 }
 
 type Algolia struct {
-	Hits []struct {
-		CreatedAt       time.Time   `json:"created_at"`
-		Title           string      `json:"title"`
-		URL             string      `json:"url"`
-		Author          string      `json:"author"`
-		Points          int         `json:"points"`
-		StoryText       interface{} `json:"story_text"`
-		CommentText     interface{} `json:"comment_text"`
-		NumComments     int         `json:"num_comments"`
-		StoryID         interface{} `json:"story_id"`
-		StoryTitle      interface{} `json:"story_title"`
-		StoryURL        interface{} `json:"story_url"`
-		ParentID        interface{} `json:"parent_id"`
-		CreatedAtI      int         `json:"created_at_i"`
-		Tags            []string    `json:"_tags"`
-		ObjectID        string      `json:"objectID"`
-		HighlightResult struct {
-			Title struct {
-				Value        string        `json:"value"`
-				MatchLevel   string        `json:"matchLevel"`
-				MatchedWords []interface{} `json:"matchedWords"`
-			} `json:"title"`
-			URL struct {
-				Value        string        `json:"value"`
-				MatchLevel   string        `json:"matchLevel"`
-				MatchedWords []interface{} `json:"matchedWords"`
-			} `json:"url"`
-			Author struct {
-				Value        string        `json:"value"`
-				MatchLevel   string        `json:"matchLevel"`
-				MatchedWords []interface{} `json:"matchedWords"`
-			} `json:"author"`
-		} `json:"_highlightResult"`
-	} `json:"hits"`
-	NbHits           int      `json:"nbHits"`
-	Page             int      `json:"page"`
-	NbPages          int      `json:"nbPages"`
-	HitsPerPage      int      `json:"hitsPerPage"`
-	ExhaustiveNbHits bool     `json:"exhaustiveNbHits"`
-	ExhaustiveTypo   bool     `json:"exhaustiveTypo"`
-	Query            string   `json:"query"`
-	Params           string   `json:"params"`
-	RenderingContent struct{} `json:"renderingContent"`
-	ProcessingTimeMS int      `json:"processingTimeMS"`
+	Hits             []AlgoliaHit `json:"hits"`
+	NbHits           int          `json:"nbHits"`
+	Page             int          `json:"page"`
+	NbPages          int          `json:"nbPages"`
+	HitsPerPage      int          `json:"hitsPerPage"`
+	ExhaustiveNbHits bool         `json:"exhaustiveNbHits"`
+	ExhaustiveTypo   bool         `json:"exhaustiveTypo"`
+	Query            string       `json:"query"`
+	Params           string       `json:"params"`
+	RenderingContent struct{}     `json:"renderingContent"`
+	ProcessingTimeMS int          `json:"processingTimeMS"`
+}
+
+type AlgoliaHit struct {
+	CreatedAt       time.Time   `json:"created_at"`
+	Title           string      `json:"title"`
+	URL             string      `json:"url"`
+	Author          string      `json:"author"`
+	Points          int         `json:"points"`
+	StoryText       interface{} `json:"story_text"`
+	CommentText     interface{} `json:"comment_text"`
+	NumComments     int         `json:"num_comments"`
+	StoryID         interface{} `json:"story_id"`
+	StoryTitle      interface{} `json:"story_title"`
+	StoryURL        interface{} `json:"story_url"`
+	ParentID        interface{} `json:"parent_id"`
+	CreatedAtI      int         `json:"created_at_i"`
+	Tags            []string    `json:"_tags"`
+	ObjectID        string      `json:"objectID"`
+	HighlightResult struct {
+		Title struct {
+			Value        string        `json:"value"`
+			MatchLevel   string        `json:"matchLevel"`
+			MatchedWords []interface{} `json:"matchedWords"`
+		} `json:"title"`
+		URL struct {
+			Value        string        `json:"value"`
+			MatchLevel   string        `json:"matchLevel"`
+			MatchedWords []interface{} `json:"matchedWords"`
+		} `json:"url"`
+		Author struct {
+			Value        string        `json:"value"`
+			MatchLevel   string        `json:"matchLevel"`
+			MatchedWords []interface{} `json:"matchedWords"`
+		} `json:"author"`
+	} `json:"_highlightResult"`
+}
+
+// HasTag reports whether the hit has the given tag, such as story or
+// comment.
+func (h *AlgoliaHit) HasTag(tag string) bool {
+	for _, t := range h.Tags {
+		if t == tag {
+			return true
+		}
+	}
+
+	return false
 }
@@ -0,0 +1,136 @@
+package export
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+type blockKind int
+
+const (
+	paragraph blockKind = iota
+	quote
+	code
+)
+
+// block is a paragraph, a quoted paragraph or a code block of a comment.
+// Code blocks keep their text as is, the others are split into spans.
+type block struct {
+	kind  blockKind
+	spans []span
+	code  string
+}
+
+// span is a run of text that is either plain, italic or a link.
+type span struct {
+	text   string
+	italic bool
+	link   string
+}
+
+var (
+	codePattern   = regexp.MustCompile(`(?s)<pre><code>(.*?)</code></pre>`)
+	inlinePattern = regexp.MustCompile(`(?s)<i>(.*?)</i>|<a href="([^"]*)"[^>]*>(.*?)</a>`)
+	tagPattern    = regexp.MustCompile(`<[^>]*>`)
+)
+
+// parse splits the HTML of a story text or comment into blocks. Hacker News
+// only uses a few tags: paragraphs, italics, links and code blocks. Quotes
+// are paragraphs that start with '>'.
+func parse(content string) []block {
+	var blocks []block
+
+	position := 0
+
+	for _, match := range codePattern.FindAllStringSubmatchIndex(content, -1) {
+		blocks = append(blocks, parseParagraphs(content[position:match[0]])...)
+		blocks = append(blocks, block{kind: code, code: parseCode(content[match[2]:match[3]])})
+		position = match[1]
+	}
+
+	return append(blocks, parseParagraphs(content[position:])...)
+}
+
+func parseParagraphs(content string) []block {
+	var blocks []block
+
+	content = strings.ReplaceAll(content, "</p>", "")
+
+	for _, p := range strings.Split(content, "<p>") {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+
+		kind := paragraph
+
+		if strings.HasPrefix(p, "&gt;") || strings.HasPrefix(p, ">") {
+			kind = quote
+			p = strings.TrimLeft(strings.TrimPrefix(strings.TrimPrefix(p, "&gt;"), ">"), " ")
+		}
+
+		blocks = append(blocks, block{kind: kind, spans: parseSpans(p)})
+	}
+
+	return blocks
+}
+
+// parseCode returns the text of a code block without the two spaces that
+// every line starts with on Hacker News.
+func parseCode(content string) string {
+	lines := strings.Split(strings.TrimRight(html.UnescapeString(content), "\n"), "\n")
+
+	for _, line := range lines {
+		if !strings.HasPrefix(line, "  ") && line != "" {
+			return strings.Join(lines, "\n")
+		}
+	}
+
+	for i, line := range lines {
+		lines[i] = strings.TrimPrefix(line, "  ")
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func parseSpans(content string) []span {
+	var spans []span
+
+	position := 0
+
+	for _, match := range inlinePattern.FindAllStringSubmatchIndex(content, -1) {
+		spans = appendText(spans, content[position:match[0]], false)
+
+		if match[2] != -1 {
+			spans = appendText(spans, content[match[2]:match[3]], true)
+		} else {
+			spans = append(spans, span{
+				text: plainText(content[match[6]:match[7]]),
+				link: html.UnescapeString(content[match[4]:match[5]]),
+			})
+		}
+
+		position = match[1]
+	}
+
+	return appendText(spans, content[position:], false)
+}
+
+func appendText(spans []span, content string, italic bool) []span {
+	if content == "" {
+		return spans
+	}
+
+	return append(spans, span{text: plainText(content), italic: italic})
+}
+
+// plainText removes the remaining tags from s and unescapes it.
+func plainText(s string) string {
+	return html.UnescapeString(tagPattern.ReplaceAllString(s, ""))
+}
+
+// isWebLink reports whether link can be linked to safely.
+func isWebLink(link string) bool {
+	return strings.HasPrefix(link, "https://") || strings.HasPrefix(link, "http://")
+}
@@ -0,0 +1,109 @@
+// Package export writes a story and its comments as a document that can be
+// read outside of circumflex.
+package export
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"clx/item"
+	"clx/settings"
+)
+
+const (
+	FormatMarkdown = "md"
+	FormatHTML     = "html"
+	FormatJSON     = "json"
+	FormatText     = "txt"
+)
+
+var Formats = []string{FormatMarkdown, FormatHTML, FormatJSON, FormatText}
+
+const deleted = "[deleted]"
+
+// Write writes story in the given format to w. Permalinks point to
+// config.WebURL and text is wrapped at config.CommentWidth.
+func Write(w io.Writer, story *item.Item, format string, config *settings.Config) error {
+	e := &exporter{webURL: strings.TrimRight(config.WebURL, "/"), width: config.CommentWidth}
+
+	var (
+		content string
+		err     error
+	)
+
+	switch format {
+	case FormatMarkdown:
+		content = e.markdown(story)
+
+	case FormatHTML:
+		content = e.html(story)
+
+	case FormatJSON:
+		content, err = e.json(story)
+
+	case FormatText:
+		content = e.text(story)
+
+	default:
+		return fmt.Errorf("unknown format %q, expected one of %s", format, strings.Join(Formats, ", "))
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.WriteString(w, content); err != nil {
+		return fmt.Errorf("could not write export: %w", err)
+	}
+
+	return nil
+}
+
+type exporter struct {
+	webURL string
+	width  int
+}
+
+func (e *exporter) permalink(id int) string {
+	return e.webURL + "/item?id=" + strconv.Itoa(id)
+}
+
+func (e *exporter) userURL(user string) string {
+	return e.webURL + "/user?id=" + url.QueryEscape(user)
+}
+
+// replies returns the replies to it that are exported. Like in the comment
+// section, deleted comments are left out unless they have replies.
+func replies(it *item.Item) []*item.Item {
+	var exported []*item.Item
+
+	for _, reply := range it.Comments {
+		if reply.Content == deleted && len(replies(reply)) == 0 {
+			continue
+		}
+
+		exported = append(exported, reply)
+	}
+
+	return exported
+}
+
+func formatTime(t int64) string {
+	if t == 0 {
+		return ""
+	}
+
+	return time.Unix(t, 0).UTC().Format("2006-01-02 15:04 UTC")
+}
+
+func pluralize(n int, singular string, plural string) string {
+	if n == 1 {
+		return "1 " + singular
+	}
+
+	return strconv.Itoa(n) + " " + plural
+}
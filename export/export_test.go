@@ -0,0 +1,195 @@
+package export_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"clx/export"
+	"clx/item"
+	"clx/settings"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newStory() *item.Item {
+	return &item.Item{
+		ID:            100,
+		Title:         "Ask HN: Lorem <ipsum>?",
+		Points:        42,
+		User:          "op",
+		Time:          1666999400,
+		Content:       "<p>Dolor sit <i>amet</i>",
+		CommentsCount: 4,
+		Comments: []*item.Item{
+			{
+				ID: 1, User: "alfa", Time: 1666999500,
+				Content: "<p>See <a href=\"https:&#x2F;&#x2F;example.com&#x2F;a_b\" rel=\"nofollow\">" +
+					"https:&#x2F;&#x2F;example.com&#x2F;a_b</a> and <a href=\"javascript:alert(1)\">this</a>" +
+					"<p>&gt; quoted *text*<p><pre><code>  if a &lt; b {\n    return\n  }\n</code></pre>",
+				Comments: []*item.Item{
+					{ID: 2, Content: "[deleted]", Comments: []*item.Item{
+						{ID: 3, User: "gamma", Time: 1666999700, Content: "<p>Consectetur adipiscing elit sed do eiusmod"},
+					}},
+				},
+			},
+			{ID: 4, Content: "[deleted]"},
+		},
+	}
+}
+
+func write(t *testing.T, format string) string {
+	t.Helper()
+
+	config := settings.Default()
+	config.CommentWidth = 30
+
+	var buf bytes.Buffer
+	require.NoError(t, export.Write(&buf, newStory(), format, config))
+
+	return buf.String()
+}
+
+func TestMarkdown(t *testing.T) {
+	t.Parallel()
+
+	expected := "# Ask HN: Lorem \\<ipsum\\>?\n\n" +
+		"42 points by [op](https://news.ycombinator.com/user?id=op) · 2022-10-28 23:23 UTC · " +
+		"[4 comments](https://news.ycombinator.com/item?id=100)\n\n" +
+		"Dolor sit *amet*\n\n" +
+		"---\n\n" +
+		"- **[alfa](https://news.ycombinator.com/user?id=alfa)** · " +
+		"[2022-10-28 23:25 UTC](https://news.ycombinator.com/item?id=1)\n\n" +
+		"  See <https://example.com/a_b> and this\n\n" +
+		"  > quoted \\*text\\*\n\n" +
+		"  ```\n  if a < b {\n    return\n  }\n  ```\n\n" +
+		"  - *deleted* · [link](https://news.ycombinator.com/item?id=2)\n\n" +
+		"    - **[gamma](https://news.ycombinator.com/user?id=gamma)** · " +
+		"[2022-10-28 23:28 UTC](https://news.ycombinator.com/item?id=3)\n\n" +
+		"      Consectetur adipiscing elit sed do eiusmod\n"
+
+	assert.Equal(t, expected, write(t, export.FormatMarkdown))
+}
+
+func TestHTML(t *testing.T) {
+	t.Parallel()
+
+	output := write(t, export.FormatHTML)
+
+	assert.Contains(t, output, "<title>Ask HN: Lorem &lt;ipsum&gt;?</title>")
+	assert.Contains(t, output, "<p>Dolor sit <i>amet</i></p>")
+	assert.Contains(t, output, `<li id="item-1">`)
+	assert.Contains(t, output, `<a href="https://example.com/a_b">https://example.com/a_b</a>`)
+	assert.Contains(t, output, "<blockquote><p>quoted *text*</p></blockquote>")
+	assert.Contains(t, output, "<pre><code>if a &lt; b {\n  return\n}</code></pre>")
+	assert.Contains(t, output, `<time datetime="2022-10-28T23:28:20Z">2022-10-28 23:28 UTC</time>`)
+	assert.NotContains(t, output, "javascript")
+	assert.NotContains(t, output, "item-4")
+}
+
+func TestJSON(t *testing.T) {
+	t.Parallel()
+
+	var story export.Story
+	require.NoError(t, json.Unmarshal([]byte(write(t, export.FormatJSON)), &story))
+
+	assert.Equal(t, export.SchemaVersion, story.SchemaVersion)
+	assert.Equal(t, "op", story.Author)
+	assert.Equal(t, "Dolor sit amet", story.Text)
+	assert.Equal(t, "https://news.ycombinator.com/item?id=100", story.Permalink)
+	assert.Equal(t, int64(1666999400), story.Time.Unix())
+
+	require.Len(t, story.Comments, 1)
+	alfa := story.Comments[0]
+	assert.Equal(t, "See https://example.com/a_b and this (javascript:alert(1))\n\n"+
+		"> quoted *text*\n\n    if a < b {\n      return\n    }", alfa.Text)
+
+	require.Len(t, alfa.Replies, 1)
+	assert.True(t, alfa.Replies[0].Deleted)
+	assert.Empty(t, alfa.Replies[0].Author)
+	assert.Equal(t, "gamma", alfa.Replies[0].Replies[0].Author)
+	assert.NotNil(t, alfa.Replies[0].Replies[0].Replies)
+}
+
+func TestText(t *testing.T) {
+	t.Parallel()
+
+	header := "Ask HN: Lorem <ipsum>?\n" +
+		"42 points by op · 2022-10-28 23:23 UTC · 4 comments\n" +
+		"https://news.ycombinator.com/item?id=100\n\n" +
+		"Dolor sit amet\n\n" +
+		"------------------------------\n"
+
+	output := write(t, export.FormatText)
+	assert.True(t, strings.HasPrefix(output, header))
+	assert.Contains(t, output, "\n        Consectetur adipiscing elit\n        sed do eiusmod\n")
+}
+
+func TestUnknownFormat(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	assert.Error(t, export.Write(&buf, newStory(), "pdf", settings.Default()))
+}
+
+func writeList(t *testing.T, format string) string {
+	t.Helper()
+
+	stories := []*item.Item{
+		{ID: 100, Title: "Lorem\tipsum", URL: "https://example.com/a", Domain: "example.com", Points: 42,
+			User: "op", Time: 1666999400, CommentsCount: 1},
+		{ID: 101, Title: "Ask HN: Dolor?", Points: 1, User: "alfa", CommentsCount: 0},
+	}
+
+	isRead := func(id int) bool { return id == 101 }
+
+	var buf bytes.Buffer
+	require.NoError(t, export.WriteList(&buf, stories, format, isRead, "https://news.ycombinator.com/"))
+
+	return buf.String()
+}
+
+func TestWriteListJSON(t *testing.T) {
+	t.Parallel()
+
+	var stories []*export.ListedStory
+	require.NoError(t, json.Unmarshal([]byte(writeList(t, export.ListFormatJSON)), &stories))
+	require.Len(t, stories, 2)
+
+	assert.Equal(t, 100, stories[0].ID)
+	assert.Equal(t, "example.com", stories[0].Domain)
+	assert.Equal(t, 1, stories[0].Comments)
+	assert.Equal(t, "2022-10-28T23:23:20Z", stories[0].Time.Format(time.RFC3339))
+	assert.False(t, stories[0].Read)
+	assert.Nil(t, stories[1].Time)
+	assert.True(t, stories[1].Read)
+	assert.Equal(t, "https://news.ycombinator.com/item?id=101", stories[1].Permalink)
+
+	lines := strings.Split(strings.TrimSuffix(writeList(t, export.ListFormatJSONLines), "\n"), "\n")
+	require.Len(t, lines, 2)
+	assert.True(t, strings.HasPrefix(lines[1], `{"id":101,"title":"Ask HN: Dolor?",`))
+}
+
+func TestWriteListTSV(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "id\ttitle\turl\tdomain\tpoints\tcomments\tauthor\ttime\tread\n"+
+		"100\tLorem ipsum\thttps://example.com/a\texample.com\t42\t1\top\t2022-10-28T23:23:20Z\tfalse\n"+
+		"101\tAsk HN: Dolor?\t\t\t1\t0\talfa\t\ttrue\n", writeList(t, export.ListFormatTSV))
+}
+
+func TestWriteListPlain(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "* Lorem ipsum (example.com)\n"+
+		"  42 points by op · 2022-10-28 23:23 UTC · 1 comment · https://news.ycombinator.com/item?id=100\n"+
+		"· Ask HN: Dolor?\n"+
+		"  1 point by alfa · 0 comments · https://news.ycombinator.com/item?id=101\n",
+		writeList(t, export.ListFormatPlain))
+
+	var buf bytes.Buffer
+	assert.Error(t, export.WriteList(&buf, nil, "xml", func(int) bool { return false }, ""))
+}
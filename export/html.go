@@ -0,0 +1,147 @@
+package export
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"clx/item"
+)
+
+const htmlStyle = `body { max-width: 50em; margin: 2em auto; padding: 0 1em; font-family: sans-serif; line-height: 1.5; }
+.meta { color: #828282; font-size: 0.9em; }
+.meta a { color: inherit; }
+ul.comments, ul.comments ul { list-style: none; padding-left: 1.5em; }
+ul.comments { padding-left: 0; }
+ul.comments ul { border-left: 2px solid #e0e0e0; }
+blockquote { margin-left: 0; padding-left: 1em; border-left: 3px solid #e0e0e0; color: #555; }
+pre { overflow-x: auto; background: #f6f6ef; padding: 0.5em; }`
+
+// html renders a standalone document. The HTML from Hacker News is parsed
+// and written again, so that only the tags that Hacker News uses end up in
+// the document.
+func (e *exporter) html(story *item.Item) string {
+	var sb strings.Builder
+
+	title := html.EscapeString(story.Title)
+
+	sb.WriteString("<!DOCTYPE html>\n<html lang=\"en\">\n<head>\n<meta charset=\"utf-8\">\n")
+	sb.WriteString("<title>" + title + "</title>\n<style>\n" + htmlStyle + "\n</style>\n</head>\n<body>\n<article>\n")
+
+	if isWebLink(story.URL) {
+		sb.WriteString(fmt.Sprintf("<h1><a href=\"%s\">%s</a></h1>\n", html.EscapeString(story.URL), title))
+	} else {
+		sb.WriteString("<h1>" + title + "</h1>\n")
+	}
+
+	meta := []string{fmt.Sprintf("%s by <a href=\"%s\">%s</a>", pluralize(story.Points, "point", "points"),
+		html.EscapeString(e.userURL(story.User)), html.EscapeString(story.User))}
+
+	if t := htmlTime(story.Time); t != "" {
+		meta = append(meta, t)
+	}
+
+	meta = append(meta, fmt.Sprintf("<a href=\"%s\">%s</a>", html.EscapeString(e.permalink(story.ID)),
+		pluralize(story.CommentsCount, "comment", "comments")))
+
+	sb.WriteString("<p class=\"meta\">" + strings.Join(meta, " · ") + "</p>\n")
+
+	if story.Content != "" {
+		sb.WriteString(htmlBlocks(parse(story.Content)))
+	}
+
+	if comments := replies(story); len(comments) != 0 {
+		sb.WriteString("<ul class=\"comments\">\n")
+
+		for _, c := range comments {
+			e.htmlComment(&sb, c)
+		}
+
+		sb.WriteString("</ul>\n")
+	}
+
+	sb.WriteString("</article>\n</body>\n</html>\n")
+
+	return sb.String()
+}
+
+func (e *exporter) htmlComment(sb *strings.Builder, c *item.Item) {
+	author := "[deleted]"
+	if c.User != "" {
+		author = fmt.Sprintf("<a href=\"%s\">%s</a>", html.EscapeString(e.userURL(c.User)), html.EscapeString(c.User))
+	}
+
+	link := htmlTime(c.Time)
+	if link == "" {
+		link = "link"
+	}
+
+	sb.WriteString(fmt.Sprintf("<li id=\"item-%d\">\n<p class=\"meta\">%s · <a href=\"%s\">%s</a></p>\n",
+		c.ID, author, html.EscapeString(e.permalink(c.ID)), link))
+
+	if c.Content != deleted {
+		sb.WriteString(htmlBlocks(parse(c.Content)))
+	}
+
+	if comments := replies(c); len(comments) != 0 {
+		sb.WriteString("<ul>\n")
+
+		for _, reply := range comments {
+			e.htmlComment(sb, reply)
+		}
+
+		sb.WriteString("</ul>\n")
+	}
+
+	sb.WriteString("</li>\n")
+}
+
+func htmlBlocks(blocks []block) string {
+	var sb strings.Builder
+
+	for _, b := range blocks {
+		switch b.kind {
+		case code:
+			sb.WriteString("<pre><code>" + html.EscapeString(b.code) + "</code></pre>\n")
+
+		case quote:
+			sb.WriteString("<blockquote><p>" + htmlSpans(b.spans) + "</p></blockquote>\n")
+
+		default:
+			sb.WriteString("<p>" + htmlSpans(b.spans) + "</p>\n")
+		}
+	}
+
+	return sb.String()
+}
+
+func htmlSpans(spans []span) string {
+	var sb strings.Builder
+
+	for _, s := range spans {
+		text := html.EscapeString(s.text)
+
+		switch {
+		case s.link != "" && isWebLink(s.link):
+			sb.WriteString(fmt.Sprintf("<a href=\"%s\">%s</a>", html.EscapeString(s.link), text))
+
+		case s.italic:
+			sb.WriteString("<i>" + text + "</i>")
+
+		default:
+			sb.WriteString(text)
+		}
+	}
+
+	return sb.String()
+}
+
+func htmlTime(t int64) string {
+	if t == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("<time datetime=\"%s\">%s</time>",
+		time.Unix(t, 0).UTC().Format(time.RFC3339), formatTime(t))
+}
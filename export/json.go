@@ -0,0 +1,110 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"clx/item"
+)
+
+// SchemaVersion is increased when fields are renamed or removed or change
+// their meaning. Adding fields does not change the version.
+const SchemaVersion = 1
+
+// Story is the JSON format of an exported story.
+type Story struct {
+	SchemaVersion int    `json:"schema_version"`
+	ID            int    `json:"id"`
+	Title         string `json:"title"`
+	URL           string `json:"url,omitempty"`
+	Author        string `json:"author"`
+	Points        int    `json:"points"`
+
+	// Time is in RFC 3339 format and omitted if it is unknown
+	Time      *time.Time `json:"time,omitempty"`
+	Permalink string     `json:"permalink"`
+
+	// Text is the text of the story as plain text and HTML is the text as it
+	// was received from Hacker News. Both are omitted for link stories.
+	Text string `json:"text,omitempty"`
+	HTML string `json:"html,omitempty"`
+
+	CommentCount int        `json:"comment_count"`
+	Comments     []*Comment `json:"comments"`
+}
+
+// Comment is the JSON format of an exported comment. Deleted comments are
+// only exported if they have replies, and then have no author or text.
+type Comment struct {
+	ID        int        `json:"id"`
+	Author    string     `json:"author,omitempty"`
+	Time      *time.Time `json:"time,omitempty"`
+	Permalink string     `json:"permalink"`
+	Text      string     `json:"text,omitempty"`
+	HTML      string     `json:"html,omitempty"`
+	Deleted   bool       `json:"deleted,omitempty"`
+	Replies   []*Comment `json:"replies"`
+}
+
+func (e *exporter) json(story *item.Item) (string, error) {
+	s := &Story{
+		SchemaVersion: SchemaVersion,
+		ID:            story.ID,
+		Title:         story.Title,
+		URL:           story.URL,
+		Author:        story.User,
+		Points:        story.Points,
+		Time:          jsonTime(story.Time),
+		Permalink:     e.permalink(story.ID),
+		CommentCount:  story.CommentsCount,
+		Comments:      e.jsonComments(story),
+	}
+
+	if story.Content != "" {
+		s.Text = textBlocks(parse(story.Content), "", 0)
+		s.HTML = story.Content
+	}
+
+	content, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("could not encode story: %w", err)
+	}
+
+	return string(content) + "\n", nil
+}
+
+func (e *exporter) jsonComments(it *item.Item) []*Comment {
+	comments := []*Comment{}
+
+	for _, reply := range replies(it) {
+		c := &Comment{
+			ID:        reply.ID,
+			Time:      jsonTime(reply.Time),
+			Permalink: e.permalink(reply.ID),
+			Replies:   e.jsonComments(reply),
+		}
+
+		if reply.Content == deleted {
+			c.Deleted = true
+		} else {
+			c.Author = reply.User
+			c.Text = textBlocks(parse(reply.Content), "", 0)
+			c.HTML = reply.Content
+		}
+
+		comments = append(comments, c)
+	}
+
+	return comments
+}
+
+func jsonTime(t int64) *time.Time {
+	if t == 0 {
+		return nil
+	}
+
+	utc := time.Unix(t, 0).UTC()
+
+	return &utc
+}
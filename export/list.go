@@ -0,0 +1,164 @@
+package export
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"clx/item"
+)
+
+const (
+	ListFormatJSON      = "json"
+	ListFormatJSONLines = "jsonl"
+	ListFormatTSV       = "tsv"
+	ListFormatPlain     = "plain"
+)
+
+var ListFormats = []string{ListFormatJSON, ListFormatJSONLines, ListFormatTSV, ListFormatPlain}
+
+// tsvColumns are the columns of the TSV format, which is written with a
+// header line.
+var tsvColumns = []string{"id", "title", "url", "domain", "points", "comments", "author", "time", "read"}
+
+// ListedStory is the JSON format of a story in a list. It is written as an
+// array with the json format and as one object per line with jsonl.
+type ListedStory struct {
+	ID       int    `json:"id"`
+	Title    string `json:"title"`
+	URL      string `json:"url,omitempty"`
+	Domain   string `json:"domain,omitempty"`
+	Points   int    `json:"points"`
+	Comments int    `json:"comments"`
+	Author   string `json:"author"`
+
+	// Time is in RFC 3339 format and omitted if it is unknown
+	Time      *time.Time `json:"time,omitempty"`
+	Permalink string     `json:"permalink"`
+
+	// Read is true if the comment section has been opened before
+	Read bool `json:"read"`
+}
+
+// WriteList writes stories in the given list format to w. isRead reports
+// whether a story has been read and permalinks point to webURL.
+func WriteList(w io.Writer, stories []*item.Item, format string, isRead func(id int) bool, webURL string) error {
+	e := &exporter{webURL: strings.TrimRight(webURL, "/")}
+
+	listed := make([]*ListedStory, 0, len(stories))
+
+	for _, story := range stories {
+		listed = append(listed, &ListedStory{
+			ID:        story.ID,
+			Title:     story.Title,
+			URL:       story.URL,
+			Domain:    story.Domain,
+			Points:    story.Points,
+			Comments:  story.CommentsCount,
+			Author:    story.User,
+			Time:      jsonTime(story.Time),
+			Permalink: e.permalink(story.ID),
+			Read:      isRead(story.ID),
+		})
+	}
+
+	bw := bufio.NewWriter(w)
+
+	var err error
+
+	switch format {
+	case ListFormatJSON:
+		encoder := json.NewEncoder(bw)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(listed)
+
+	case ListFormatJSONLines:
+		encoder := json.NewEncoder(bw)
+
+		for _, story := range listed {
+			if err = encoder.Encode(story); err != nil {
+				break
+			}
+		}
+
+	case ListFormatTSV:
+		writeTSV(bw, listed)
+
+	case ListFormatPlain:
+		writePlain(bw, listed)
+
+	default:
+		return fmt.Errorf("unknown format %q, expected one of %s", format, strings.Join(ListFormats, ", "))
+	}
+
+	if err != nil {
+		return fmt.Errorf("could not encode stories: %w", err)
+	}
+
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("could not write stories: %w", err)
+	}
+
+	return nil
+}
+
+func writeTSV(w *bufio.Writer, stories []*ListedStory) {
+	w.WriteString(strings.Join(tsvColumns, "\t") + "\n")
+
+	for _, story := range stories {
+		t := ""
+		if story.Time != nil {
+			t = story.Time.Format(time.RFC3339)
+		}
+
+		fields := []string{
+			strconv.Itoa(story.ID),
+			tsvField(story.Title),
+			tsvField(story.URL),
+			tsvField(story.Domain),
+			strconv.Itoa(story.Points),
+			strconv.Itoa(story.Comments),
+			tsvField(story.Author),
+			t,
+			strconv.FormatBool(story.Read),
+		}
+
+		w.WriteString(strings.Join(fields, "\t") + "\n")
+	}
+}
+
+// tsvField replaces the tabs and line breaks in s, which would otherwise
+// split the field.
+func tsvField(s string) string {
+	return strings.NewReplacer("\t", " ", "\r", " ", "\n", " ").Replace(s)
+}
+
+// writePlain writes two lines per story, like the main view. Stories that
+// have been read are marked with a '·' instead of a '*'.
+func writePlain(w *bufio.Writer, stories []*ListedStory) {
+	for _, story := range stories {
+		marker := "*"
+		if story.Read {
+			marker = "·"
+		}
+
+		headline := marker + " " + tsvField(story.Title)
+		if story.Domain != "" {
+			headline += " (" + story.Domain + ")"
+		}
+
+		meta := []string{pluralize(story.Points, "point", "points") + " by " + story.Author}
+
+		if story.Time != nil {
+			meta = append(meta, story.Time.Format("2006-01-02 15:04 UTC"))
+		}
+
+		meta = append(meta, pluralize(story.Comments, "comment", "comments"), story.Permalink)
+
+		w.WriteString(headline + "\n  " + strings.Join(meta, " · ") + "\n")
+	}
+}
@@ -0,0 +1,158 @@
+package export
+
+import (
+	"fmt"
+	"strings"
+
+	"clx/item"
+)
+
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "*", `\*`, "_", `\_`, "`", "\\`", "[", `\[`, "]", `\]`, "<", `\<`, ">", `\>`)
+
+// markdown renders the comments as nested lists, so that the nesting
+// survives in any Markdown renderer.
+func (e *exporter) markdown(story *item.Item) string {
+	var sb strings.Builder
+
+	sb.WriteString("# " + escapeMarkdown(story.Title) + "\n\n")
+
+	if story.URL != "" {
+		sb.WriteString("<" + story.URL + ">\n\n")
+	}
+
+	meta := []string{fmt.Sprintf("%s by [%s](%s)", pluralize(story.Points, "point", "points"),
+		escapeMarkdown(story.User), e.userURL(story.User))}
+
+	if t := formatTime(story.Time); t != "" {
+		meta = append(meta, t)
+	}
+
+	meta = append(meta, fmt.Sprintf("[%s](%s)",
+		pluralize(story.CommentsCount, "comment", "comments"), e.permalink(story.ID)))
+
+	sb.WriteString(strings.Join(meta, " · ") + "\n")
+
+	if story.Content != "" {
+		sb.WriteString("\n" + markdownBlocks(parse(story.Content), ""))
+	}
+
+	if comments := replies(story); len(comments) != 0 {
+		sb.WriteString("\n---\n")
+
+		for _, c := range comments {
+			e.markdownComment(&sb, c, 0)
+		}
+	}
+
+	return sb.String()
+}
+
+func (e *exporter) markdownComment(sb *strings.Builder, c *item.Item, depth int) {
+	indent := strings.Repeat("  ", depth)
+
+	author := "*deleted*"
+	if c.User != "" {
+		author = fmt.Sprintf("**[%s](%s)**", escapeMarkdown(c.User), e.userURL(c.User))
+	}
+
+	link := "link"
+	if t := formatTime(c.Time); t != "" {
+		link = t
+	}
+
+	sb.WriteString(fmt.Sprintf("\n%s- %s · [%s](%s)\n", indent, author, link, e.permalink(c.ID)))
+
+	if c.Content != deleted {
+		sb.WriteString("\n" + markdownBlocks(parse(c.Content), indent+"  "))
+	}
+
+	for _, reply := range replies(c) {
+		e.markdownComment(sb, reply, depth+1)
+	}
+}
+
+// markdownBlocks renders blocks with every line indented by indent, followed
+// by an empty line after each block but the last.
+func markdownBlocks(blocks []block, indent string) string {
+	rendered := make([]string, len(blocks))
+
+	for i, b := range blocks {
+		switch b.kind {
+		case code:
+			fence := "```"
+			for strings.Contains(b.code, fence) {
+				fence += "`"
+			}
+
+			rendered[i] = indentLines(fence+"\n"+b.code+"\n"+fence, indent)
+
+		case quote:
+			rendered[i] = indent + "> " + markdownParagraph(b.spans)
+
+		default:
+			rendered[i] = indent + markdownParagraph(b.spans)
+		}
+	}
+
+	return strings.Join(rendered, "\n\n") + "\n"
+}
+
+func markdownParagraph(spans []span) string {
+	var sb strings.Builder
+
+	for _, s := range spans {
+		switch {
+		case s.link != "" && isWebLink(s.link) && (s.text == s.link || strings.HasSuffix(s.text, "...")):
+			// Hacker News shortens long links, so the full link is shown
+			sb.WriteString("<" + s.link + ">")
+
+		case s.link != "" && isWebLink(s.link):
+			sb.WriteString("[" + escapeMarkdown(s.text) + "](" + markdownURL(s.link) + ")")
+
+		case s.italic:
+			sb.WriteString("*" + escapeMarkdown(s.text) + "*")
+
+		default:
+			sb.WriteString(escapeMarkdown(s.text))
+		}
+	}
+
+	p := sb.String()
+
+	// A paragraph that starts like a heading or a list would be read as one
+	if strings.HasPrefix(p, "#") || strings.HasPrefix(p, "- ") || strings.HasPrefix(p, "+ ") {
+		p = `\` + p
+	}
+
+	return p
+}
+
+func escapeMarkdown(s string) string {
+	return markdownEscaper.Replace(s)
+}
+
+// markdownURL returns link so that it can be used as the destination of a
+// Markdown link.
+func markdownURL(link string) string {
+	if strings.ContainsAny(link, " ()") {
+		return "<" + link + ">"
+	}
+
+	return link
+}
+
+func indentLines(s string, indent string) string {
+	if indent == "" {
+		return s
+	}
+
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = indent + line
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,145 @@
+package export
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"clx/item"
+)
+
+const textIndentation = "    "
+
+// text renders plain text where replies are indented below their parent.
+func (e *exporter) text(story *item.Item) string {
+	var sb strings.Builder
+
+	sb.WriteString(story.Title + "\n")
+
+	if story.URL != "" {
+		sb.WriteString(story.URL + "\n")
+	}
+
+	meta := []string{pluralize(story.Points, "point", "points") + " by " + story.User}
+
+	if t := formatTime(story.Time); t != "" {
+		meta = append(meta, t)
+	}
+
+	meta = append(meta, pluralize(story.CommentsCount, "comment", "comments"))
+
+	sb.WriteString(strings.Join(meta, " · ") + "\n")
+	sb.WriteString(e.permalink(story.ID) + "\n")
+
+	if story.Content != "" {
+		sb.WriteString("\n" + textBlocks(parse(story.Content), "", e.width) + "\n")
+	}
+
+	if comments := replies(story); len(comments) != 0 {
+		sb.WriteString("\n" + strings.Repeat("-", e.width) + "\n")
+
+		for _, c := range comments {
+			e.textComment(&sb, c, "")
+		}
+	}
+
+	return sb.String()
+}
+
+func (e *exporter) textComment(sb *strings.Builder, c *item.Item, indent string) {
+	meta := []string{c.User}
+	if c.User == "" {
+		meta = []string{deleted}
+	}
+
+	if t := formatTime(c.Time); t != "" {
+		meta = append(meta, t)
+	}
+
+	meta = append(meta, e.permalink(c.ID))
+
+	sb.WriteString(fmt.Sprintf("\n%s%s\n", indent, strings.Join(meta, " · ")))
+
+	if c.Content != deleted {
+		sb.WriteString(textBlocks(parse(c.Content), indent, e.width) + "\n")
+	}
+
+	for _, reply := range replies(c) {
+		e.textComment(sb, reply, indent+textIndentation)
+	}
+}
+
+// PlainText returns content, the HTML text of a story or comment, as plain
+// text after indent. Paragraphs are wrapped at width unless width is 0.
+func PlainText(content string, indent string, width int) string {
+	return textBlocks(parse(content), indent, width)
+}
+
+// textBlocks renders blocks as paragraphs that are separated by an empty
+// line. Paragraphs are wrapped at width, not counting the indentation, unless
+// width is 0. Code blocks are never wrapped.
+func textBlocks(blocks []block, indent string, width int) string {
+	rendered := make([]string, len(blocks))
+
+	for i, b := range blocks {
+		switch b.kind {
+		case code:
+			rendered[i] = indentLines(b.code, indent+textIndentation)
+
+		case quote:
+			rendered[i] = wrap(textSpans(b.spans), indent+"> ", width)
+
+		default:
+			rendered[i] = wrap(textSpans(b.spans), indent, width)
+		}
+	}
+
+	return strings.Join(rendered, "\n\n")
+}
+
+func textSpans(spans []span) string {
+	var sb strings.Builder
+
+	for _, s := range spans {
+		switch {
+		case s.link != "" && (s.text == s.link || strings.HasSuffix(s.text, "...")):
+			sb.WriteString(s.link)
+
+		case s.link != "":
+			sb.WriteString(s.text + " (" + s.link + ")")
+
+		default:
+			sb.WriteString(s.text)
+		}
+	}
+
+	return sb.String()
+}
+
+// wrap breaks s into lines of at most width characters after prefix. Words
+// that are longer than a line, such as links, are not broken.
+func wrap(s string, prefix string, width int) string {
+	if width == 0 {
+		return prefix + s
+	}
+
+	var (
+		lines []string
+		line  string
+	)
+
+	for _, word := range strings.Fields(s) {
+		if line != "" && utf8.RuneCountInString(line+" "+word) > width {
+			lines = append(lines, prefix+line)
+			line = ""
+		}
+
+		if line != "" {
+			line += " "
+		}
+
+		line += word
+	}
+
+	return strings.Join(append(lines, prefix+line), "\n")
+}
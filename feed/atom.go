@@ -0,0 +1,93 @@
+package feed
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+type atom struct {
+	XMLName   xml.Name     `xml:"http://www.w3.org/2005/Atom feed"`
+	Title     string       `xml:"title"`
+	ID        string       `xml:"id"`
+	Links     []atomLink   `xml:"link"`
+	Updated   string       `xml:"updated"`
+	Generator string       `xml:"generator"`
+	Entries   []*atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type atomEntry struct {
+	Title      string         `xml:"title"`
+	ID         string         `xml:"id"`
+	Links      []atomLink     `xml:"link"`
+	Published  string         `xml:"published,omitempty"`
+	Updated    string         `xml:"updated"`
+	Author     atomAuthor     `xml:"author"`
+	Categories []atomCategory `xml:"category"`
+	Summary    atomText       `xml:"summary"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type atomText struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+// atom writes an Atom document. The discussion of an entry is linked with
+// the replies relation from the Atom threading extension.
+func (f *Feed) atom() ([]byte, error) {
+	entries := f.entries()
+	feedUpdated := updated(entries).Format(time.RFC3339)
+
+	feed := atom{
+		Title:     f.Title,
+		ID:        f.Link,
+		Links:     []atomLink{{Href: f.Link}},
+		Updated:   feedUpdated,
+		Generator: generator,
+	}
+
+	for _, e := range entries {
+		entry := &atomEntry{
+			Title: e.title,
+			ID:    e.discussion,
+			Links: []atomLink{
+				{Href: e.link, Rel: "alternate"},
+				{Href: e.discussion, Rel: "replies", Type: "text/html"},
+			},
+			Updated: feedUpdated,
+			Author:  atomAuthor{Name: e.author},
+			Summary: atomText{Type: "html", Value: e.summary()},
+		}
+
+		if !e.published.IsZero() {
+			entry.Published = e.published.Format(time.RFC3339)
+			entry.Updated = entry.Published
+		}
+
+		for _, label := range e.labels {
+			entry.Categories = append(entry.Categories, atomCategory{Term: label})
+		}
+
+		feed.Entries = append(feed.Entries, entry)
+	}
+
+	content, err := xml.MarshalIndent(feed, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header), append(content, '\n')...), nil
+}
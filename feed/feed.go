@@ -0,0 +1,152 @@
+// Package feed writes a list of stories as an RSS 2.0, Atom or JSON Feed
+// document that can be subscribed to in a feed reader.
+package feed
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"clx/item"
+	"clx/watchlist"
+)
+
+const (
+	FormatRSS  = "rss"
+	FormatAtom = "atom"
+	FormatJSON = "json"
+)
+
+var Formats = []string{FormatRSS, FormatAtom, FormatJSON}
+
+const generator = "circumflex"
+
+// Feed is a list of stories together with the page on Hacker News that it
+// follows.
+type Feed struct {
+	Title string
+	Link  string
+
+	Stories []*item.Item
+
+	// WebURL is where the discussions of the stories are linked to
+	WebURL string
+
+	// Watchlist adds the labels of the rules that match the title of a story
+	// to its entry as categories
+	Watchlist watchlist.Watchlist
+}
+
+// entry holds what the formats have in common for a story.
+type entry struct {
+	title      string
+	link       string
+	discussion string
+	author     string
+	points     int
+	comments   int
+	published  time.Time
+	labels     []string
+}
+
+// Write writes the feed in the given format to w.
+func (f *Feed) Write(w io.Writer, format string) error {
+	var (
+		content []byte
+		err     error
+	)
+
+	switch format {
+	case FormatRSS:
+		content, err = f.rss()
+
+	case FormatAtom:
+		content, err = f.atom()
+
+	case FormatJSON:
+		content, err = f.json()
+
+	default:
+		return fmt.Errorf("unknown format %q, expected one of %s", format, strings.Join(Formats, ", "))
+	}
+
+	if err != nil {
+		return fmt.Errorf("could not encode feed: %w", err)
+	}
+
+	if _, err := w.Write(content); err != nil {
+		return fmt.Errorf("could not write feed: %w", err)
+	}
+
+	return nil
+}
+
+func (f *Feed) entries() []*entry {
+	webURL := strings.TrimRight(f.WebURL, "/")
+	entries := make([]*entry, 0, len(f.Stories))
+
+	for _, story := range f.Stories {
+		e := &entry{
+			title:      story.Title,
+			discussion: webURL + "/item?id=" + strconv.Itoa(story.ID),
+			author:     story.User,
+			points:     story.Points,
+			comments:   story.CommentsCount,
+		}
+
+		if story.Time != 0 {
+			e.published = time.Unix(story.Time, 0).UTC()
+		}
+
+		// Ask HN and other text posts link to their own discussion
+		e.link = e.discussion
+		if strings.HasPrefix(story.URL, "https://") || strings.HasPrefix(story.URL, "http://") {
+			e.link = story.URL
+		}
+
+		for _, rule := range f.Watchlist.Matching(story.Title) {
+			if rule.Label != "" {
+				e.labels = append(e.labels, rule.Label)
+			}
+		}
+
+		entries = append(entries, e)
+	}
+
+	return entries
+}
+
+// updated returns the time of the newest entry, or now if there are none.
+func updated(entries []*entry) time.Time {
+	var newest time.Time
+
+	for _, e := range entries {
+		if e.published.After(newest) {
+			newest = e.published
+		}
+	}
+
+	if newest.IsZero() {
+		return time.Now().UTC()
+	}
+
+	return newest
+}
+
+// summary describes the score and comments of e with a link to the
+// discussion, as HTML.
+func (e *entry) summary() string {
+	return fmt.Sprintf("<p>%s by %s · <a href=\"%s\">%s</a></p>", pluralize(e.points, "point", "points"),
+		html.EscapeString(e.author), html.EscapeString(e.discussion), pluralize(e.comments, "comment", "comments"))
+}
+
+func pluralize(n int, singular string, plural string) string {
+	if n == 1 {
+		return "1 " + singular
+	}
+
+	return strconv.Itoa(n) + " " + plural
+}
@@ -0,0 +1,136 @@
+package feed_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+
+	"clx/feed"
+	"clx/item"
+	"clx/watchlist"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func write(t *testing.T, format string) string {
+	t.Helper()
+
+	rules, err := watchlist.Parse("keyword golang: go\nkeyword: rust")
+	require.NoError(t, err)
+
+	f := &feed.Feed{
+		Title: "Hacker News: best",
+		Link:  "https://news.ycombinator.com/best",
+		Stories: []*item.Item{
+			{ID: 100, Title: "Go & Rust", URL: "https://example.com/a", Points: 42, User: "op", Time: 1666999400, CommentsCount: 1},
+			{ID: 101, Title: "Ask HN: <Lorem>?", URL: "item?id=101", Points: 1, User: "alfa", Time: 1666999500},
+		},
+		WebURL:    "https://news.ycombinator.com/",
+		Watchlist: rules,
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, f.Write(&buf, format))
+
+	return buf.String()
+}
+
+func TestRSS(t *testing.T) {
+	t.Parallel()
+
+	content := write(t, feed.FormatRSS)
+
+	var doc struct {
+		Channel struct {
+			LastBuildDate string `xml:"lastBuildDate"`
+			Items         []struct {
+				Title       string   `xml:"title"`
+				Link        string   `xml:"link"`
+				Comments    string   `xml:"comments"`
+				PubDate     string   `xml:"pubDate"`
+				Categories  []string `xml:"category"`
+				Description string   `xml:"description"`
+			} `xml:"item"`
+		} `xml:"channel"`
+	}
+
+	require.NoError(t, xml.Unmarshal([]byte(content), &doc))
+	require.Len(t, doc.Channel.Items, 2)
+
+	first := doc.Channel.Items[0]
+	assert.Equal(t, "Go & Rust", first.Title)
+	assert.Equal(t, "https://example.com/a", first.Link)
+	assert.Equal(t, "https://news.ycombinator.com/item?id=100", first.Comments)
+	assert.Equal(t, "Fri, 28 Oct 2022 23:23:20 +0000", first.PubDate)
+	assert.Equal(t, []string{"golang"}, first.Categories)
+	assert.Equal(t, "<p>42 points by op · <a href=\"https://news.ycombinator.com/item?id=100\">1 comment</a></p>",
+		first.Description)
+
+	assert.Equal(t, "https://news.ycombinator.com/item?id=101", doc.Channel.Items[1].Link)
+	assert.Equal(t, "Fri, 28 Oct 2022 23:25:00 +0000", doc.Channel.LastBuildDate)
+	assert.Contains(t, content, "<dc:creator>alfa</dc:creator>")
+}
+
+func TestAtom(t *testing.T) {
+	t.Parallel()
+
+	var doc struct {
+		XMLName xml.Name `xml:"http://www.w3.org/2005/Atom feed"`
+		Updated string   `xml:"updated"`
+		Entries []struct {
+			ID    string `xml:"id"`
+			Links []struct {
+				Href string `xml:"href,attr"`
+				Rel  string `xml:"rel,attr"`
+			} `xml:"link"`
+			Published string `xml:"published"`
+			Author    string `xml:"author>name"`
+		} `xml:"entry"`
+	}
+
+	require.NoError(t, xml.Unmarshal([]byte(write(t, feed.FormatAtom)), &doc))
+	require.Len(t, doc.Entries, 2)
+
+	assert.Equal(t, "2022-10-28T23:25:00Z", doc.Updated)
+
+	first := doc.Entries[0]
+	assert.Equal(t, "https://news.ycombinator.com/item?id=100", first.ID)
+	require.Len(t, first.Links, 2)
+	assert.Equal(t, "https://example.com/a", first.Links[0].Href)
+	assert.Equal(t, "replies", first.Links[1].Rel)
+	assert.Equal(t, "2022-10-28T23:23:20Z", first.Published)
+	assert.Equal(t, "op", first.Author)
+}
+
+func TestJSONFeed(t *testing.T) {
+	t.Parallel()
+
+	var doc map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(write(t, feed.FormatJSON)), &doc))
+
+	assert.Equal(t, "https://jsonfeed.org/version/1.1", doc["version"])
+
+	items := doc["items"].([]interface{})
+	require.Len(t, items, 2)
+
+	first := items[0].(map[string]interface{})
+	assert.Equal(t, "https://news.ycombinator.com/item?id=100", first["url"])
+	assert.Equal(t, "https://example.com/a", first["external_url"])
+	assert.Equal(t, map[string]interface{}{"points": 42.0, "comments": 1.0}, first["_hacker_news"])
+
+	second := items[1].(map[string]interface{})
+	_, hasExternalURL := second["external_url"]
+	assert.False(t, hasExternalURL)
+
+	_, hasTags := second["tags"]
+	assert.False(t, hasTags)
+}
+
+func TestUnknownFormat(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	assert.Error(t, (&feed.Feed{}).Write(&buf, "opml"))
+}
@@ -0,0 +1,81 @@
+package feed
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const jsonFeedVersion = "https://jsonfeed.org/version/1.1"
+
+type jsonFeed struct {
+	Version     string      `json:"version"`
+	Title       string      `json:"title"`
+	HomePageURL string      `json:"home_page_url"`
+	Items       []*jsonItem `json:"items"`
+}
+
+// jsonItem links to the discussion with url and to the story with
+// external_url, like a link blog. The score is in the _hacker_news
+// extension.
+type jsonItem struct {
+	ID            string         `json:"id"`
+	URL           string         `json:"url"`
+	ExternalURL   string         `json:"external_url,omitempty"`
+	Title         string         `json:"title"`
+	ContentHTML   string         `json:"content_html"`
+	DatePublished *time.Time     `json:"date_published,omitempty"`
+	Authors       []jsonAuthor   `json:"authors,omitempty"`
+	Tags          []string       `json:"tags,omitempty"`
+	HackerNews    jsonHackerNews `json:"_hacker_news"`
+}
+
+type jsonAuthor struct {
+	Name string `json:"name"`
+}
+
+type jsonHackerNews struct {
+	Points   int `json:"points"`
+	Comments int `json:"comments"`
+}
+
+func (f *Feed) json() ([]byte, error) {
+	feed := jsonFeed{
+		Version:     jsonFeedVersion,
+		Title:       f.Title,
+		HomePageURL: f.Link,
+		Items:       []*jsonItem{},
+	}
+
+	for _, e := range f.entries() {
+		it := &jsonItem{
+			ID:          e.discussion,
+			URL:         e.discussion,
+			Title:       e.title,
+			ContentHTML: e.summary(),
+			Tags:        e.labels,
+			HackerNews:  jsonHackerNews{Points: e.points, Comments: e.comments},
+		}
+
+		if e.link != e.discussion {
+			it.ExternalURL = e.link
+		}
+
+		if !e.published.IsZero() {
+			published := e.published
+			it.DatePublished = &published
+		}
+
+		if e.author != "" {
+			it.Authors = []jsonAuthor{{Name: e.author}}
+		}
+
+		feed.Items = append(feed.Items, it)
+	}
+
+	content, err := json.MarshalIndent(feed, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append(content, '\n'), nil
+}
@@ -0,0 +1,77 @@
+package feed
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+type rss struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	DC      string     `xml:"xmlns:dc,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string     `xml:"title"`
+	Link          string     `xml:"link"`
+	Description   string     `xml:"description"`
+	Generator     string     `xml:"generator"`
+	LastBuildDate string     `xml:"lastBuildDate"`
+	Items         []*rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string   `xml:"title"`
+	Link        string   `xml:"link"`
+	Comments    string   `xml:"comments"`
+	GUID        rssGUID  `xml:"guid"`
+	Creator     string   `xml:"dc:creator,omitempty"`
+	PubDate     string   `xml:"pubDate,omitempty"`
+	Categories  []string `xml:"category"`
+	Description string   `xml:"description"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+// rss writes an RSS 2.0 document. The author is written as dc:creator, since
+// the author element of RSS is meant for email addresses.
+func (f *Feed) rss() ([]byte, error) {
+	entries := f.entries()
+
+	channel := rssChannel{
+		Title:         f.Title,
+		Link:          f.Link,
+		Description:   f.Title,
+		Generator:     generator,
+		LastBuildDate: updated(entries).Format(time.RFC1123Z),
+	}
+
+	for _, e := range entries {
+		it := &rssItem{
+			Title:       e.title,
+			Link:        e.link,
+			Comments:    e.discussion,
+			GUID:        rssGUID{IsPermaLink: true, Value: e.discussion},
+			Creator:     e.author,
+			Categories:  e.labels,
+			Description: e.summary(),
+		}
+
+		if !e.published.IsZero() {
+			it.PubDate = e.published.Format(time.RFC1123Z)
+		}
+
+		channel.Items = append(channel.Items, it)
+	}
+
+	content, err := xml.MarshalIndent(rss{Version: "2.0", DC: "http://purl.org/dc/elements/1.1/", Channel: channel}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header), append(content, '\n')...), nil
+}
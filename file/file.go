@@ -9,6 +9,11 @@ import (
 const (
 	ConfigFileNameFull    = "config.env"
 	FavoritesFileNameFull = "favorites.json"
+	SessionFileNameFull   = "session.json"
+	KillfileName          = "killfile"
+	WatchlistName         = "watchlist"
+	KeymapName            = "keymap"
+	ThemesDirectoryName   = "themes"
 )
 
 func PathToConfigDirectory() string {
@@ -19,6 +24,14 @@ func PathToConfigDirectory() string {
 	return path.Join(homeDir, configDir, clxDir)
 }
 
+func PathToCacheDirectory() string {
+	homeDir, _ := os.UserHomeDir()
+	cacheDir := ".cache"
+	clxDir := "circumflex"
+
+	return path.Join(homeDir, cacheDir, clxDir)
+}
+
 func PathToConfigFile() string {
 	return path.Join(PathToConfigDirectory(), ConfigFileNameFull)
 }
@@ -27,6 +40,26 @@ func PathToFavoritesFile() string {
 	return path.Join(PathToConfigDirectory(), FavoritesFileNameFull)
 }
 
+func PathToSessionFile() string {
+	return path.Join(PathToConfigDirectory(), SessionFileNameFull)
+}
+
+func PathToKillfile() string {
+	return path.Join(PathToConfigDirectory(), KillfileName)
+}
+
+func PathToWatchlist() string {
+	return path.Join(PathToConfigDirectory(), WatchlistName)
+}
+
+func PathToKeymap() string {
+	return path.Join(PathToConfigDirectory(), KeymapName)
+}
+
+func PathToThemesDirectory() string {
+	return path.Join(PathToConfigDirectory(), ThemesDirectoryName)
+}
+
 func Exists(pathToFile string) bool {
 	if _, err := os.Stat(pathToFile); os.IsNotExist(err) {
 		return false
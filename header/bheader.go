@@ -8,7 +8,15 @@ import (
 	"github.com/charmbracelet/lipgloss"
 )
 
-func GetHeader(selectedSubHeader int, favoritesHasItems bool, width int) string {
+const (
+	logoWidth         = len("  clx  ")
+	categoriesPadding = "   "
+	categorySeparator = " • "
+)
+
+// GetHeader returns the header with the given categories in order. The front
+// page is selected when none of them are.
+func GetHeader(selectedSubHeader int, subHeaders []int, width int) string {
 	bg := style.GetLogoBg()
 
 	c := lipgloss.NewStyle().
@@ -24,7 +32,7 @@ func GetHeader(selectedSubHeader int, favoritesHasItems bool, width int) string
 		Background(bg)
 
 	title := c.Render("  c") + l.Render("l") + x.Render("x  ")
-	categories := getCategories(selectedSubHeader, favoritesHasItems)
+	categories := getCategories(selectedSubHeader, subHeaders)
 	filler := getFiller(title, categories, width)
 
 	return title + categories + filler
@@ -44,49 +52,58 @@ func getFiller(title string, categories string, width int) string {
 		Render(filler)
 }
 
-func getCategories(selectedSubHeader int, favoritesHasItems bool) string {
-	subHeaders := getSubHeaders(favoritesHasItems)
+func getCategories(selectedSubHeader int, subHeaders []int) string {
 	fg := style.GetUnselectedItemFg()
 	bg := style.GetHeaderBg()
 
 	categories := lipgloss.NewStyle().
 		Background(bg).
-		Render("   ")
+		Render(categoriesPadding)
 
 	separator := lipgloss.NewStyle().
 		Foreground(fg).
 		Background(bg).
-		Render(" • ")
+		Render(categorySeparator)
 
 	for i, subHeader := range subHeaders {
 		isOnLastItem := i == len(subHeaders)-1
-		selectedCatColor, isSelected := getColor(i, selectedSubHeader)
+		selectedCatColor, isSelected := getColor(subHeader, selectedSubHeader)
 
 		categories += lipgloss.NewStyle().
 			Foreground(selectedCatColor).
 			Background(bg).
 			Bold(isSelected).
-			Render(subHeader)
+			Render(category.Name(subHeader))
 
 		if !isOnLastItem {
 			categories += separator
 		}
 	}
 
-	return categories
-}
+	// Search results and user profiles are temporary categories that are
+	// only shown while they are selected
+	if selectedSubHeader == category.Search {
+		categories += separator + lipgloss.NewStyle().
+			Foreground(style.GetOrange()).
+			Background(bg).
+			Bold(true).
+			Render("search")
+	}
 
-func getSubHeaders(favoritesHasItems bool) []string {
-	if favoritesHasItems {
-		return []string{"new", "ask", "show", "favorites"}
+	if selectedSubHeader == category.User {
+		categories += separator + lipgloss.NewStyle().
+			Foreground(style.GetRed()).
+			Background(bg).
+			Bold(true).
+			Render("user")
 	}
 
-	return []string{"new", "ask", "show"}
+	return categories
 }
 
-func getColor(i int, selectedSubHeader int) (lipgloss.TerminalColor, bool) {
-	if i+1 == selectedSubHeader {
-		return getSelectedCategoryColor(i + 1)
+func getColor(subHeader int, selectedSubHeader int) (lipgloss.TerminalColor, bool) {
+	if subHeader == selectedSubHeader {
+		return getSelectedCategoryColor(subHeader)
 	}
 
 	return style.GetUnselectedItemFg(), false
@@ -100,9 +117,46 @@ func getSelectedCategoryColor(selectedSubHeader int) (lipgloss.TerminalColor, bo
 		return style.GetYellow(), true
 	case category.Show:
 		return style.GetBlue(), true
+	case category.Best:
+		return style.GetOrange(), true
+	case category.Jobs:
+		return style.GetGreen(), true
+	case category.Past:
+		return style.GetCyan(), true
+	case category.Active:
+		return style.GetRed(), true
+	case category.Replies:
+		return style.GetGreen(), true
 	case category.Favorites:
 		return style.GetPink(), true
 	default:
 		return style.GetUnselectedItemFg(), false
 	}
 }
+
+// CategoryAt returns the category whose name is at column x of the header
+// with the given categories, or the front page for the logo. It returns
+// false for the separators and the space after the last category.
+func CategoryAt(x int, subHeaders []int) (int, bool) {
+	if x < 0 {
+		return 0, false
+	}
+
+	if x < logoWidth {
+		return category.FrontPage, true
+	}
+
+	position := logoWidth + lipgloss.Width(categoriesPadding)
+
+	for _, subHeader := range subHeaders {
+		width := lipgloss.Width(category.Name(subHeader))
+
+		if x >= position && x < position+width {
+			return subHeader, true
+		}
+
+		position += width + lipgloss.Width(categorySeparator)
+	}
+
+	return 0, false
+}
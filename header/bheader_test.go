@@ -0,0 +1,45 @@
+package header_test
+
+import (
+	"testing"
+
+	"clx/constants/category"
+	"clx/header"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCategoryAt(t *testing.T) {
+	t.Parallel()
+
+	// "  clx     new • ask • show"
+	subHeaders := []int{category.New, category.Ask, category.Show}
+
+	tests := []struct {
+		x        int
+		category int
+		ok       bool
+	}{
+		{x: 0, category: category.FrontPage, ok: true},
+		{x: 6, category: category.FrontPage, ok: true},
+		{x: 8, ok: false},
+		{x: 10, category: category.New, ok: true},
+		{x: 12, category: category.New, ok: true},
+		{x: 14, ok: false},
+		{x: 16, category: category.Ask, ok: true},
+		{x: 22, category: category.Show, ok: true},
+		{x: 25, category: category.Show, ok: true},
+		{x: 26, ok: false},
+		{x: -1, ok: false},
+	}
+
+	for _, test := range tests {
+		cat, ok := header.CategoryAt(test.x, subHeaders)
+
+		assert.Equal(t, test.ok, ok, test.x)
+
+		if test.ok {
+			assert.Equal(t, test.category, cat, test.x)
+		}
+	}
+}
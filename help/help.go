@@ -5,6 +5,7 @@ import (
 
 	"clx/constants/unicode"
 	"clx/info"
+	"clx/keymaps"
 	"github.com/charmbracelet/lipgloss"
 )
 
@@ -12,13 +13,13 @@ const (
 	newPar = "\n\n"
 )
 
-func GetHelpScreen(enableNerdFonts bool) string {
+func GetHelpScreen(enableNerdFonts bool, keymap *keymaps.Keymap) string {
 	textWidth := 70
 
 	var sb strings.Builder
 
 	sb.WriteString(unicode.ZeroWidthSpace + newPar)
-	sb.WriteString(unicode.ZeroWidthSpace + info.GetText(textWidth, enableNerdFonts) + newPar)
+	sb.WriteString(unicode.ZeroWidthSpace + info.GetText(textWidth, enableNerdFonts, keymap) + newPar)
 
 	return sb.String()
 }
@@ -14,6 +14,17 @@ type History interface {
 	GetLastCommentCount(id int) int
 	ClearAndWriteToDisk()
 	MarkAsReadAndWriteToDisk(id int, commentsOnLastVisit int)
+	GetReadingPosition(id int) ReadingPosition
+	SaveReadingPositionAndWriteToDisk(id int, position ReadingPosition)
+}
+
+// ReadingPosition is where the user left the comment section of a story.
+type ReadingPosition struct {
+	// CollapsedComments maps the IDs of top-level comments to whether their
+	// replies were collapsed. Comments that are not in the map were posted
+	// after the last visit.
+	CollapsedComments map[int]bool `json:",omitempty"`
+	LastReadComment   int          `json:",omitempty"`
 }
 
 func NewPersistentHistory() History {
@@ -35,3 +35,9 @@ func (Mock) GetLastCommentCount(_ int) int {
 func (Mock) ClearAndWriteToDisk() {}
 
 func (Mock) MarkAsReadAndWriteToDisk(_ int, _ int) {}
+
+func (Mock) GetReadingPosition(_ int) ReadingPosition {
+	return ReadingPosition{}
+}
+
+func (Mock) SaveReadingPositionAndWriteToDisk(_ int, _ ReadingPosition) {}
@@ -19,3 +19,9 @@ func (NonPersistent) GetLastCommentCount(_ int) int {
 func (NonPersistent) ClearAndWriteToDisk() {}
 
 func (NonPersistent) MarkAsReadAndWriteToDisk(_ int, _ int) {}
+
+func (NonPersistent) GetReadingPosition(_ int) ReadingPosition {
+	return ReadingPosition{}
+}
+
+func (NonPersistent) SaveReadingPositionAndWriteToDisk(_ int, _ ReadingPosition) {}
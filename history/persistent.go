@@ -13,6 +13,7 @@ type Persistent struct {
 type StoryInfo struct {
 	LastVisited         int64
 	CommentsOnLastVisit int
+	ReadingPosition
 }
 
 func (his *Persistent) Contains(id int) bool {
@@ -45,11 +46,29 @@ func (his *Persistent) ClearAndWriteToDisk() {
 }
 
 func (his *Persistent) MarkAsReadAndWriteToDisk(id int, commentsOnLastVisit int) {
-	his.VisitedStories[id] = StoryInfo{
-		LastVisited:         time.Now().Unix(),
-		CommentsOnLastVisit: commentsOnLastVisit,
+	story := his.VisitedStories[id]
+	story.LastVisited = time.Now().Unix()
+	story.CommentsOnLastVisit = commentsOnLastVisit
+
+	his.VisitedStories[id] = story
+
+	_, dirPath, fileName := getCacheFilePaths()
+	writeToDisk(his, dirPath, fileName)
+}
+
+func (his *Persistent) GetReadingPosition(id int) ReadingPosition {
+	return his.VisitedStories[id].ReadingPosition
+}
+
+func (his *Persistent) SaveReadingPositionAndWriteToDisk(id int, position ReadingPosition) {
+	story, contains := his.VisitedStories[id]
+	if !contains {
+		story.LastVisited = time.Now().Unix()
 	}
 
+	story.ReadingPosition = position
+	his.VisitedStories[id] = story
+
 	_, dirPath, fileName := getCacheFilePaths()
 	writeToDisk(his, dirPath, fileName)
 }
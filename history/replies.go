@@ -0,0 +1,97 @@
+package history
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const repliesFileName = "replies.json"
+
+// firstRepliesCheck is how far back replies are new the first time they are
+// checked for a user.
+const firstRepliesCheck = 7 * 24 * time.Hour
+
+// RepliesChecked holds when the replies to each user were last checked, so
+// that only new replies are shown the next time.
+type RepliesChecked struct {
+	path string
+
+	// LastChecked maps user names in lower case to Unix timestamps
+	LastChecked map[string]int64
+}
+
+// PathToRepliesFile returns the path of the file next to history.json that
+// RepliesChecked is stored in.
+func PathToRepliesFile() string {
+	_, dirPath, _ := getCacheFilePaths()
+
+	return path.Join(dirPath, repliesFileName)
+}
+
+// LoadRepliesChecked reads the times of the last checks from path. A file
+// that does not exist means that no replies have been checked yet.
+func LoadRepliesChecked(path string) (*RepliesChecked, error) {
+	r := &RepliesChecked{path: path, LastChecked: make(map[string]int64)}
+
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return r, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("could not read %s: %w", path, err)
+	}
+
+	if err := json.Unmarshal(content, &r.LastChecked); err != nil {
+		return nil, fmt.Errorf("could not parse %s: %w", path, err)
+	}
+
+	return r, nil
+}
+
+// Get returns when the replies to user were last checked, or the zero time if
+// they have never been checked.
+func (r *RepliesChecked) Get(user string) time.Time {
+	checked, ok := r.LastChecked[strings.ToLower(user)]
+	if !ok {
+		return time.Time{}
+	}
+
+	return time.Unix(checked, 0)
+}
+
+// After returns the time after which replies to user are new: the last check,
+// or a week before now if they have never been checked.
+func (r *RepliesChecked) After(user string, now time.Time) time.Time {
+	if checked := r.Get(user); !checked.IsZero() {
+		return checked
+	}
+
+	return now.Add(-firstRepliesCheck)
+}
+
+// SetAndWriteToDisk records that the replies to user were checked at checked
+// and writes the times of all checks to disk.
+func (r *RepliesChecked) SetAndWriteToDisk(user string, checked time.Time) error {
+	r.LastChecked[strings.ToLower(user)] = checked.Unix()
+
+	content, err := json.Marshal(r.LastChecked)
+	if err != nil {
+		return fmt.Errorf("could not encode the times of the last checks: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o700); err != nil {
+		return fmt.Errorf("could not create %s: %w", filepath.Dir(r.path), err)
+	}
+
+	if err := os.WriteFile(r.path, content, 0o600); err != nil {
+		return fmt.Errorf("could not write %s: %w", r.path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,39 @@
+package history_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"clx/history"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepliesChecked(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "circumflex", "replies.json")
+
+	checked, err := history.LoadRepliesChecked(path)
+	require.NoError(t, err)
+	assert.True(t, checked.Get("alfa").IsZero())
+
+	now := time.Unix(1666999400, 0)
+	assert.True(t, now.Add(-7*24*time.Hour).Equal(checked.After("alfa", now)))
+
+	require.NoError(t, checked.SetAndWriteToDisk("Alfa", now))
+
+	reloaded, err := history.LoadRepliesChecked(path)
+	require.NoError(t, err)
+	assert.True(t, now.Equal(reloaded.Get("alfa")))
+	assert.True(t, now.Equal(reloaded.After("alfa", now.Add(time.Hour))))
+	assert.True(t, reloaded.Get("beta").IsZero())
+
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0o600))
+
+	_, err = history.LoadRepliesChecked(path)
+	assert.Error(t, err)
+}
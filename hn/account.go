@@ -0,0 +1,22 @@
+package hn
+
+import "context"
+
+// Session is a logged in session on news.ycombinator.com. Cookie is the value
+// of the 'user' cookie that the site sets when logging in.
+type Session struct {
+	Username string
+	Cookie   string
+}
+
+// Account performs the actions on Hacker News that need an account. All
+// methods except Login fail with ErrUnauthorized when there is no session.
+type Account interface {
+	Login(ctx context.Context, username string, password string) (*Session, error)
+	Username() string
+	Upvote(ctx context.Context, id int) error
+	Favorite(ctx context.Context, id int) error
+	Unfavorite(ctx context.Context, id int) error
+	FetchFavorites(ctx context.Context) ([]int, error)
+	Reply(ctx context.Context, parentID int, text string) error
+}
@@ -0,0 +1,82 @@
+package hn
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	ErrTimeout      = errors.New("request timed out")
+	ErrNotFound     = errors.New("item not found")
+	ErrRateLimited  = errors.New("rate limited")
+	ErrDecode       = errors.New("could not decode response")
+	ErrUnavailable  = errors.New("service unavailable")
+	ErrNotCached    = errors.New("not available offline")
+	ErrUnauthorized = errors.New("not logged in")
+	ErrBadLogin     = errors.New("bad login")
+	ErrRejected     = errors.New("rejected by hacker news")
+)
+
+// Error is returned by the services when a request fails. Kind is one of the
+// sentinel errors above, which lets callers use errors.Is to find out what
+// went wrong without having to know about the underlying HTTP client.
+type Error struct {
+	Kind error
+	URL  string
+	Err  error
+}
+
+func (e *Error) Error() string {
+	if e.Err == nil {
+		return e.Kind.Error()
+	}
+
+	return e.Kind.Error() + ": " + e.Err.Error()
+}
+
+func (e *Error) Is(target error) bool {
+	return target == e.Kind
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// ErrorMessage returns a short description of err that is suitable for
+// showing to the user.
+func ErrorMessage(err error) string {
+	switch {
+	case err == nil:
+		return ""
+
+	case errors.Is(err, ErrTimeout), errors.Is(err, context.DeadlineExceeded):
+		return "Request timed out, check your connection and try again"
+
+	case errors.Is(err, ErrNotFound):
+		return "Could not find the requested item"
+
+	case errors.Is(err, ErrRateLimited):
+		return "Rate limited by the API, try again in a moment"
+
+	case errors.Is(err, ErrDecode):
+		return "Received an unexpected response from the API"
+
+	case errors.Is(err, ErrNotCached):
+		return "Not available in offline mode, browse it while online to cache it"
+
+	case errors.Is(err, ErrUnauthorized):
+		return "Not logged in, run 'clx login' first"
+
+	case errors.Is(err, ErrBadLogin):
+		return "Wrong username or password"
+
+	case errors.Is(err, ErrRejected):
+		return "Hacker News did not accept the request"
+
+	case errors.Is(err, context.Canceled):
+		return "Request cancelled"
+
+	default:
+		return "Could not reach Hacker News, check your connection and try again"
+	}
+}
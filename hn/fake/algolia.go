@@ -0,0 +1,300 @@
+package fake
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"clx/utils/ints"
+)
+
+type hit struct {
+	CreatedAt   time.Time `json:"created_at"`
+	CreatedAtI  int64     `json:"created_at_i"`
+	Title       string    `json:"title"`
+	URL         string    `json:"url"`
+	Author      string    `json:"author"`
+	Points      int       `json:"points"`
+	StoryText   string    `json:"story_text,omitempty"`
+	CommentText string    `json:"comment_text,omitempty"`
+	NumComments int       `json:"num_comments"`
+	StoryID     int       `json:"story_id,omitempty"`
+	StoryTitle  string    `json:"story_title,omitempty"`
+	ParentID    int       `json:"parent_id,omitempty"`
+	Tags        []string  `json:"_tags"`
+	ObjectID    string    `json:"objectID"`
+}
+
+type searchResponse struct {
+	Hits        []*hit `json:"hits"`
+	NbHits      int    `json:"nbHits"`
+	Page        int    `json:"page"`
+	NbPages     int    `json:"nbPages"`
+	HitsPerPage int    `json:"hitsPerPage"`
+	Query       string `json:"query"`
+}
+
+// handleAlgolia serves /api/v1/search and /api/v1/search_by_date. It
+// understands the tags, query, numericFilters, page, hitsPerPage, offset and
+// length parameters.
+func (s *Server) handleAlgolia(w http.ResponseWriter, r *http.Request) {
+	endpoint := strings.TrimPrefix(r.URL.Path, "/api/v1/")
+	if endpoint != "search" && endpoint != "search_by_date" {
+		http.NotFound(w, r)
+
+		return
+	}
+
+	params := r.URL.Query()
+	tags := parseTags(params.Get("tags"))
+	query := strings.ToLower(params.Get("query"))
+	filters := parseNumericFilters(params.Get("numericFilters"))
+
+	var hits []*hit
+
+	for _, it := range s.items {
+		h := s.toHit(it)
+
+		if matchesTags(h.Tags, tags) && matchesQuery(h, query) && matchesNumericFilters(h, filters) {
+			hits = append(hits, h)
+		}
+	}
+
+	sort.Slice(hits, func(i, j int) bool {
+		if endpoint == "search_by_date" {
+			return hits[i].CreatedAtI > hits[j].CreatedAtI
+		}
+
+		return hits[i].Points > hits[j].Points
+	})
+
+	hitsPerPage := 20
+	if n, err := strconv.Atoi(params.Get("hitsPerPage")); err == nil && n > 0 {
+		hitsPerPage = n
+	}
+
+	page, _ := strconv.Atoi(params.Get("page"))
+	start := ints.Min(page*hitsPerPage, len(hits))
+	end := ints.Min(start+hitsPerPage, len(hits))
+
+	// offset and length take precedence over page and hitsPerPage
+	if offset, err := strconv.Atoi(params.Get("offset")); err == nil && offset >= 0 {
+		length, _ := strconv.Atoi(params.Get("length"))
+		start = ints.Min(offset, len(hits))
+		end = ints.Min(start+length, len(hits))
+	}
+
+	writeJSON(w, searchResponse{
+		Hits:        hits[start:end],
+		NbHits:      len(hits),
+		Page:        page,
+		NbPages:     (len(hits) + hitsPerPage - 1) / hitsPerPage,
+		HitsPerPage: hitsPerPage,
+		Query:       params.Get("query"),
+	})
+}
+
+func (s *Server) toHit(it *Item) *hit {
+	h := &hit{
+		CreatedAt:   time.Unix(it.Time, 0).UTC(),
+		CreatedAtI:  it.Time,
+		Author:      it.By,
+		ObjectID:    strconv.Itoa(it.ID),
+		Points:      it.Score,
+		NumComments: it.Descendants,
+		Tags:        []string{it.Type, "author_" + it.By},
+	}
+
+	if it.Type == "comment" {
+		story := s.rootOf(it)
+
+		h.CommentText = it.Text
+		h.ParentID = it.Parent
+		h.StoryID = story.ID
+		h.StoryTitle = story.Title
+		h.Tags = append(h.Tags, "story_"+strconv.Itoa(story.ID))
+
+		return h
+	}
+
+	h.Title = it.Title
+	h.URL = it.URL
+	h.StoryText = it.Text
+	h.Tags = append(h.Tags, "story_"+strconv.Itoa(it.ID))
+
+	switch {
+	case strings.HasPrefix(it.Title, "Ask HN"):
+		h.Tags = append(h.Tags, "ask_hn")
+
+	case strings.HasPrefix(it.Title, "Show HN"):
+		h.Tags = append(h.Tags, "show_hn")
+	}
+
+	for _, id := range s.lists["topstories"] {
+		if id == it.ID {
+			h.Tags = append(h.Tags, "front_page")
+		}
+	}
+
+	return h
+}
+
+func (s *Server) rootOf(it *Item) *Item {
+	for it.Parent != 0 && s.items[it.Parent] != nil {
+		it = s.items[it.Parent]
+	}
+
+	return it
+}
+
+// parseTags parses Algolia's tag syntax, where commas mean AND and a
+// parenthesised group means OR: 'story,(author_pg,author_dang)'.
+func parseTags(tags string) [][]string {
+	var (
+		groups [][]string
+		depth  int
+		start  int
+	)
+
+	tags += ","
+
+	for i, c := range tags {
+		switch {
+		case c == '(':
+			depth++
+
+		case c == ')':
+			depth--
+
+		case c == ',' && depth == 0:
+			group := strings.Trim(tags[start:i], "()")
+			start = i + 1
+
+			var alternatives []string
+
+			for _, tag := range strings.Split(group, ",") {
+				if tag != "" {
+					alternatives = append(alternatives, tag)
+				}
+			}
+
+			if len(alternatives) != 0 {
+				groups = append(groups, alternatives)
+			}
+		}
+	}
+
+	return groups
+}
+
+func matchesTags(itemTags []string, groups [][]string) bool {
+	for _, alternatives := range groups {
+		if !containsAny(itemTags, alternatives) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func containsAny(tags []string, alternatives []string) bool {
+	for _, tag := range tags {
+		for _, alternative := range alternatives {
+			if tag == alternative {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func matchesQuery(h *hit, query string) bool {
+	if query == "" {
+		return true
+	}
+
+	text := strings.ToLower(h.Title + " " + h.StoryText + " " + h.CommentText + " " + h.URL)
+
+	for _, word := range strings.Fields(query) {
+		if !strings.Contains(text, word) {
+			return false
+		}
+	}
+
+	return true
+}
+
+type numericFilter struct {
+	attribute string
+	operator  string
+	value     int64
+}
+
+func parseNumericFilters(filters string) []numericFilter {
+	var parsed []numericFilter
+
+	for _, filter := range strings.Split(filters, ",") {
+		for _, operator := range []string{">=", "<=", "!=", ">", "<", "="} {
+			attribute, value, found := strings.Cut(filter, operator)
+			if !found {
+				continue
+			}
+
+			n, err := strconv.ParseInt(value, 10, 64)
+			if err == nil {
+				parsed = append(parsed, numericFilter{attribute: attribute, operator: operator, value: n})
+			}
+
+			break
+		}
+	}
+
+	return parsed
+}
+
+func matchesNumericFilters(h *hit, filters []numericFilter) bool {
+	for _, f := range filters {
+		var actual int64
+
+		switch f.attribute {
+		case "created_at_i":
+			actual = h.CreatedAtI
+		case "points":
+			actual = int64(h.Points)
+		case "num_comments":
+			actual = int64(h.NumComments)
+		case "parent_id":
+			actual = int64(h.ParentID)
+		case "story_id":
+			actual = int64(h.StoryID)
+		default:
+			continue
+		}
+
+		if !compare(actual, f.operator, f.value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func compare(a int64, operator string, b int64) bool {
+	switch operator {
+	case ">=":
+		return a >= b
+	case "<=":
+		return a <= b
+	case "!=":
+		return a != b
+	case ">":
+		return a > b
+	case "<":
+		return a < b
+	default:
+		return a == b
+	}
+}
@@ -0,0 +1,190 @@
+// Package fake provides an HTTP server that mimics the parts of the Firebase,
+// Algolia and hackerweb APIs that circumflex talks to. It serves the stories
+// and comments in the fixtures directory, and is meant for integration tests
+// and for running circumflex without network access:
+//
+//	server := fake.NewServer()
+//	defer server.Close()
+//
+//	config := settings.Default()
+//	config.FirebaseURL = server.FirebaseURL()
+//	config.AlgoliaURL = server.AlgoliaURL()
+//	config.HackerWebURL = server.HackerWebURL()
+//	config.WebURL = server.WebURL()
+//
+// The website accepts logins for the users in the fixtures with Password.
+// Votes, favorites and replies change the items that the server serves.
+package fake
+
+import (
+	_ "embed"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+)
+
+//go:embed fixtures/items.json
+var itemsFixture []byte
+
+//go:embed fixtures/lists.json
+var listsFixture []byte
+
+//go:embed fixtures/users.json
+var usersFixture []byte
+
+//go:embed fixtures/article.html
+var articleFixture []byte
+
+// Placeholder for the address of the server in the fixtures. It is replaced
+// with the actual address once the server has started.
+const serverPlaceholder = "{{server}}"
+
+// Item is a story or comment in the shape of the Firebase API.
+type Item struct {
+	ID          int    `json:"id"`
+	Type        string `json:"type"`
+	By          string `json:"by,omitempty"`
+	Time        int64  `json:"time"`
+	Title       string `json:"title,omitempty"`
+	URL         string `json:"url,omitempty"`
+	Text        string `json:"text,omitempty"`
+	Parent      int    `json:"parent,omitempty"`
+	Kids        []int  `json:"kids,omitempty"`
+	Score       int    `json:"score,omitempty"`
+	Descendants int    `json:"descendants,omitempty"`
+	Deleted     bool   `json:"deleted,omitempty"`
+	Dead        bool   `json:"dead,omitempty"`
+}
+
+// User is a user in the shape of the Firebase API. Submitted is filled in
+// from the items when the user is requested.
+type User struct {
+	ID        string `json:"id"`
+	Created   int64  `json:"created"`
+	Karma     int    `json:"karma"`
+	About     string `json:"about,omitempty"`
+	Submitted []int  `json:"submitted,omitempty"`
+}
+
+type Server struct {
+	*httptest.Server
+
+	// Status, if set, is used as the response code for every request
+	// instead of serving the fixtures. Use it to simulate outages and rate
+	// limiting.
+	Status int
+
+	// HackerWebStatus is like Status, but only applies to the hackerweb API.
+	HackerWebStatus int
+
+	// mu serializes requests, since the website changes the items
+	mu sync.Mutex
+
+	items     map[int]*Item
+	lists     map[string][]int
+	users     map[string]*User
+	votes     map[string]map[int]bool
+	favorites map[string][]int
+}
+
+// NewServer starts a server that serves the bundled fixtures. The caller
+// should call Close when finished.
+func NewServer() *Server {
+	var (
+		items []*Item
+		lists map[string][]int
+		users []*User
+	)
+
+	if err := json.Unmarshal(itemsFixture, &items); err != nil {
+		panic(err)
+	}
+
+	if err := json.Unmarshal(listsFixture, &lists); err != nil {
+		panic(err)
+	}
+
+	if err := json.Unmarshal(usersFixture, &users); err != nil {
+		panic(err)
+	}
+
+	s := NewServerWithItems(items, lists)
+
+	for _, user := range users {
+		s.users[user.ID] = user
+	}
+
+	return s
+}
+
+// NewServerWithItems starts a server that serves the given items. lists maps
+// the name of a Firebase story list, such as 'topstories', to the IDs in it.
+func NewServerWithItems(items []*Item, lists map[string][]int) *Server {
+	s := &Server{
+		items:     make(map[int]*Item, len(items)),
+		lists:     lists,
+		users:     make(map[string]*User),
+		votes:     make(map[string]map[int]bool),
+		favorites: make(map[string][]int),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v0/", s.handleFirebase)
+	mux.HandleFunc("/api/v1/", s.handleAlgolia)
+	mux.HandleFunc("/hackerweb/", s.handleHackerWeb)
+	mux.HandleFunc("/web/", s.handleWeb)
+	mux.HandleFunc("/articles/", s.handleArticle)
+
+	s.Server = httptest.NewServer(s.withStatus(mux))
+
+	for _, it := range items {
+		it.URL = strings.ReplaceAll(it.URL, serverPlaceholder, s.URL)
+		s.items[it.ID] = it
+	}
+
+	return s
+}
+
+func (s *Server) FirebaseURL() string {
+	return s.URL + "/v0"
+}
+
+func (s *Server) AlgoliaURL() string {
+	return s.URL + "/api/v1"
+}
+
+func (s *Server) HackerWebURL() string {
+	return s.URL + "/hackerweb"
+}
+
+// WebURL returns the base URL of the fake news.ycombinator.com.
+func (s *Server) WebURL() string {
+	return s.URL + "/web"
+}
+
+func (s *Server) withStatus(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if s.Status != 0 {
+			w.WriteHeader(s.Status)
+
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) handleArticle(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = w.Write(articleFixture)
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	_ = json.NewEncoder(w).Encode(v)
+}
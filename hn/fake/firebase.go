@@ -0,0 +1,60 @@
+package fake
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// handleFirebase serves /v0/{list}.json, /v0/item/{id}.json and
+// /v0/user/{id}.json.
+func (s *Server) handleFirebase(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/v0/"), ".json")
+
+	if strings.HasPrefix(path, "item/") {
+		id, _ := strconv.Atoi(strings.TrimPrefix(path, "item/"))
+
+		// Like the real API, unknown items are answered with 'null'
+		writeJSON(w, s.items[id])
+
+		return
+	}
+
+	if strings.HasPrefix(path, "user/") {
+		writeJSON(w, s.user(strings.TrimPrefix(path, "user/")))
+
+		return
+	}
+
+	list, ok := s.lists[path]
+	if !ok {
+		http.NotFound(w, r)
+
+		return
+	}
+
+	writeJSON(w, list)
+}
+
+// user returns the user with the given ID and the IDs of their items, newest
+// first, or nil if there is no such user.
+func (s *Server) user(id string) *User {
+	user, ok := s.users[id]
+	if !ok {
+		return nil
+	}
+
+	u := *user
+	u.Submitted = nil
+
+	for _, it := range s.items {
+		if it.By == id {
+			u.Submitted = append(u.Submitted, it.ID)
+		}
+	}
+
+	sort.Sort(sort.Reverse(sort.IntSlice(u.Submitted)))
+
+	return &u
+}
@@ -0,0 +1,124 @@
+package fake
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type hackerWebItem struct {
+	ID            int              `json:"id"`
+	Title         string           `json:"title,omitempty"`
+	Points        int              `json:"points,omitempty"`
+	User          string           `json:"user,omitempty"`
+	Time          int64            `json:"time"`
+	TimeAgo       string           `json:"time_ago"`
+	Type          string           `json:"type"`
+	URL           string           `json:"url,omitempty"`
+	Level         int              `json:"level"`
+	Domain        string           `json:"domain,omitempty"`
+	Comments      []*hackerWebItem `json:"comments"`
+	Content       string           `json:"content"`
+	CommentsCount int              `json:"comments_count"`
+}
+
+// handleHackerWeb serves /hackerweb/item/{id}.
+func (s *Server) handleHackerWeb(w http.ResponseWriter, r *http.Request) {
+	if s.HackerWebStatus != 0 {
+		w.WriteHeader(s.HackerWebStatus)
+
+		return
+	}
+
+	id, _ := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/hackerweb/item/"))
+
+	it, ok := s.items[id]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		writeJSON(w, map[string]string{"error": "Not Found"})
+
+		return
+	}
+
+	writeJSON(w, s.toHackerWebItem(it, 0))
+}
+
+func (s *Server) toHackerWebItem(it *Item, level int) *hackerWebItem {
+	hw := &hackerWebItem{
+		ID:       it.ID,
+		Title:    it.Title,
+		Points:   it.Score,
+		User:     it.By,
+		Time:     it.Time,
+		TimeAgo:  timeAgo(it.Time),
+		Type:     it.Type,
+		URL:      it.URL,
+		Level:    level,
+		Domain:   domain(it.URL),
+		Comments: []*hackerWebItem{},
+	}
+
+	// Like the real API, texts start with a paragraph tag
+	if it.Text != "" {
+		hw.Content = "<p>" + it.Text
+	}
+
+	if it.Deleted {
+		hw.Content = "[deleted]"
+	}
+
+	// The root item is not part of the comment tree, so its replies start
+	// at level 0
+	childLevel := level + 1
+	if it.Type != "comment" {
+		childLevel = 0
+	}
+
+	for _, kid := range it.Kids {
+		child, ok := s.items[kid]
+		if !ok || child.Dead {
+			continue
+		}
+
+		hw.Comments = append(hw.Comments, s.toHackerWebItem(child, childLevel))
+	}
+
+	hw.CommentsCount = countComments(hw)
+
+	return hw
+}
+
+func countComments(it *hackerWebItem) int {
+	count := 0
+
+	for _, c := range it.Comments {
+		count += 1 + countComments(c)
+	}
+
+	return count
+}
+
+func timeAgo(unixTime int64) string {
+	elapsed := time.Since(time.Unix(unixTime, 0))
+
+	switch {
+	case elapsed < time.Hour:
+		return fmt.Sprintf("%d minutes ago", int(elapsed.Minutes()))
+	case elapsed < 24*time.Hour:
+		return fmt.Sprintf("%d hours ago", int(elapsed.Hours()))
+	default:
+		return fmt.Sprintf("%d days ago", int(elapsed.Hours()/24))
+	}
+}
+
+func domain(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimPrefix(u.Hostname(), "www.")
+}
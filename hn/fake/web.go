@@ -0,0 +1,264 @@
+package fake
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"clx/utils/ints"
+)
+
+// Password is the password of every user on the fake website.
+const Password = "hunter2"
+
+const favoritesPerPage = 30
+
+// handleWeb serves the forms of news.ycombinator.com that need an account:
+// /web/login, /web/item, /web/vote, /web/fave, /web/favorites and
+// /web/comment.
+func (s *Server) handleWeb(w http.ResponseWriter, r *http.Request) {
+	_ = r.ParseForm()
+
+	switch strings.TrimPrefix(r.URL.Path, "/web/") {
+	case "login":
+		s.handleLogin(w, r)
+
+	case "item":
+		s.handleItemPage(w, r)
+
+	case "vote":
+		s.handleVote(w, r)
+
+	case "fave":
+		s.handleFave(w, r)
+
+	case "favorites":
+		s.handleFavorites(w, r)
+
+	case "comment":
+		s.handleComment(w, r)
+
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *Server) handleLogin(w http.ResponseWriter, r *http.Request) {
+	username := r.PostForm.Get("acct")
+
+	if _, ok := s.users[username]; !ok || r.PostForm.Get("pw") != Password {
+		writeHTML(w, "Bad login.")
+
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{Name: "user", Value: username + "&" + token(username, "session")})
+	http.Redirect(w, r, r.PostForm.Get("goto"), http.StatusFound)
+}
+
+// loggedInUser returns the user whose cookie was sent with r, or an empty
+// string if there is none.
+func (s *Server) loggedInUser(r *http.Request) string {
+	cookie, err := r.Cookie("user")
+	if err != nil {
+		return ""
+	}
+
+	username, sessionToken, _ := strings.Cut(cookie.Value, "&")
+	if _, ok := s.users[username]; !ok || sessionToken != token(username, "session") {
+		return ""
+	}
+
+	return username
+}
+
+func (s *Server) handleItemPage(w http.ResponseWriter, r *http.Request) {
+	id, _ := strconv.Atoi(r.Form.Get("id"))
+
+	it, ok := s.items[id]
+	if !ok {
+		writeHTML(w, "No such item.")
+
+		return
+	}
+
+	username := s.loggedInUser(r)
+	if username == "" {
+		writeHTML(w, fmt.Sprintf(`<a href="login?goto=item%%3Fid%%3D%d">login</a>`, id))
+
+		return
+	}
+
+	auth := token(username, strconv.Itoa(id))
+	fave := fmt.Sprintf(`<a href="fave?id=%d&amp;auth=%s">favorite</a>`, id, auth)
+
+	if s.isFavorite(username, id) {
+		fave = fmt.Sprintf(`<a href="fave?id=%d&amp;un=t&amp;auth=%s">un-favorite</a>`, id, auth)
+	}
+
+	writeHTML(w, fmt.Sprintf(`<span class="pagetop"><a id="me" href="user?id=%[1]s">%[1]s</a>
+<a id="logout" href="logout?auth=%[2]s&amp;goto=item%%3Fid%%3D%[3]d">logout</a></span>
+<tr class='athing' id='%[3]d'><td class='votelinks'><a id='up_%[3]d' class='clicky'
+href='vote?id=%[3]d&amp;how=up&amp;auth=%[4]s&amp;goto=item%%3Fid%%3D%[3]d'></a></td>
+<td class="title">%[5]s</td></tr>
+<td class="subtext">%[6]s</td>
+<form action="comment" method="post"><input type="hidden" name="parent" value="%[3]d">
+<input type="hidden" name="goto" value="item?id=%[3]d"><input type="hidden" name="hmac" value="%[7]s">
+<textarea name="text"></textarea><input type="submit" value="add comment"></form>`,
+		username, token(username, "logout"), id, auth, html.EscapeString(it.Title), fave,
+		token(username, "reply"+strconv.Itoa(id))))
+}
+
+func (s *Server) handleVote(w http.ResponseWriter, r *http.Request) {
+	id, ok := s.authorize(w, r)
+	if !ok {
+		return
+	}
+
+	username := s.loggedInUser(r)
+
+	if s.votes[username] == nil {
+		s.votes[username] = make(map[int]bool)
+	}
+
+	if !s.votes[username][id] {
+		s.votes[username][id] = true
+		s.items[id].Score++
+	}
+
+	http.Redirect(w, r, "item?id="+strconv.Itoa(id), http.StatusFound)
+}
+
+func (s *Server) handleFave(w http.ResponseWriter, r *http.Request) {
+	id, ok := s.authorize(w, r)
+	if !ok {
+		return
+	}
+
+	username := s.loggedInUser(r)
+
+	var favorites []int
+
+	// Newest favorites are shown first
+	if r.Form.Get("un") != "t" {
+		favorites = append(favorites, id)
+	}
+
+	for _, favorite := range s.favorites[username] {
+		if favorite != id {
+			favorites = append(favorites, favorite)
+		}
+	}
+
+	s.favorites[username] = favorites
+
+	http.Redirect(w, r, "item?id="+strconv.Itoa(id), http.StatusFound)
+}
+
+func (s *Server) handleFavorites(w http.ResponseWriter, r *http.Request) {
+	username := r.Form.Get("id")
+
+	page, _ := strconv.Atoi(r.Form.Get("p"))
+	if page < 1 {
+		page = 1
+	}
+
+	favorites := s.favorites[username]
+	start := ints.Min((page-1)*favoritesPerPage, len(favorites))
+	end := ints.Min(start+favoritesPerPage, len(favorites))
+
+	var sb strings.Builder
+
+	for _, id := range favorites[start:end] {
+		sb.WriteString(fmt.Sprintf("<tr class='athing' id='%d'><td class=\"title\">%s</td></tr>\n",
+			id, html.EscapeString(s.items[id].Title)))
+	}
+
+	if end < len(favorites) {
+		sb.WriteString(fmt.Sprintf("<a href='favorites?id=%s&amp;p=%d' class='morelink' rel='next'>More</a>",
+			url.QueryEscape(username), page+1))
+	}
+
+	writeHTML(w, sb.String())
+}
+
+// handleComment adds the reply as a new item, so that it shows up in the
+// other APIs as well.
+func (s *Server) handleComment(w http.ResponseWriter, r *http.Request) {
+	username := s.loggedInUser(r)
+	parentID, _ := strconv.Atoi(r.PostForm.Get("parent"))
+	parent, ok := s.items[parentID]
+
+	if username == "" || !ok || r.PostForm.Get("hmac") != token(username, "reply"+strconv.Itoa(parentID)) {
+		writeHTML(w, "Unknown or expired link.")
+
+		return
+	}
+
+	text := strings.TrimSpace(r.PostForm.Get("text"))
+	if text == "" {
+		writeHTML(w, "Please try again.")
+
+		return
+	}
+
+	id := 0
+	for existing := range s.items {
+		id = ints.Max(id, existing+1)
+	}
+
+	s.items[id] = &Item{
+		ID:     id,
+		Type:   "comment",
+		By:     username,
+		Time:   time.Now().Unix(),
+		Text:   html.EscapeString(text),
+		Parent: parentID,
+	}
+
+	parent.Kids = append(parent.Kids, id)
+	s.rootOf(parent).Descendants++
+
+	http.Redirect(w, r, r.PostForm.Get("goto"), http.StatusFound)
+}
+
+// authorize checks the session and the auth token of a vote or fave link,
+// and returns the ID of the item that the link is for.
+func (s *Server) authorize(w http.ResponseWriter, r *http.Request) (int, bool) {
+	id, _ := strconv.Atoi(r.Form.Get("id"))
+	username := s.loggedInUser(r)
+
+	_, ok := s.items[id]
+	if username == "" || !ok || r.Form.Get("auth") != token(username, strconv.Itoa(id)) {
+		writeHTML(w, "Can't make that vote.")
+
+		return 0, false
+	}
+
+	return id, true
+}
+
+func (s *Server) isFavorite(username string, id int) bool {
+	for _, favorite := range s.favorites[username] {
+		if favorite == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+// token returns a token that is unique to the user and purpose.
+func token(username string, purpose string) string {
+	return fmt.Sprintf("%x", sha1.Sum([]byte(username+":"+purpose)))[:20]
+}
+
+func writeHTML(w http.ResponseWriter, body string) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = w.Write([]byte("<html><body>" + body + "</body></html>"))
+}
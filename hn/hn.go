@@ -1,9 +1,48 @@
 package hn
 
-import "clx/item"
+import (
+	"context"
+	"sync/atomic"
+	"time"
 
+	"clx/item"
+)
+
+// Service fetches stories, comments and users from Hacker News. Its methods
+// go to the network and can take seconds, so the list calls them from a
+// tea.Cmd rather than from Update, and reports their errors in the status bar.
 type Service interface {
-	FetchItems(itemsToFetch int, category int) (items []*item.Item, errMsg string)
-	FetchItem(id int) *item.Item
-	FetchComments(int) *item.Item
+	// FetchItems returns the stories at the positions from offset up to
+	// offset+itemsToFetch of category. Stories can be left out, so fewer
+	// stories than requested may be returned before the end of the list.
+	// None are returned once the end has been reached. Since an empty batch
+	// does not mean that the end has been reached, services report it
+	// through a context from WithEndOfList.
+	FetchItems(ctx context.Context, offset int, itemsToFetch int, category int) ([]*item.Item, error)
+	FetchItem(ctx context.Context, id int) (*item.Item, error)
+	FetchComments(ctx context.Context, id int) (*item.Item, error)
+	Search(ctx context.Context, query SearchQuery, offset int, itemsToFetch int) ([]*item.Item, error)
+	FetchUser(ctx context.Context, name string) (*User, error)
+
+	// FetchReplies returns the replies by other users to the latest stories
+	// and comments by name that were posted after after, newest first.
+	FetchReplies(ctx context.Context, name string, after time.Time) ([]*Reply, error)
+}
+
+type endOfListKey struct{}
+
+// WithEndOfList returns a context for FetchItems and Search, and a function
+// that reports whether the request reached the end of the list.
+func WithEndOfList(ctx context.Context) (context.Context, func() bool) {
+	reached := new(atomic.Bool)
+
+	return context.WithValue(ctx, endOfListKey{}, reached), reached.Load
+}
+
+// SetEndOfList records that the end of the list has been reached, if ctx
+// comes from WithEndOfList.
+func SetEndOfList(ctx context.Context) {
+	if reached, ok := ctx.Value(endOfListKey{}).(*atomic.Bool); ok {
+		reached.Store(true)
+	}
 }
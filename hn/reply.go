@@ -0,0 +1,13 @@
+package hn
+
+// Reply is a comment that answers a story or comment. Time is a Unix
+// timestamp and Text is HTML in the same format as the text of a comment.
+type Reply struct {
+	ID         int
+	ParentID   int
+	StoryID    int
+	StoryTitle string
+	Author     string
+	Time       int64
+	Text       string
+}
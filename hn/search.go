@@ -0,0 +1,160 @@
+package hn
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	SearchTypeStory   = "story"
+	SearchTypeComment = "comment"
+)
+
+// dateLayout is the format of the dates in the after: and before: filters.
+const dateLayout = "2006-01-02"
+
+// SearchQuery describes a full-text search for stories and comments. Zero
+// values mean that the corresponding filter is not applied.
+type SearchQuery struct {
+	Text string
+
+	// ByDate sorts the results by date, newest first, instead of by
+	// relevance.
+	ByDate bool
+
+	// Type is SearchTypeStory, SearchTypeComment or empty for both.
+	Type      string
+	Author    string
+	MinPoints int
+	After     time.Time
+	Before    time.Time
+}
+
+// ParseSearchQuery parses the text of the search prompt. Words of the form
+// key:value are filters and the rest is the text to search for:
+//
+//	rust author:pg type:story points:100 after:2022-01-01 before:2023-01-01 sort:date
+func ParseSearchQuery(input string) (SearchQuery, error) {
+	var (
+		query SearchQuery
+		words []string
+	)
+
+	for _, word := range strings.Fields(input) {
+		// Words such as 'https://example.com' or 'C++:' are part of the
+		// text and not filters
+		key, value, found := strings.Cut(word, ":")
+		if !found || value == "" || !isFilter(key) {
+			words = append(words, word)
+
+			continue
+		}
+
+		if err := query.SetFilter(key, value); err != nil {
+			return SearchQuery{}, err
+		}
+	}
+
+	query.Text = strings.Join(words, " ")
+
+	return query, nil
+}
+
+// SetFilter sets the filter key of the search prompt, such as author or
+// points, to value.
+func (q *SearchQuery) SetFilter(key string, value string) error {
+	switch key {
+	case "author", "by":
+		q.Author = value
+
+	case "type":
+		if value != SearchTypeStory && value != SearchTypeComment {
+			return fmt.Errorf("type must be %s or %s, not %q", SearchTypeStory, SearchTypeComment, value)
+		}
+
+		q.Type = value
+
+	case "points":
+		points, err := strconv.Atoi(value)
+		if err != nil || points < 0 {
+			return fmt.Errorf("points must be a whole number, not %q", value)
+		}
+
+		q.MinPoints = points
+
+	case "after", "before":
+		date, err := time.ParseInLocation(dateLayout, value, time.Local)
+		if err != nil {
+			return fmt.Errorf("%s must be a date such as 2022-12-31, not %q", key, value)
+		}
+
+		if key == "after" {
+			q.After = date
+		} else {
+			q.Before = date
+		}
+
+	case "sort":
+		if value != "date" && value != "relevance" {
+			return fmt.Errorf("sort must be date or relevance, not %q", value)
+		}
+
+		q.ByDate = value == "date"
+
+	}
+
+	return nil
+}
+
+func isFilter(key string) bool {
+	switch key {
+	case "author", "by", "type", "points", "after", "before", "sort":
+		return true
+
+	default:
+		return false
+	}
+}
+
+// IsEmpty reports whether the query neither has text nor filters.
+func (q SearchQuery) IsEmpty() bool {
+	return q == SearchQuery{} || q == SearchQuery{ByDate: true}
+}
+
+// PastFrontPageQuery returns the query for the stories that were submitted on
+// the given day in UTC. Sorted by relevance, which puts the stories with the
+// most points first, they approximate the front page of that day.
+func PastFrontPageQuery(day time.Time) SearchQuery {
+	start := StartOfDay(day)
+
+	return SearchQuery{
+		Type:   SearchTypeStory,
+		After:  start,
+		Before: start.AddDate(0, 0, 1),
+	}
+}
+
+// StartOfDay returns midnight in UTC of the day that t falls on in UTC.
+func StartOfDay(t time.Time) time.Time {
+	t = t.UTC()
+
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// Yesterday returns the start of yesterday in UTC. It is the latest day whose
+// front page is over, and the day that the Past category shows by default.
+func Yesterday() time.Time {
+	return StartOfDay(time.Now()).AddDate(0, 0, -1)
+}
+
+// ParseDay parses a date such as 2022-12-31 as the start of that day in UTC.
+func ParseDay(value string) (time.Time, error) {
+	day, err := time.ParseInLocation(dateLayout, value, time.UTC)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q is not a date such as 2022-12-31", value)
+	}
+
+	return day, nil
+}
@@ -0,0 +1,59 @@
+package hn_test
+
+import (
+	"testing"
+	"time"
+
+	"clx/hn"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSearchQuery(t *testing.T) {
+	t.Parallel()
+
+	query, err := hn.ParseSearchQuery("rust author:pg type:story points:100 after:2022-01-01 sort:date compiler")
+	require.NoError(t, err)
+
+	assert.Equal(t, hn.SearchQuery{
+		Text:      "rust compiler",
+		ByDate:    true,
+		Type:      hn.SearchTypeStory,
+		Author:    "pg",
+		MinPoints: 100,
+		After:     time.Date(2022, 1, 1, 0, 0, 0, 0, time.Local),
+	}, query)
+
+	query, err = hn.ParseSearchQuery("https://example.com C++: by:dang")
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com C++:", query.Text)
+	assert.Equal(t, "dang", query.Author)
+
+	for _, input := range []string{"type:job", "points:many", "before:yesterday", "sort:oldest"} {
+		_, err = hn.ParseSearchQuery(input)
+		assert.Error(t, err, input)
+	}
+}
+
+func TestPastFrontPageQuery(t *testing.T) {
+	t.Parallel()
+
+	day, err := hn.ParseDay("2022-10-28")
+	require.NoError(t, err)
+
+	// The day is taken in UTC, whatever the time of day and the location
+	local := time.FixedZone("UTC+10", 10*60*60)
+	for _, d := range []time.Time{day, day.Add(23 * time.Hour), day.Add(20 * time.Hour).In(local)} {
+		assert.Equal(t, hn.SearchQuery{
+			Type:   hn.SearchTypeStory,
+			After:  time.Date(2022, 10, 28, 0, 0, 0, 0, time.UTC),
+			Before: time.Date(2022, 10, 29, 0, 0, 0, 0, time.UTC),
+		}, hn.PastFrontPageQuery(d), d)
+	}
+
+	for _, input := range []string{"", "yesterday", "2022-13-01", "28.10.2022"} {
+		_, err = hn.ParseDay(input)
+		assert.Error(t, err, input)
+	}
+}
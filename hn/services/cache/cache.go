@@ -0,0 +1,364 @@
+// Package cache provides an hn.Service that stores the responses of another
+// service on disk. Story lists are considered fresh for a configurable
+// duration per category, after which they are fetched again. In offline mode,
+// everything is served from disk regardless of its age.
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"clx/constants/category"
+	"clx/hn"
+	"clx/item"
+)
+
+const (
+	listsDir    = "lists"
+	itemsDir    = "items"
+	commentsDir = "comments"
+	usersDir    = "users"
+)
+
+type refreshKey struct{}
+
+// WithRefresh returns a context that makes the service skip fresh entries
+// and go to the network. The response is still written to the cache.
+func WithRefresh(ctx context.Context) context.Context {
+	return context.WithValue(ctx, refreshKey{}, true)
+}
+
+func isRefresh(ctx context.Context) bool {
+	refresh, _ := ctx.Value(refreshKey{}).(bool)
+
+	return refresh
+}
+
+type Service struct {
+	next        hn.Service
+	dir         string
+	categoryTTL map[int]time.Duration
+	itemTTL     time.Duration
+	offline     bool
+	now         func() time.Time
+}
+
+type entry struct {
+	FetchedAt time.Time
+	Requested int          `json:",omitempty"`
+	Items     []*item.Item `json:",omitempty"`
+	Item      *item.Item   `json:",omitempty"`
+	User      *hn.User     `json:",omitempty"`
+
+	// Offsets holds the offset of the request that returned each of Items.
+	// Services can return fewer stories than requested, so the position of
+	// a story in Items says nothing about the offset it was fetched at.
+	Offsets []int `json:",omitempty"`
+
+	// EndOfList is set if the request for the last page of Items reached the
+	// end of the list.
+	EndOfList bool `json:",omitempty"`
+}
+
+// New returns a service that caches the responses of next in dir. Story
+// lists are fresh for the duration in categoryTTL for their category, and
+// single items and comment sections for itemTTL. A missing or zero TTL means
+// that the response is always fetched again, but still stored for offline use.
+func New(next hn.Service, dir string, categoryTTL map[int]time.Duration, itemTTL time.Duration) *Service {
+	return &Service{
+		next:        next,
+		dir:         dir,
+		categoryTTL: categoryTTL,
+		itemTTL:     itemTTL,
+		now:         time.Now,
+	}
+}
+
+// NewOffline returns a service that only serves responses that have
+// previously been cached in dir. Requests for anything else fail with
+// hn.ErrNotCached.
+func NewOffline(dir string) *Service {
+	return &Service{
+		dir:     dir,
+		offline: true,
+		now:     time.Now,
+	}
+}
+
+func (s *Service) FetchItems(ctx context.Context, offset int, itemsToFetch int, category int) ([]*item.Item, error) {
+	path := s.listPath(category)
+
+	cached, ok := s.read(path)
+
+	// Offline, a shorter list is better than nothing. Online, it means that
+	// the list has to be fetched again.
+	if ok && (s.offline || (cached.Requested >= offset+itemsToFetch && s.isFresh(ctx, cached, s.categoryTTL[category]))) {
+		// Offline, nothing comes after the cached list
+		if offset+itemsToFetch >= cached.Requested && (s.offline || cached.EndOfList) {
+			hn.SetEndOfList(ctx)
+		}
+
+		return cached.itemsBetween(offset, offset+itemsToFetch), nil
+	}
+
+	if s.offline {
+		return nil, fmt.Errorf("could not fetch stories: %w", &hn.Error{Kind: hn.ErrNotCached, URL: path})
+	}
+
+	nextCtx, reachedEnd := hn.WithEndOfList(ctx)
+
+	items, err := s.next.FetchItems(nextCtx, offset, itemsToFetch, category)
+	if err != nil {
+		return nil, err
+	}
+
+	if reachedEnd() {
+		hn.SetEndOfList(ctx)
+	}
+
+	switch {
+	case offset == 0:
+		s.write(path, &entry{
+			FetchedAt: s.now(),
+			Requested: itemsToFetch,
+			Items:     items,
+			Offsets:   repeat(offset, len(items)),
+			EndOfList: reachedEnd(),
+		})
+
+	// Later pages are appended to the cached list if they continue it, so
+	// that they are available offline. The list keeps the age of its first
+	// page.
+	case ok && cached.Requested == offset:
+		s.write(path, &entry{
+			FetchedAt: cached.FetchedAt,
+			Requested: offset + itemsToFetch,
+			Items:     append(cached.Items, items...),
+			Offsets:   append(cached.offsets(), repeat(offset, len(items))...),
+			EndOfList: reachedEnd(),
+		})
+	}
+
+	return items, nil
+}
+
+// itemsBetween returns the cached stories that were fetched at offsets from
+// start up to end, but no more than end-start of them.
+func (e *entry) itemsBetween(start int, end int) []*item.Item {
+	items := []*item.Item{}
+
+	for i, offset := range e.offsets() {
+		if offset >= start && offset < end && len(items) < end-start {
+			items = append(items, e.Items[i])
+		}
+	}
+
+	return items
+}
+
+// offsets returns Offsets, or the position of each story for lists that were
+// cached before Offsets was added.
+func (e *entry) offsets() []int {
+	if len(e.Offsets) == len(e.Items) {
+		return e.Offsets
+	}
+
+	offsets := make([]int, len(e.Items))
+	for i := range offsets {
+		offsets[i] = i
+	}
+
+	return offsets
+}
+
+func repeat(offset int, n int) []int {
+	offsets := make([]int, n)
+	for i := range offsets {
+		offsets[i] = offset
+	}
+
+	return offsets
+}
+
+func (s *Service) FetchItem(ctx context.Context, id int) (*item.Item, error) {
+	path := s.path(itemsDir, id)
+
+	if cached, ok := s.read(path); ok && (s.offline || s.isFresh(ctx, cached, s.itemTTL)) {
+		return cached.Item, nil
+	}
+
+	if s.offline {
+		return nil, fmt.Errorf("could not fetch item %d: %w", id, &hn.Error{Kind: hn.ErrNotCached, URL: path})
+	}
+
+	it, err := s.next.FetchItem(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	s.write(path, &entry{FetchedAt: s.now(), Item: it})
+
+	return it, nil
+}
+
+func (s *Service) FetchComments(ctx context.Context, id int) (*item.Item, error) {
+	path := s.path(commentsDir, id)
+
+	if cached, ok := s.read(path); ok && (s.offline || s.isFresh(ctx, cached, s.itemTTL)) {
+		return cached.Item, nil
+	}
+
+	if s.offline {
+		return nil, fmt.Errorf("could not fetch comments for item %d: %w", id,
+			&hn.Error{Kind: hn.ErrNotCached, URL: path})
+	}
+
+	story, err := s.next.FetchComments(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	s.write(path, &entry{FetchedAt: s.now(), Item: story})
+
+	return story, nil
+}
+
+func (s *Service) FetchUser(ctx context.Context, name string) (*hn.User, error) {
+	path := filepath.Join(s.dir, usersDir, url.PathEscape(name)+".json")
+
+	if cached, ok := s.read(path); ok && (s.offline || s.isFresh(ctx, cached, s.itemTTL)) {
+		return cached.User, nil
+	}
+
+	if s.offline {
+		return nil, fmt.Errorf("could not fetch user %s: %w", name, &hn.Error{Kind: hn.ErrNotCached, URL: path})
+	}
+
+	user, err := s.next.FetchUser(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	s.write(path, &entry{FetchedAt: s.now(), User: user})
+
+	return user, nil
+}
+
+// Search is not cached, since the results depend on the query. Offline, it
+// always fails.
+func (s *Service) Search(ctx context.Context, query hn.SearchQuery, offset int, itemsToFetch int) ([]*item.Item, error) {
+	if s.offline {
+		return nil, fmt.Errorf("could not search for %q: %w", query.Text, &hn.Error{Kind: hn.ErrNotCached})
+	}
+
+	return s.next.Search(ctx, query, offset, itemsToFetch)
+}
+
+// FetchReplies is not cached, since only new replies are asked for. Offline,
+// it always fails.
+func (s *Service) FetchReplies(ctx context.Context, name string, after time.Time) ([]*hn.Reply, error) {
+	if s.offline {
+		return nil, fmt.Errorf("could not fetch the replies to %s: %w", name, &hn.Error{Kind: hn.ErrNotCached})
+	}
+
+	return s.next.FetchReplies(ctx, name, after)
+}
+
+// Prune removes cached responses that were written more than maxAge ago.
+func (s *Service) Prune(maxAge time.Duration) error {
+	deadline := s.now().Add(-maxAge)
+
+	for _, dir := range []string{listsDir, itemsDir, commentsDir, usersDir} {
+		files, err := os.ReadDir(filepath.Join(s.dir, dir))
+		if os.IsNotExist(err) {
+			continue
+		}
+
+		if err != nil {
+			return fmt.Errorf("could not read cache directory: %w", err)
+		}
+
+		for _, f := range files {
+			info, err := f.Info()
+			if err != nil || !info.ModTime().Before(deadline) {
+				continue
+			}
+
+			if err := os.Remove(filepath.Join(s.dir, dir, f.Name())); err != nil {
+				return fmt.Errorf("could not remove cached response: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) isFresh(ctx context.Context, e *entry, ttl time.Duration) bool {
+	return !isRefresh(ctx) && s.now().Sub(e.FetchedAt) < ttl
+}
+
+func (s *Service) path(dir string, id int) string {
+	return filepath.Join(s.dir, dir, strconv.Itoa(id)+".json")
+}
+
+// listPath returns the path of the list of cat. The Past category is the
+// front page of yesterday, so it is stored per day; otherwise the list of an
+// earlier day would be shown offline.
+func (s *Service) listPath(cat int) string {
+	if cat == category.Past {
+		day := hn.StartOfDay(s.now()).AddDate(0, 0, -1)
+
+		return filepath.Join(s.dir, listsDir, strconv.Itoa(cat)+"-"+day.Format("2006-01-02")+".json")
+	}
+
+	return s.path(listsDir, cat)
+}
+
+// read returns the entry at path. Missing and unreadable entries are treated
+// as cache misses.
+func (s *Service) read(path string) (*entry, bool) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+
+	e := new(entry)
+	if err := json.Unmarshal(content, e); err != nil {
+		return nil, false
+	}
+
+	return e, true
+}
+
+// write stores e at path. Failing to write to the cache should not fail the
+// request, so errors are ignored.
+func (s *Service) write(path string, e *entry) {
+	content, err := json.Marshal(e)
+	if err != nil {
+		return
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return
+	}
+
+	// Write to a temporary file first so that concurrent readers never see a
+	// partially written entry
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return
+	}
+
+	_, writeErr := tmp.Write(content)
+	closeErr := tmp.Close()
+
+	if writeErr != nil || closeErr != nil || os.Rename(tmp.Name(), path) != nil {
+		_ = os.Remove(tmp.Name())
+	}
+}
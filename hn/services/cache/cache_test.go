@@ -0,0 +1,285 @@
+package cache_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"clx/constants/category"
+	"clx/hn"
+	"clx/hn/services/cache"
+	"clx/item"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type countingService struct {
+	calls int
+	err   error
+
+	// skip leaves out the first skip stories of every page, like job posts
+	// or muted stories are left out
+	skip int
+
+	// end is the length of the list, if it is set
+	end int
+}
+
+func (s *countingService) FetchItems(ctx context.Context, offset int, itemsToFetch int, _ int) ([]*item.Item, error) {
+	s.calls++
+
+	last := offset + itemsToFetch
+	if s.end != 0 && last >= s.end {
+		last = s.end
+		hn.SetEndOfList(ctx)
+	}
+
+	items := make([]*item.Item, 0, itemsToFetch)
+	for i := offset + 1 + s.skip; i <= last; i++ {
+		items = append(items, &item.Item{ID: i})
+	}
+
+	return items, s.err
+}
+
+func (s *countingService) FetchItem(_ context.Context, id int) (*item.Item, error) {
+	s.calls++
+
+	return &item.Item{ID: id}, s.err
+}
+
+func (s *countingService) FetchComments(_ context.Context, id int) (*item.Item, error) {
+	s.calls++
+
+	return &item.Item{ID: id, Comments: []*item.Item{{ID: id + 1}}}, s.err
+}
+
+func (s *countingService) Search(_ context.Context, _ hn.SearchQuery, _ int, _ int) ([]*item.Item, error) {
+	s.calls++
+
+	return nil, s.err
+}
+
+func (s *countingService) FetchUser(_ context.Context, name string) (*hn.User, error) {
+	s.calls++
+
+	return &hn.User{ID: name, Karma: 10}, s.err
+}
+
+func (s *countingService) FetchReplies(_ context.Context, _ string, _ time.Time) ([]*hn.Reply, error) {
+	s.calls++
+
+	return nil, s.err
+}
+
+func TestFetchItemsUsesTTL(t *testing.T) {
+	t.Parallel()
+
+	next := &countingService{}
+	ttl := map[int]time.Duration{category.FrontPage: time.Hour}
+	service := cache.New(next, t.TempDir(), ttl, time.Hour)
+
+	_, err := service.FetchItems(context.Background(), 0, 3, category.FrontPage)
+	require.NoError(t, err)
+
+	items, err := service.FetchItems(context.Background(), 0, 2, category.FrontPage)
+	require.NoError(t, err)
+	assert.Len(t, items, 2)
+	assert.Equal(t, 1, next.calls)
+
+	// More items than were cached
+	_, err = service.FetchItems(context.Background(), 0, 5, category.FrontPage)
+	require.NoError(t, err)
+	assert.Equal(t, 2, next.calls)
+
+	// No TTL for this category
+	_, err = service.FetchItems(context.Background(), 0, 3, category.New)
+	require.NoError(t, err)
+	_, err = service.FetchItems(context.Background(), 0, 3, category.New)
+	require.NoError(t, err)
+	assert.Equal(t, 4, next.calls)
+
+	_, err = service.FetchItems(cache.WithRefresh(context.Background()), 0, 3, category.FrontPage)
+	require.NoError(t, err)
+	assert.Equal(t, 5, next.calls)
+}
+
+func TestFetchItemsAppendsPages(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	next := &countingService{}
+	ttl := map[int]time.Duration{category.New: time.Hour}
+	service := cache.New(next, dir, ttl, time.Hour)
+
+	_, err := service.FetchItems(context.Background(), 0, 3, category.New)
+	require.NoError(t, err)
+	_, err = service.FetchItems(context.Background(), 3, 3, category.New)
+	require.NoError(t, err)
+
+	items, err := service.FetchItems(context.Background(), 0, 6, category.New)
+	require.NoError(t, err)
+	require.Len(t, items, 6)
+	assert.Equal(t, 6, items[5].ID)
+	assert.Equal(t, 2, next.calls)
+
+	offline := cache.NewOffline(dir)
+
+	items, err = offline.FetchItems(context.Background(), 3, 3, category.New)
+	require.NoError(t, err)
+	require.Len(t, items, 3)
+	assert.Equal(t, 4, items[0].ID)
+
+	// The end of the cached list
+	items, err = offline.FetchItems(context.Background(), 6, 3, category.New)
+	require.NoError(t, err)
+	assert.Empty(t, items)
+}
+
+func TestFetchItemsKeepsEndOfList(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	next := &countingService{end: 6}
+	ttl := map[int]time.Duration{category.New: time.Hour}
+	service := cache.New(next, dir, ttl, time.Hour)
+
+	ctx, reachedEnd := hn.WithEndOfList(context.Background())
+	_, err := service.FetchItems(ctx, 0, 3, category.New)
+	require.NoError(t, err)
+	assert.False(t, reachedEnd())
+
+	ctx, reachedEnd = hn.WithEndOfList(context.Background())
+	_, err = service.FetchItems(ctx, 3, 3, category.New)
+	require.NoError(t, err)
+	assert.True(t, reachedEnd())
+
+	// From the cache
+	ctx, reachedEnd = hn.WithEndOfList(context.Background())
+	_, err = service.FetchItems(ctx, 3, 3, category.New)
+	require.NoError(t, err)
+	assert.True(t, reachedEnd())
+	assert.Equal(t, 2, next.calls)
+
+	ctx, reachedEnd = hn.WithEndOfList(context.Background())
+	_, err = cache.NewOffline(dir).FetchItems(ctx, 0, 3, category.New)
+	require.NoError(t, err)
+	assert.False(t, reachedEnd())
+}
+
+func TestFetchItemsAppendsShortPages(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	next := &countingService{skip: 1}
+	ttl := map[int]time.Duration{category.New: time.Hour}
+	service := cache.New(next, dir, ttl, time.Hour)
+
+	_, err := service.FetchItems(context.Background(), 0, 3, category.New)
+	require.NoError(t, err)
+	_, err = service.FetchItems(context.Background(), 3, 3, category.New)
+	require.NoError(t, err)
+	assert.Equal(t, 2, next.calls)
+
+	items, err := service.FetchItems(context.Background(), 3, 3, category.New)
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	assert.Equal(t, 5, items[0].ID)
+	assert.Equal(t, 2, next.calls)
+
+	items, err = cache.NewOffline(dir).FetchItems(context.Background(), 0, 6, category.New)
+	require.NoError(t, err)
+	assert.Len(t, items, 4)
+}
+
+func TestOffline(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	online := cache.New(&countingService{}, dir, nil, 0)
+
+	_, err := online.FetchItems(context.Background(), 0, 3, category.Ask)
+	require.NoError(t, err)
+	_, err = online.FetchComments(context.Background(), 10)
+	require.NoError(t, err)
+	_, err = online.FetchUser(context.Background(), "pg")
+	require.NoError(t, err)
+
+	offline := cache.NewOffline(dir)
+
+	items, err := offline.FetchItems(context.Background(), 0, 5, category.Ask)
+	require.NoError(t, err)
+	assert.Len(t, items, 3)
+
+	story, err := offline.FetchComments(context.Background(), 10)
+	require.NoError(t, err)
+	require.Len(t, story.Comments, 1)
+	assert.Equal(t, 11, story.Comments[0].ID)
+
+	user, err := offline.FetchUser(context.Background(), "pg")
+	require.NoError(t, err)
+	assert.Equal(t, 10, user.Karma)
+
+	_, err = offline.FetchUser(context.Background(), "dang")
+	assert.ErrorIs(t, err, hn.ErrNotCached)
+
+	_, err = offline.FetchItems(context.Background(), 0, 3, category.Show)
+	assert.ErrorIs(t, err, hn.ErrNotCached)
+
+	_, err = offline.FetchItem(context.Background(), 10)
+	assert.ErrorIs(t, err, hn.ErrNotCached)
+}
+
+func TestPastListIsStoredPerDay(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	online := cache.New(&countingService{}, dir, nil, 0)
+
+	_, err := online.FetchItems(context.Background(), 0, 3, category.Past)
+	require.NoError(t, err)
+
+	// Move the list to an earlier day, as if it had been cached back then
+	earlier := filepath.Join(dir, "lists", strconv.Itoa(category.Past)+"-2006-01-02.json")
+	require.NoError(t, os.Rename(filepath.Join(dir, "lists",
+		strconv.Itoa(category.Past)+"-"+hn.Yesterday().Format("2006-01-02")+".json"), earlier))
+
+	_, err = cache.NewOffline(dir).FetchItems(context.Background(), 0, 3, category.Past)
+	assert.ErrorIs(t, err, hn.ErrNotCached)
+}
+
+func TestPrune(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	service := cache.New(&countingService{}, dir, nil, 0)
+
+	_, err := service.FetchItems(context.Background(), 0, 3, category.Ask)
+	require.NoError(t, err)
+	_, err = service.FetchItem(context.Background(), 10)
+	require.NoError(t, err)
+	_, err = service.FetchComments(context.Background(), 10)
+	require.NoError(t, err)
+	_, err = service.FetchUser(context.Background(), "pg")
+	require.NoError(t, err)
+
+	old := time.Now().Add(-48 * time.Hour)
+
+	files, err := filepath.Glob(filepath.Join(dir, "*", "*.json"))
+	require.NoError(t, err)
+	require.Len(t, files, 4)
+
+	for _, file := range files {
+		require.NoError(t, os.Chtimes(file, old, old))
+	}
+
+	require.NoError(t, service.Prune(24*time.Hour))
+
+	files, err = filepath.Glob(filepath.Join(dir, "*", "*.json"))
+	require.NoError(t, err)
+	assert.Empty(t, files)
+}
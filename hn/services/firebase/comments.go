@@ -0,0 +1,259 @@
+// Package firebase builds comment trees from the official Hacker News API at
+// https://hacker-news.firebaseio.com. The API only serves one item per
+// request, so each level of the tree is fetched by a fixed number of workers.
+package firebase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"clx/endpoints"
+	"clx/hn"
+	"clx/item"
+	"clx/utils/http"
+
+	"github.com/bobesa/go-domain-util/domainutil"
+	"github.com/nleeper/goment"
+)
+
+// DefaultWorkers is the default number of requests that are in flight at the
+// same time.
+const DefaultWorkers = http.MaxConnsPerHost
+
+type CommentFetcher struct {
+	baseURL string
+	workers int
+}
+
+// New returns a CommentFetcher that talks to the Firebase API at baseURL and
+// makes at most workers requests at the same time.
+func New(baseURL string, workers int) *CommentFetcher {
+	if workers < 1 {
+		workers = 1
+	}
+
+	return &CommentFetcher{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		workers: workers,
+	}
+}
+
+// FetchComments returns the item with the given ID together with the full
+// tree of replies below it, in the same shape as the hackerweb API.
+func (f *CommentFetcher) FetchComments(ctx context.Context, id int) (*item.Item, error) {
+	root, err := f.get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch comments for item %d: %w", id, err)
+	}
+
+	// The Firebase API answers with 'null' for IDs that do not exist
+	if root.Id == 0 {
+		return nil, fmt.Errorf("could not fetch comments for item %d: %w", id,
+			&hn.Error{Kind: hn.ErrNotFound, URL: f.itemURL(id)})
+	}
+
+	replies, err := f.fetchReplies(ctx, root.Kids)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch comments for item %d: %w", id, err)
+	}
+
+	story := mapItem(root, 0)
+
+	// The root item is not part of the comment tree, so its replies start
+	// at level 0
+	childLevel := 0
+	if root.Type == "comment" {
+		childLevel = 1
+	}
+
+	story.Comments = buildTree(replies, root.Kids, childLevel)
+	story.CommentsCount = countComments(story)
+
+	// goment is not safe for concurrent use, so the relative times are
+	// computed once the tree is complete
+	now, err := goment.New()
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch comments for item %d: %w", id, err)
+	}
+
+	setTimeAgo(story, now)
+
+	return story, nil
+}
+
+func (f *CommentFetcher) itemURL(id int) string {
+	return fmt.Sprintf("%s/item/%d.json", f.baseURL, id)
+}
+
+// fetchReplies fetches the items with the given IDs and all replies below
+// them one level at a time. Items that do not exist or are dead are left out
+// together with their replies.
+func (f *CommentFetcher) fetchReplies(ctx context.Context, ids []int) (map[int]*endpoints.HN, error) {
+	replies := make(map[int]*endpoints.HN)
+
+	for len(ids) > 0 {
+		items, err := f.fetchAll(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+
+		var next []int
+
+		for i, it := range items {
+			if it.Id == 0 || it.Dead {
+				continue
+			}
+
+			replies[ids[i]] = it
+			next = append(next, it.Kids...)
+		}
+
+		ids = next
+	}
+
+	return replies, nil
+}
+
+// fetchAll fetches the items with the given IDs with at most f.workers
+// requests in flight and returns them in the same order. The first failed
+// request stops the others.
+func (f *CommentFetcher) fetchAll(ctx context.Context, ids []int) ([]*endpoints.HN, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	items := make([]*endpoints.HN, len(ids))
+	jobs := make(chan int)
+
+	var (
+		wg      sync.WaitGroup
+		errOnce sync.Once
+		err     error
+	)
+
+	workers := f.workers
+	if len(ids) < workers {
+		workers = len(ids)
+	}
+
+	for n := 0; n < workers; n++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := range jobs {
+				it, getErr := f.get(ctx, ids[i])
+				if getErr != nil {
+					errOnce.Do(func() {
+						err = getErr
+						cancel()
+					})
+
+					continue
+				}
+
+				items[i] = it
+			}
+		}()
+	}
+
+	for i := range ids {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+		}
+	}
+
+	close(jobs)
+	wg.Wait()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	return items, nil
+}
+
+func (f *CommentFetcher) get(ctx context.Context, id int) (*endpoints.HN, error) {
+	result := new(endpoints.HN)
+
+	if err := http.Get(ctx, f.itemURL(id), 5*time.Second, result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// buildTree returns the comments with the given IDs from replies, in the
+// order of ids, with their own replies below them.
+func buildTree(replies map[int]*endpoints.HN, ids []int, level int) []*item.Item {
+	comments := make([]*item.Item, 0, len(ids))
+
+	for _, id := range ids {
+		reply, ok := replies[id]
+		if !ok {
+			continue
+		}
+
+		comment := mapItem(reply, level)
+		comment.Comments = buildTree(replies, reply.Kids, level+1)
+		comment.CommentsCount = countComments(comment)
+
+		comments = append(comments, comment)
+	}
+
+	return comments
+}
+
+func mapItem(it *endpoints.HN, level int) *item.Item {
+	content := ""
+	if it.Text != "" {
+		// hackerweb starts every text with a paragraph tag, which the
+		// comment section relies on
+		content = "<p>" + it.Text
+	}
+
+	if it.Deleted {
+		content = "[deleted]"
+	}
+
+	return &item.Item{
+		ID:      it.Id,
+		Title:   it.Title,
+		Points:  it.Score,
+		User:    it.By,
+		Time:    int64(it.Time),
+		Type:    it.Type,
+		URL:     it.Url,
+		Level:   level,
+		Domain:  domainutil.Domain(it.Url),
+		Content: content,
+	}
+}
+
+func setTimeAgo(it *item.Item, now *goment.Goment) {
+	if moment, err := goment.Unix(it.Time); err == nil {
+		it.TimeAgo = moment.From(now)
+	}
+
+	for _, c := range it.Comments {
+		setTimeAgo(c, now)
+	}
+}
+
+func countComments(it *item.Item) int {
+	count := 0
+
+	for _, c := range it.Comments {
+		count += 1 + countComments(c)
+	}
+
+	return count
+}
@@ -0,0 +1,115 @@
+package firebase_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"clx/hn"
+	"clx/hn/fake"
+	"clx/hn/services/firebase"
+	"clx/item"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ids(items []*item.Item) []int {
+	result := make([]int, 0, len(items))
+
+	for _, it := range items {
+		result = append(result, it.ID)
+	}
+
+	return result
+}
+
+func TestFetchComments(t *testing.T) {
+	t.Parallel()
+
+	server := fake.NewServer()
+	defer server.Close()
+
+	// A single worker makes sure that waiting for replies does not hold on
+	// to the only slot
+	for _, workers := range []int{1, firebase.DefaultWorkers} {
+		story, err := firebase.New(server.FirebaseURL(), workers).FetchComments(context.Background(), 1)
+		require.NoError(t, err)
+
+		assert.Equal(t, "Lorem ipsum dolor sit amet (2019)", story.Title)
+		assert.Equal(t, 5, story.CommentsCount)
+		assert.NotEmpty(t, story.TimeAgo)
+
+		require.Equal(t, []int{101, 104}, ids(story.Comments))
+		require.Equal(t, []int{102, 103}, ids(story.Comments[0].Comments))
+		require.Equal(t, []int{105}, ids(story.Comments[0].Comments[1].Comments))
+
+		assert.Equal(t, 0, story.Comments[0].Level)
+		assert.Equal(t, 1, story.Comments[0].Comments[1].Level)
+		assert.Equal(t, 2, story.Comments[0].Comments[1].Comments[0].Level)
+		assert.Equal(t, 3, story.Comments[0].CommentsCount)
+		assert.Equal(t, "<p>Phasellus ut nulla risus. Ut sed volutpat dui.<p>Donec quam tortor, porttitor a ante sed.",
+			story.Comments[0].Comments[0].Content)
+	}
+}
+
+func TestFetchCommentsSkipsDeadAndMarksDeleted(t *testing.T) {
+	t.Parallel()
+
+	server := fake.NewServerWithItems([]*fake.Item{
+		{ID: 1, Type: "story", Title: "Story", Kids: []int{2, 3, 4, 5}},
+		{ID: 2, Type: "comment", Text: "first"},
+		{ID: 3, Type: "comment", Dead: true, Text: "dead"},
+		{ID: 4, Type: "comment", Deleted: true, Kids: []int{6}},
+		{ID: 6, Type: "comment", Text: "reply to deleted"},
+	}, nil)
+	defer server.Close()
+
+	story, err := firebase.New(server.FirebaseURL(), 2).FetchComments(context.Background(), 1)
+	require.NoError(t, err)
+
+	// 5 does not exist and 3 is dead
+	require.Equal(t, []int{2, 4}, ids(story.Comments))
+	assert.Equal(t, "[deleted]", story.Comments[1].Content)
+	assert.Equal(t, 3, story.CommentsCount)
+}
+
+func TestFetchCommentsLargeThread(t *testing.T) {
+	t.Parallel()
+
+	// A story with 500 replies that each have a reply of their own
+	items := []*fake.Item{{ID: 1, Type: "story", Title: "Story"}}
+
+	for id := 2; id < 1002; id += 2 {
+		items[0].Kids = append(items[0].Kids, id)
+		items = append(items,
+			&fake.Item{ID: id, Type: "comment", Text: "reply", Kids: []int{id + 1}},
+			&fake.Item{ID: id + 1, Type: "comment", Text: "nested reply"})
+	}
+
+	server := fake.NewServerWithItems(items, nil)
+	defer server.Close()
+
+	story, err := firebase.New(server.FirebaseURL(), 4).FetchComments(context.Background(), 1)
+	require.NoError(t, err)
+
+	require.Len(t, story.Comments, 500)
+	assert.Equal(t, 1000, story.CommentsCount)
+	assert.Equal(t, 2, story.Comments[0].ID)
+	assert.Equal(t, []int{1001}, ids(story.Comments[499].Comments))
+}
+
+func TestFetchCommentsErrors(t *testing.T) {
+	t.Parallel()
+
+	server := fake.NewServer()
+	defer server.Close()
+
+	_, err := firebase.New(server.FirebaseURL(), 4).FetchComments(context.Background(), 999)
+	assert.ErrorIs(t, err, hn.ErrNotFound)
+
+	server.Status = http.StatusServiceUnavailable
+
+	_, err = firebase.New(server.FirebaseURL(), 4).FetchComments(context.Background(), 1)
+	assert.ErrorIs(t, err, hn.ErrUnavailable)
+}
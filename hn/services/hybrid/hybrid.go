@@ -1,74 +1,357 @@
 package hybrid
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net/url"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
-	"clx/app"
 	"clx/constants/category"
 	"clx/endpoints"
+	"clx/hn"
+	"clx/hn/services/firebase"
 	"clx/item"
+	"clx/utils/http"
+	"clx/utils/ints"
 
 	"github.com/bobesa/go-domain-util/domainutil"
-	"github.com/go-resty/resty/v2"
 )
 
+// CommentBackend selects the API that comment trees are fetched from.
+type CommentBackend int
+
 const (
-	uri = "https://hacker-news.firebaseio.com/v0"
+	// HackerWeb fetches the whole comment tree in a single request from the
+	// hackerweb API and falls back to Firebase if that fails.
+	HackerWeb CommentBackend = iota
+
+	// Firebase builds the comment tree from the official API, one item per
+	// request.
+	Firebase
 )
 
-type Service struct{}
+type Service struct {
+	firebaseURL    string
+	algoliaURL     string
+	hackerWebURL   string
+	commentBackend CommentBackend
+	firebase       *firebase.CommentFetcher
+
+	mu    sync.Mutex
+	lists map[int]*storyList
+}
+
+// storyList is the list of story IDs of a category from the Firebase API.
+// Later pages are taken from the same list as the first page, so that stories
+// do not move between pages while the category is paged through.
+type storyList struct {
+	ids []int
+
+	// before is when the oldest story in ids was submitted, or zero if it
+	// has not been looked up yet
+	before time.Time
+}
+
+// New returns a service that fetches the list of stories from the Firebase
+// API at firebaseURL, the metadata for each story from the Algolia API at
+// algoliaURL and comment trees from the API selected by commentBackend.
+func New(firebaseURL, algoliaURL, hackerWebURL string, commentBackend CommentBackend) *Service {
+	return &Service{
+		firebaseURL:    strings.TrimSuffix(firebaseURL, "/"),
+		algoliaURL:     strings.TrimSuffix(algoliaURL, "/"),
+		hackerWebURL:   strings.TrimSuffix(hackerWebURL, "/"),
+		commentBackend: commentBackend,
+		firebase:       firebase.New(firebaseURL, firebase.DefaultWorkers),
+		lists:          make(map[int]*storyList),
+	}
+}
+
+// FetchItems returns the stories at the positions from offset up to
+// offset+itemsToFetch of cat. The list from the Firebase API ends after a few
+// hundred stories, after which it is continued with older stories from
+// Algolia. Job posts on the front page are left out, so fewer stories than
+// requested can be returned before the end of the list.
+func (s *Service) FetchItems(ctx context.Context, offset int, itemsToFetch int, cat int) ([]*item.Item, error) {
+	if cat == category.Past {
+		return s.FetchPastFrontPage(ctx, offset, itemsToFetch, hn.Yesterday())
+	}
+
+	list, err := s.getStoriesList(ctx, cat, offset == 0)
+	if err != nil {
+		return nil, err
+	}
 
-func (s *Service) FetchItems(itemsToFetch int, category int) (items []*item.Item, errMsg string) {
-	// Posts of the type: 'Company (YC __) is hiring ...' is filtered out
-	// from Algolia. For this reason, we ask for one more item than we need.
-	itemsToFetchWithBuffer := itemsToFetch + 1
-	listOfIDs, errMsg := fetchStoriesList(category)
-	if errMsg != "" {
-		return nil, errMsg
+	stories := []*item.Item{}
+	end := offset + itemsToFetch
+
+	if offset < len(list.ids) {
+		stories, err = s.fetchStoriesByID(ctx, list.ids[offset:ints.Min(end, len(list.ids))], cat)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	ids := getStoryListURIParam(listOfIDs[0:itemsToFetchWithBuffer])
+	if !canContinue(cat) {
+		if end >= len(list.ids) {
+			hn.SetEndOfList(ctx)
+		}
 
-	url := "https://hn.algolia.com/api/v1/search?tags=story," +
-		"(" + ids + ")&hitsPerPage=" + strconv.Itoa(itemsToFetchWithBuffer)
+		return stories, nil
+	}
 
-	var a *endpoints.Algolia
+	if end <= len(list.ids) {
+		return stories, nil
+	}
 
-	client := resty.New()
-	client.SetTimeout(10 * time.Second)
+	start := offset - len(list.ids)
+	if start < 0 {
+		start = 0
+	}
 
-	_, err := client.R().
-		SetHeader("User-Agent", app.Name+"/"+app.Version).
-		SetResult(&a).
-		Get(url)
+	older, err := s.fetchOlderStories(ctx, cat, list, start, end-len(list.ids)-start)
 	if err != nil {
-		return nil, err.Error()
+		return nil, err
+	}
+
+	return append(stories, older...), nil
+}
+
+// fetchStoriesByID returns the stories with the given IDs in the same order.
+func (s *Service) fetchStoriesByID(ctx context.Context, ids []int, cat int) ([]*item.Item, error) {
+	// Job posts are tagged 'job' rather than 'story' on Algolia, which
+	// leaves them out of the other categories
+	itemType := "story"
+	if cat == category.Jobs {
+		itemType = "job"
+	}
+
+	url := s.algoliaURL + "/search?tags=" + itemType + "," +
+		"(" + getStoryListURIParam(ids) + ")&hitsPerPage=" + strconv.Itoa(len(ids))
+
+	a := new(endpoints.Algolia)
+
+	if err := http.Get(ctx, url, 10*time.Second, a); err != nil {
+		return nil, fmt.Errorf("could not fetch stories: %w", err)
 	}
 
-	mapOfItemsWithMetaData := mapStories(a)
-	orderedStories := joinStories(listOfIDs, mapOfItemsWithMetaData)
+	stories := joinStories(ids, mapStories(a))
+	if stories == nil {
+		return []*item.Item{}, nil
+	}
+
+	return stories, nil
+}
+
+// canContinue reports whether cat is continued with older stories from
+// Algolia after the end of its list.
+func canContinue(cat int) bool {
+	_, _, ok := getOlderStoriesQuery(cat)
 
-	return orderedStories[0:min(itemsToFetch, len(orderedStories))], ""
+	return ok
 }
 
-func fetchStoriesList(category int) (stories []int, errMsg string) {
-	url := fmt.Sprintf("%s/%s.json", uri, getCategory(category))
+// getOlderStoriesQuery returns the Algolia endpoint and tags for the stories
+// that continue the list of cat. New stories are continued by date, and the
+// front page and the best stories with the stories with the most points.
+func getOlderStoriesQuery(cat int) (string, string, bool) {
+	switch cat {
+	case category.FrontPage, category.Best:
+		return "/search", "story", true
+
+	case category.New:
+		return "/search_by_date", "story", true
+
+	case category.Ask:
+		return "/search_by_date", "ask_hn", true
+
+	case category.Show:
+		return "/search_by_date", "show_hn", true
 
-	client := resty.New()
-	client.SetTimeout(10 * time.Second)
+	case category.Jobs:
+		return "/search_by_date", "job", true
 
-	_, err := client.R().
-		SetHeader("User-Agent", app.Name+"/"+app.Version).
-		SetResult(&stories).
-		Get(url)
+	default:
+		return "", "", false
+	}
+}
+
+// fetchOlderStories returns the stories of cat that were submitted before the
+// oldest story in list, starting at offset. Stories that are in list are left
+// out. Algolia serves at most 1000 results per query, which is where the
+// category ends.
+func (s *Service) fetchOlderStories(ctx context.Context, cat int, list *storyList, offset int, itemsToFetch int) ([]*item.Item, error) {
+	before, err := s.getOldestTime(ctx, list)
 	if err != nil {
-		return nil, err.Error()
+		return nil, err
+	}
+
+	endpoint, tags, _ := getOlderStoriesQuery(cat)
+
+	params := url.Values{}
+	params.Set("tags", tags)
+	params.Set("hitsPerPage", strconv.Itoa(itemsToFetch))
+	params.Set("offset", strconv.Itoa(offset))
+	params.Set("length", strconv.Itoa(itemsToFetch))
+
+	if !before.IsZero() {
+		params.Set("numericFilters", "created_at_i<"+strconv.FormatInt(before.Unix(), 10))
+	}
+
+	a := new(endpoints.Algolia)
+
+	if err := http.Get(ctx, s.algoliaURL+endpoint+"?"+params.Encode(), 10*time.Second, a); err != nil {
+		return nil, fmt.Errorf("could not fetch older stories: %w", err)
+	}
+
+	if len(a.Hits) < itemsToFetch {
+		hn.SetEndOfList(ctx)
+	}
+
+	inList := make(map[int]bool, len(list.ids))
+	for _, id := range list.ids {
+		inList[id] = true
+	}
+
+	stories := make([]*item.Item, 0, len(a.Hits))
+
+	for i := range a.Hits {
+		story := mapSearchHit(&a.Hits[i])
+
+		if !inList[story.ID] {
+			stories = append(stories, story)
+		}
+	}
+
+	return stories, nil
+}
+
+// getStoriesList returns the list of story IDs of cat. The list is fetched
+// again if refresh is set or if it has not been fetched before.
+func (s *Service) getStoriesList(ctx context.Context, cat int, refresh bool) (*storyList, error) {
+	s.mu.Lock()
+	list, ok := s.lists[cat]
+	s.mu.Unlock()
+
+	if ok && !refresh {
+		return list, nil
+	}
+
+	ids, err := s.fetchStoriesList(ctx, cat)
+	if err != nil {
+		return nil, err
+	}
+
+	list = &storyList{ids: ids}
+
+	s.mu.Lock()
+	s.lists[cat] = list
+	s.mu.Unlock()
+
+	return list, nil
+}
+
+// getOldestTime returns when the oldest story in list was submitted. IDs grow
+// over time, so that is the story with the lowest ID.
+func (s *Service) getOldestTime(ctx context.Context, list *storyList) (time.Time, error) {
+	s.mu.Lock()
+	before := list.before
+	s.mu.Unlock()
+
+	if !before.IsZero() || len(list.ids) == 0 {
+		return before, nil
+	}
+
+	oldest := list.ids[0]
+	for _, id := range list.ids {
+		if id < oldest {
+			oldest = id
+		}
+	}
+
+	story, err := s.FetchItem(ctx, oldest)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	before = time.Unix(story.Time, 0)
+
+	s.mu.Lock()
+	list.before = before
+	s.mu.Unlock()
+
+	return before, nil
+}
+
+// FetchPastFrontPage returns the stories with the most points that were
+// submitted on the given day in UTC, which approximates the front page of
+// that day.
+func (s *Service) FetchPastFrontPage(ctx context.Context, offset int, itemsToFetch int, day time.Time) ([]*item.Item, error) {
+	return s.Search(ctx, hn.PastFrontPageQuery(day), offset, itemsToFetch)
+}
+
+func (s *Service) fetchStoriesList(ctx context.Context, cat int) ([]int, error) {
+	if cat == category.Active {
+		return s.fetchActiveStoriesList(ctx)
+	}
+
+	list, err := getCategory(cat)
+	if err != nil {
+		return nil, err
+	}
+
+	var stories []int
+
+	url := fmt.Sprintf("%s/%s.json", s.firebaseURL, list)
+
+	if err := http.Get(ctx, url, 10*time.Second, &stories); err != nil {
+		return nil, fmt.Errorf("could not fetch list of stories: %w", err)
+	}
+
+	return stories, nil
+}
+
+// fetchActiveStoriesList returns the IDs of the stories that appear most often
+// among the latest comments on the site, which is roughly what the 'active'
+// page on Hacker News shows.
+func (s *Service) fetchActiveStoriesList(ctx context.Context) ([]int, error) {
+	url := s.algoliaURL + "/search_by_date?tags=comment&hitsPerPage=1000"
+
+	a := new(endpoints.Algolia)
+
+	if err := http.Get(ctx, url, 10*time.Second, a); err != nil {
+		return nil, fmt.Errorf("could not fetch latest comments: %w", err)
+	}
+
+	var (
+		ids      []int
+		comments = make(map[int]int)
+	)
+
+	for i := range a.Hits {
+		id := getStoryID(&a.Hits[i])
+		if id == 0 {
+			continue
+		}
+
+		if comments[id] == 0 {
+			ids = append(ids, id)
+		}
+
+		comments[id]++
 	}
 
-	return stories, ""
+	// Stories with the same number of comments stay ordered by their latest
+	// comment
+	sort.SliceStable(ids, func(i, j int) bool {
+		return comments[ids[i]] > comments[ids[j]]
+	})
+
+	return ids, nil
 }
 
 func getStoryListURIParam(ids []int) string {
@@ -81,22 +364,28 @@ func getStoryListURIParam(ids []int) string {
 	return sb.String()
 }
 
-func getCategory(cat int) string {
+func getCategory(cat int) (string, error) {
 	switch cat {
 	case category.FrontPage:
-		return "topstories"
+		return "topstories", nil
 
 	case category.New:
-		return "newstories"
+		return "newstories", nil
 
 	case category.Ask:
-		return "askstories"
+		return "askstories", nil
 
 	case category.Show:
-		return "showstories"
+		return "showstories", nil
+
+	case category.Best:
+		return "beststories", nil
+
+	case category.Jobs:
+		return "jobstories", nil
 
 	default:
-		panic("Unsupported c: " + strconv.Itoa(cat))
+		return "", fmt.Errorf("unsupported category %d", cat)
 	}
 }
 
@@ -156,22 +445,126 @@ func joinStories(orderedIds []int, stories map[int]*item.Item) []*item.Item {
 	return orderedStories
 }
 
-func (s Service) FetchItem(id int) *item.Item {
-	hn := new(endpoints.HN)
+func (s *Service) FetchItem(ctx context.Context, id int) (*item.Item, error) {
+	story := new(endpoints.HN)
+	url := fmt.Sprintf("%s/item/%d.json", s.firebaseURL, id)
 
-	client := resty.New()
-	client.SetTimeout(5 * time.Second)
-	client.SetBaseURL("https://hacker-news.firebaseio.com/v0/item/")
+	if err := http.Get(ctx, url, 5*time.Second, story); err != nil {
+		return nil, fmt.Errorf("could not fetch item %d: %w", id, err)
+	}
 
-	_, err := client.R().
-		SetHeader("User-Agent", app.Name+"/"+app.Version).
-		SetResult(hn).
-		Get(strconv.Itoa(id) + ".json")
-	if err != nil {
-		panic(err)
+	// The Firebase API answers with 'null' for IDs that do not exist
+	if story.Id == 0 {
+		return nil, fmt.Errorf("could not fetch item %d: %w", id, &hn.Error{Kind: hn.ErrNotFound, URL: url})
+	}
+
+	return mapItem(story), nil
+}
+
+func (s *Service) FetchUser(ctx context.Context, name string) (*hn.User, error) {
+	user := new(endpoints.HNUser)
+	endpoint := fmt.Sprintf("%s/user/%s.json", s.firebaseURL, url.PathEscape(name))
+
+	if err := http.Get(ctx, endpoint, 5*time.Second, user); err != nil {
+		return nil, fmt.Errorf("could not fetch user %s: %w", name, err)
+	}
+
+	// Like items, unknown users are answered with 'null'
+	if user.ID == "" {
+		return nil, fmt.Errorf("could not fetch user %s: %w", name, &hn.Error{Kind: hn.ErrNotFound, URL: endpoint})
+	}
+
+	return &hn.User{
+		ID:      user.ID,
+		Karma:   user.Karma,
+		Created: user.Created,
+		About:   user.About,
+	}, nil
+}
+
+// repliedItems is the number of the latest stories and comments of a user
+// that FetchReplies looks for replies to.
+const repliedItems = 100
+
+// FetchReplies finds the replies to the latest stories and comments by name
+// in two searches: one for the stories and comments themselves, and one for
+// the comments in the same stories, which are then narrowed down to the ones
+// whose parent is by name. At most 1000 comments are searched, newest first.
+func (s *Service) FetchReplies(ctx context.Context, name string, after time.Time) ([]*hn.Reply, error) {
+	params := url.Values{}
+	params.Set("tags", "(story,comment),author_"+name)
+	params.Set("hitsPerPage", strconv.Itoa(repliedItems))
+
+	own := new(endpoints.Algolia)
+
+	if err := http.Get(ctx, s.algoliaURL+"/search_by_date?"+params.Encode(), 10*time.Second, own); err != nil {
+		return nil, fmt.Errorf("could not fetch the stories and comments by %s: %w", name, err)
 	}
 
-	return mapItem(hn)
+	var (
+		isOwn     = make(map[int]bool)
+		storyTags []string
+		seen      = make(map[int]bool)
+	)
+
+	for i := range own.Hits {
+		id, _ := strconv.Atoi(own.Hits[i].ObjectID)
+		isOwn[id] = true
+
+		storyID := id
+		if own.Hits[i].HasTag("comment") {
+			storyID = getStoryID(&own.Hits[i])
+		}
+
+		if storyID != 0 && !seen[storyID] {
+			seen[storyID] = true
+			storyTags = append(storyTags, "story_"+strconv.Itoa(storyID))
+		}
+	}
+
+	replies := []*hn.Reply{}
+
+	if len(storyTags) == 0 {
+		return replies, nil
+	}
+
+	params = url.Values{}
+	params.Set("tags", "comment,("+strings.Join(storyTags, ",")+")")
+	params.Set("hitsPerPage", "1000")
+
+	if !after.IsZero() {
+		params.Set("numericFilters", "created_at_i>"+strconv.FormatInt(after.Unix(), 10))
+	}
+
+	comments := new(endpoints.Algolia)
+
+	if err := http.Get(ctx, s.algoliaURL+"/search_by_date?"+params.Encode(), 10*time.Second, comments); err != nil {
+		return nil, fmt.Errorf("could not fetch the replies to %s: %w", name, err)
+	}
+
+	for i := range comments.Hits {
+		hit := &comments.Hits[i]
+
+		if !isOwn[getParentID(hit)] || strings.EqualFold(hit.Author, name) {
+			continue
+		}
+
+		id, _ := strconv.Atoi(hit.ObjectID)
+		storyTitle, _ := hit.StoryTitle.(string)
+		text, _ := hit.CommentText.(string)
+
+		replies = append(replies, &hn.Reply{
+			ID:         id,
+			ParentID:   getParentID(hit),
+			StoryID:    getStoryID(hit),
+			StoryTitle: sanitize(storyTitle),
+			Author:     hit.Author,
+			Time:       int64(hit.CreatedAtI),
+			Text:       text,
+		})
+	}
+
+	return replies, nil
 }
 
 func mapItem(hn *endpoints.HN) *item.Item {
@@ -188,22 +581,39 @@ func mapItem(hn *endpoints.HN) *item.Item {
 	}
 }
 
-func (s Service) FetchComments(id int) *item.Item {
+func (s *Service) FetchComments(ctx context.Context, id int) (*item.Item, error) {
+	if s.commentBackend == Firebase {
+		return s.firebase.FetchComments(ctx, id)
+	}
+
+	story, err := s.fetchCommentsFromHackerWeb(ctx, id)
+	if err != nil && ctx.Err() == nil && shouldFallBack(err) {
+		return s.firebase.FetchComments(ctx, id)
+	}
+
+	return story, err
+}
+
+// shouldFallBack reports whether err means that hackerweb could not be reached
+// or failed. Other errors, such as a missing item, are returned as they are.
+func shouldFallBack(err error) bool {
+	return errors.Is(err, hn.ErrUnavailable) || errors.Is(err, hn.ErrTimeout)
+}
+
+func (s *Service) fetchCommentsFromHackerWeb(ctx context.Context, id int) (*item.Item, error) {
 	comments := new(endpoints.Comments)
+	url := s.hackerWebURL + "/item/" + strconv.Itoa(id)
 
-	client := resty.New()
-	client.SetTimeout(5 * time.Second)
-	client.SetBaseURL("http://api.hackerwebapp.com/item/")
+	if err := http.Get(ctx, url, 5*time.Second, comments); err != nil {
+		return nil, fmt.Errorf("could not fetch comments for item %d: %w", id, err)
+	}
 
-	_, err := client.R().
-		SetHeader("User-Agent", app.Name+"/"+app.Version).
-		SetResult(comments).
-		Get(strconv.Itoa(id))
-	if err != nil {
-		panic(err)
+	if comments.ID == 0 {
+		return nil, fmt.Errorf("could not fetch comments for item %d: %w", id,
+			&hn.Error{Kind: hn.ErrNotFound, URL: url})
 	}
 
-	return mapComments(comments)
+	return mapComments(comments), nil
 }
 
 func mapComments(comments *endpoints.Comments) *item.Item {
@@ -230,9 +640,126 @@ func mapComments(comments *endpoints.Comments) *item.Item {
 	}
 }
 
-func min(a, b int) int {
-	if a < b {
-		return a
+func (s *Service) Search(ctx context.Context, query hn.SearchQuery, offset int, itemsToFetch int) ([]*item.Item, error) {
+	endpoint := "/search"
+	if query.ByDate {
+		endpoint = "/search_by_date"
+	}
+
+	params := url.Values{}
+	params.Set("query", query.Text)
+	params.Set("tags", getSearchTags(query))
+	params.Set("hitsPerPage", strconv.Itoa(itemsToFetch))
+
+	if offset > 0 {
+		params.Set("offset", strconv.Itoa(offset))
+		params.Set("length", strconv.Itoa(itemsToFetch))
+	}
+
+	if filters := getSearchFilters(query); filters != "" {
+		params.Set("numericFilters", filters)
+	}
+
+	a := new(endpoints.Algolia)
+
+	if err := http.Get(ctx, s.algoliaURL+endpoint+"?"+params.Encode(), 10*time.Second, a); err != nil {
+		return nil, fmt.Errorf("could not search for %q: %w", query.Text, err)
+	}
+
+	if len(a.Hits) < itemsToFetch {
+		hn.SetEndOfList(ctx)
+	}
+
+	results := make([]*item.Item, 0, len(a.Hits))
+
+	for i := range a.Hits {
+		results = append(results, mapSearchHit(&a.Hits[i]))
+	}
+
+	return results, nil
+}
+
+func getSearchTags(query hn.SearchQuery) string {
+	tags := []string{"(story,comment)"}
+	if query.Type != "" {
+		tags = []string{query.Type}
 	}
-	return b
+
+	if query.Author != "" {
+		tags = append(tags, "author_"+query.Author)
+	}
+
+	return strings.Join(tags, ",")
+}
+
+func getSearchFilters(query hn.SearchQuery) string {
+	var filters []string
+
+	if query.MinPoints > 0 {
+		filters = append(filters, "points>="+strconv.Itoa(query.MinPoints))
+	}
+
+	if !query.After.IsZero() {
+		filters = append(filters, "created_at_i>="+strconv.FormatInt(query.After.Unix(), 10))
+	}
+
+	if !query.Before.IsZero() {
+		filters = append(filters, "created_at_i<"+strconv.FormatInt(query.Before.Unix(), 10))
+	}
+
+	return strings.Join(filters, ",")
+}
+
+// mapSearchHit maps a story or comment hit to an item. Comments are mapped
+// to the story they belong to, so that opening them shows the comment
+// section they appear in. The text of the comment is kept in Content.
+func mapSearchHit(hit *endpoints.AlgoliaHit) *item.Item {
+	id, _ := strconv.Atoi(hit.ObjectID)
+
+	if !hit.HasTag("comment") {
+		return &item.Item{
+			ID:            id,
+			Title:         sanitize(hit.Title),
+			Points:        hit.Points,
+			User:          hit.Author,
+			Time:          int64(hit.CreatedAtI),
+			Type:          "story",
+			URL:           hit.URL,
+			Domain:        domainutil.Domain(hit.URL),
+			CommentsCount: hit.NumComments,
+		}
+	}
+
+	storyURL, _ := hit.StoryURL.(string)
+	storyTitle, _ := hit.StoryTitle.(string)
+	commentText, _ := hit.CommentText.(string)
+
+	return &item.Item{
+		ID:      getStoryID(hit),
+		Title:   sanitize(storyTitle),
+		Points:  hit.Points,
+		User:    hit.Author,
+		Time:    int64(hit.CreatedAtI),
+		Type:    "comment",
+		URL:     storyURL,
+		Domain:  domainutil.Domain(storyURL),
+		Content: commentText,
+	}
+}
+
+// getStoryID returns the ID of the story that a comment hit belongs to, or 0
+// if the hit does not have one.
+func getStoryID(hit *endpoints.AlgoliaHit) int {
+	// JSON numbers are decoded as float64 into interface{}
+	storyID, _ := hit.StoryID.(float64)
+
+	return int(storyID)
+}
+
+// getParentID returns the ID of the story or comment that a comment hit
+// replies to, or 0 if the hit does not have one.
+func getParentID(hit *endpoints.AlgoliaHit) int {
+	parentID, _ := hit.ParentID.(float64)
+
+	return int(parentID)
 }
@@ -0,0 +1,402 @@
+package hybrid_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"clx/constants/category"
+	"clx/hn"
+	"clx/hn/fake"
+	"clx/hn/services/hybrid"
+	"clx/item"
+	"clx/reader"
+	"clx/settings"
+	"clx/tree"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newService(server *fake.Server) *hybrid.Service {
+	return hybrid.New(server.FirebaseURL(), server.AlgoliaURL(), server.HackerWebURL(), hybrid.HackerWeb)
+}
+
+func ids(items []*item.Item) []int {
+	result := make([]int, 0, len(items))
+
+	for _, it := range items {
+		result = append(result, it.ID)
+	}
+
+	return result
+}
+
+func TestFetchItems(t *testing.T) {
+	t.Parallel()
+
+	server := fake.NewServer()
+	defer server.Close()
+
+	stories, err := newService(server).FetchItems(context.Background(), 0, 3, category.New)
+	require.NoError(t, err)
+
+	require.Len(t, stories, 3)
+	assert.Equal(t, 6, stories[0].ID)
+	assert.Equal(t, 5, stories[1].ID)
+	assert.Equal(t, 4, stories[2].ID)
+	assert.Equal(t, "zeta", stories[0].User)
+}
+
+func TestFetchItemsWithOffset(t *testing.T) {
+	t.Parallel()
+
+	server := fake.NewServer()
+	defer server.Close()
+
+	service := newService(server)
+
+	stories, err := service.FetchItems(context.Background(), 3, 2, category.New)
+	require.NoError(t, err)
+	require.Len(t, stories, 2)
+	assert.Equal(t, 3, stories[0].ID)
+	assert.Equal(t, 2, stories[1].ID)
+
+	// Fewer stories than requested at the end of the list
+	stories, err = service.FetchItems(context.Background(), 5, 3, category.New)
+	require.NoError(t, err)
+	require.Len(t, stories, 1)
+	assert.Equal(t, 1, stories[0].ID)
+
+	stories, err = service.FetchItems(context.Background(), 6, 3, category.New)
+	require.NoError(t, err)
+	assert.Empty(t, stories)
+
+	results, err := service.Search(context.Background(), hn.SearchQuery{Type: hn.SearchTypeStory}, 4, 10)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, 5, results[0].ID)
+	assert.Equal(t, 6, results[1].ID)
+}
+
+func TestFetchItemsReportsEndOfList(t *testing.T) {
+	t.Parallel()
+
+	var items []*fake.Item
+	for id := 1; id <= 6; id++ {
+		items = append(items, &fake.Item{ID: id, Type: "story", Title: "Story", Time: int64(1666900000 + id*60)})
+	}
+
+	server := fake.NewServerWithItems(items, map[string][]int{"newstories": {6, 5, 4}, "askstories": {3, 2, 1}})
+	defer server.Close()
+
+	service := newService(server)
+
+	ctx, reachedEnd := hn.WithEndOfList(context.Background())
+	_, err := service.FetchItems(ctx, 0, 3, category.Ask)
+	require.NoError(t, err)
+	assert.False(t, reachedEnd())
+
+	ctx, reachedEnd = hn.WithEndOfList(context.Background())
+	_, err = service.FetchItems(ctx, 0, 3, category.Active)
+	require.NoError(t, err)
+	assert.True(t, reachedEnd())
+
+	// The list is continued with older stories from Algolia
+	ctx, reachedEnd = hn.WithEndOfList(context.Background())
+	stories, err := service.FetchItems(ctx, 3, 2, category.New)
+	require.NoError(t, err)
+	assert.Equal(t, []int{3, 2}, ids(stories))
+	assert.False(t, reachedEnd())
+
+	ctx, reachedEnd = hn.WithEndOfList(context.Background())
+	stories, err = service.FetchItems(ctx, 5, 3, category.New)
+	require.NoError(t, err)
+	assert.Equal(t, []int{1}, ids(stories))
+	assert.True(t, reachedEnd())
+
+	ctx, reachedEnd = hn.WithEndOfList(context.Background())
+	_, err = service.Search(ctx, hn.SearchQuery{Type: hn.SearchTypeStory}, 0, 3)
+	require.NoError(t, err)
+	assert.False(t, reachedEnd())
+
+	ctx, reachedEnd = hn.WithEndOfList(context.Background())
+	_, err = service.Search(ctx, hn.SearchQuery{Type: hn.SearchTypeStory}, 4, 10)
+	require.NoError(t, err)
+	assert.True(t, reachedEnd())
+}
+
+func TestFetchItemsContinuesWithOlderStories(t *testing.T) {
+	t.Parallel()
+
+	var items []*fake.Item
+	for id := 1; id <= 6; id++ {
+		items = append(items, &fake.Item{ID: id, Type: "story", Title: "Story", Time: int64(1666900000 + id*60)})
+	}
+
+	server := fake.NewServerWithItems(items, map[string][]int{"newstories": {6, 5, 4}})
+	defer server.Close()
+
+	service := newService(server)
+
+	stories, err := service.FetchItems(context.Background(), 0, 3, category.New)
+	require.NoError(t, err)
+	assert.Equal(t, []int{6, 5, 4}, ids(stories))
+
+	// The list from the Firebase API ends after 4
+	stories, err = service.FetchItems(context.Background(), 2, 3, category.New)
+	require.NoError(t, err)
+	assert.Equal(t, []int{4, 3, 2}, ids(stories))
+
+	stories, err = service.FetchItems(context.Background(), 5, 3, category.New)
+	require.NoError(t, err)
+	assert.Equal(t, []int{1}, ids(stories))
+
+	stories, err = service.FetchItems(context.Background(), 6, 3, category.New)
+	require.NoError(t, err)
+	assert.Empty(t, stories)
+}
+
+func TestFetchItemsFromOtherCategories(t *testing.T) {
+	t.Parallel()
+
+	server := fake.NewServer()
+	defer server.Close()
+
+	service := newService(server)
+
+	jobs, err := service.FetchItems(context.Background(), 0, 10, category.Jobs)
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	assert.Equal(t, 7, jobs[0].ID)
+
+	best, err := service.FetchItems(context.Background(), 0, 2, category.Best)
+	require.NoError(t, err)
+	require.Len(t, best, 2)
+	assert.Equal(t, 1, best[0].ID)
+	assert.Equal(t, 2, best[1].ID)
+
+	// Story 1 has the most comments, followed by story 3
+	active, err := service.FetchItems(context.Background(), 0, 10, category.Active)
+	require.NoError(t, err)
+	require.Len(t, active, 2)
+	assert.Equal(t, 1, active[0].ID)
+	assert.Equal(t, 3, active[1].ID)
+}
+
+func TestFetchPastFrontPage(t *testing.T) {
+	t.Parallel()
+
+	server := fake.NewServer()
+	defer server.Close()
+
+	service := newService(server)
+
+	stories, err := service.FetchPastFrontPage(context.Background(), 0, 3, time.Date(2022, 10, 28, 12, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Len(t, stories, 3)
+	assert.Equal(t, 1, stories[0].ID)
+	assert.Equal(t, 2, stories[1].ID)
+	assert.Equal(t, 3, stories[2].ID)
+
+	stories, err = service.FetchPastFrontPage(context.Background(), 0, 3, time.Date(2022, 10, 27, 12, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Empty(t, stories)
+}
+
+func TestFetchCommentsAndPrint(t *testing.T) {
+	t.Parallel()
+
+	server := fake.NewServer()
+	defer server.Close()
+
+	story, err := newService(server).FetchComments(context.Background(), 1)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Lorem ipsum dolor sit amet (2019)", story.Title)
+	assert.Equal(t, 5, story.CommentsCount)
+	require.Len(t, story.Comments, 2)
+	assert.Equal(t, "theta", story.Comments[0].User)
+	assert.Equal(t, 0, story.Comments[0].Level)
+	assert.Equal(t, 1, story.Comments[0].Comments[1].Level)
+	assert.Equal(t, 2, story.Comments[0].Comments[1].Comments[0].Level)
+
+	commentSection := tree.Print(story, settings.Default(), 120, time.Now().Unix())
+
+	assert.Contains(t, commentSection, "Maecenas suscipit aliquet lorem")
+	assert.Contains(t, commentSection, "Duis accumsan eros sit amet libero facilisis")
+}
+
+func TestFetchCommentsFallsBackToFirebase(t *testing.T) {
+	t.Parallel()
+
+	server := fake.NewServer()
+	server.HackerWebStatus = http.StatusBadGateway
+	defer server.Close()
+
+	story, err := newService(server).FetchComments(context.Background(), 1)
+	require.NoError(t, err)
+
+	assert.Equal(t, 5, story.CommentsCount)
+	require.Len(t, story.Comments, 2)
+	assert.Equal(t, "theta", story.Comments[0].User)
+}
+
+func TestFetchCommentsDoesNotFallBackForMissingItems(t *testing.T) {
+	t.Parallel()
+
+	server := fake.NewServer()
+	server.HackerWebStatus = http.StatusNotFound
+	defer server.Close()
+
+	// The story exists in the Firebase API, but hackerweb says it does not
+	_, err := newService(server).FetchComments(context.Background(), 1)
+	assert.ErrorIs(t, err, hn.ErrNotFound)
+}
+
+func TestFetchItemAndReadArticle(t *testing.T) {
+	t.Parallel()
+
+	server := fake.NewServer()
+	defer server.Close()
+
+	story, err := newService(server).FetchItem(context.Background(), 1)
+	require.NoError(t, err)
+
+	assert.Equal(t, server.URL+"/articles/lorem", story.URL)
+
+	article, err := reader.GetArticle(story.URL, story.Title, 70, " ▎")
+	require.NoError(t, err)
+
+	assert.Contains(t, article, "Vivamus elementum")
+}
+
+func TestSearch(t *testing.T) {
+	t.Parallel()
+
+	server := fake.NewServer()
+	defer server.Close()
+
+	service := newService(server)
+
+	results, err := service.Search(context.Background(), hn.SearchQuery{Text: "lorem"}, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, results, 4)
+	assert.Equal(t, 1, results[0].ID)
+	assert.Equal(t, 3, results[1].ID)
+	assert.Equal(t, 5, results[2].ID)
+
+	// Comments are mapped to the story they belong to
+	assert.Equal(t, "comment", results[3].Type)
+	assert.Equal(t, 1, results[3].ID)
+	assert.Equal(t, "Lorem ipsum dolor sit amet (2019)", results[3].Title)
+	assert.Contains(t, results[3].Content, "Maecenas suscipit")
+
+	results, err = service.Search(context.Background(),
+		hn.SearchQuery{Text: "lorem", Type: hn.SearchTypeStory, MinPoints: 40}, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, 1, results[0].ID)
+	assert.Equal(t, 3, results[1].ID)
+
+	results, err = service.Search(context.Background(),
+		hn.SearchQuery{Author: "theta", Type: hn.SearchTypeComment, ByDate: true}, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, int64(1666999400), results[0].Time)
+	assert.Equal(t, int64(1666997000), results[1].Time)
+
+	results, err = service.Search(context.Background(), hn.SearchQuery{
+		Type:   hn.SearchTypeStory,
+		After:  time.Unix(1666985000, 0),
+		Before: time.Unix(1666990000, 0),
+	}, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, 2, results[0].ID)
+	assert.Equal(t, 3, results[1].ID)
+}
+
+func TestFetchUser(t *testing.T) {
+	t.Parallel()
+
+	server := fake.NewServer()
+	defer server.Close()
+
+	service := newService(server)
+
+	user, err := service.FetchUser(context.Background(), "alfa")
+	require.NoError(t, err)
+	assert.Equal(t, "alfa", user.ID)
+	assert.Equal(t, 1234, user.Karma)
+	assert.Equal(t, int64(1420070400), user.Created)
+	assert.Contains(t, user.About, "Lorem ipsum")
+
+	_, err = service.FetchUser(context.Background(), "nobody")
+	assert.ErrorIs(t, err, hn.ErrNotFound)
+}
+
+func TestFetchReplies(t *testing.T) {
+	t.Parallel()
+
+	server := fake.NewServer()
+	defer server.Close()
+
+	service := newService(server)
+
+	// Replies to both the stories and the comments of a user, newest first
+	replies, err := service.FetchReplies(context.Background(), "alfa", time.Time{})
+	require.NoError(t, err)
+	require.Len(t, replies, 2)
+	assert.Equal(t, 104, replies[0].ID)
+	assert.Equal(t, "kappa", replies[0].Author)
+	assert.Equal(t, 101, replies[1].ID)
+	assert.Equal(t, 1, replies[1].ParentID)
+	assert.Equal(t, 1, replies[1].StoryID)
+	assert.Equal(t, "Lorem ipsum dolor sit amet (2019)", replies[1].StoryTitle)
+
+	// Replies by the user to their own comments are left out
+	replies, err = service.FetchReplies(context.Background(), "theta", time.Time{})
+	require.NoError(t, err)
+	require.Len(t, replies, 2)
+	assert.Equal(t, 103, replies[0].ID)
+	assert.Equal(t, 102, replies[1].ID)
+
+	replies, err = service.FetchReplies(context.Background(), "theta", time.Unix(1666997600, 0))
+	require.NoError(t, err)
+	require.Len(t, replies, 1)
+	assert.Equal(t, 103, replies[0].ID)
+
+	replies, err = service.FetchReplies(context.Background(), "nobody", time.Time{})
+	require.NoError(t, err)
+	assert.Empty(t, replies)
+}
+
+func TestErrors(t *testing.T) {
+	t.Parallel()
+
+	server := fake.NewServer()
+	defer server.Close()
+
+	service := newService(server)
+
+	_, err := service.FetchItem(context.Background(), 999)
+	assert.ErrorIs(t, err, hn.ErrNotFound)
+
+	_, err = service.FetchComments(context.Background(), 999)
+	assert.ErrorIs(t, err, hn.ErrNotFound)
+
+	rateLimited := fake.NewServer()
+	rateLimited.Status = http.StatusTooManyRequests
+	defer rateLimited.Close()
+
+	_, err = newService(rateLimited).FetchItems(context.Background(), 0, 3, category.FrontPage)
+	assert.ErrorIs(t, err, hn.ErrRateLimited)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+
+	_, err = service.FetchItem(ctx, 1)
+	assert.ErrorIs(t, err, hn.ErrTimeout)
+}
@@ -1,10 +1,13 @@
 package mock
 
 import (
+	"context"
 	"math/rand"
+	"strings"
 	"time"
 
 	"clx/constants/category"
+	"clx/hn"
 	"clx/item"
 )
 
@@ -13,13 +16,20 @@ type Service struct{}
 func (Service) Init(_ int) {
 }
 
-func (Service) FetchItems(_ int, cat int) (items []*item.Item, error string) {
+func (Service) FetchItems(ctx context.Context, offset int, _ int, cat int) ([]*item.Item, error) {
+	// There is only one page of dummy stories
+	hn.SetEndOfList(ctx)
+
+	if offset > 0 {
+		return []*item.Item{}, nil
+	}
+
 	// Uncomment to test the spinner on startup
 	if cat != 0 {
 		time.Sleep(time.Second * 1)
 	}
 
-	items = []*item.Item{
+	items := []*item.Item{
 		{
 			Title:         "Lorem ipsum dolor sit amet et quasi architecto",
 			Points:        31,
@@ -288,10 +298,10 @@ func (Service) FetchItems(_ int, cat int) (items []*item.Item, error string) {
 		rand.Shuffle(len(items), func(i, j int) { items[i], items[j] = items[j], items[i] })
 	}
 
-	return items, ""
+	return items, nil
 }
 
-func (Service) FetchComments(_ int) *item.Item {
+func (Service) FetchComments(_ context.Context, _ int) (*item.Item, error) {
 	return &item.Item{
 		ID:      32145667,
 		Title:   "Mauris commodo odio (YC W05) quis diam fermentum, et suscipit augue pharetra [video]",
@@ -416,9 +426,98 @@ func (Service) FetchComments(_ int) *item.Item {
 		Content: "<p>Lorem ipsum dolor sit amet, " +
 			"consectetur adipiscing elit. Integer a augue id elit efficitur tempor sit amet quis lectus.",
 		CommentsCount: 57,
+	}, nil
+}
+
+func (s Service) Search(ctx context.Context, query hn.SearchQuery, offset int, itemsToFetch int) ([]*item.Item, error) {
+	items, _ := s.FetchItems(ctx, offset, itemsToFetch, category.FrontPage)
+
+	var results []*item.Item
+
+	for _, it := range items {
+		if strings.Contains(strings.ToLower(it.Title), strings.ToLower(query.Text)) {
+			results = append(results, it)
+		}
 	}
+
+	return results, nil
+}
+
+func (s Service) FetchItem(_ context.Context, id int) (*item.Item, error) {
+	return &item.Item{
+		ID:     id,
+		Title:  "Lorem ipsum dolor sit amet et quasi architecto",
+		Points: 31,
+		User:   "alfa",
+		Time:   time.Now().Add(-time.Minute * 2).Unix(),
+		URL:    "https://en.wikipedia.org/wiki/Riemann_hypothesis",
+		Domain: "en.wikipedia.org",
+	}, nil
+}
+
+func (Service) FetchUser(_ context.Context, name string) (*hn.User, error) {
+	return &hn.User{
+		ID:      name,
+		Karma:   4321,
+		Created: time.Now().AddDate(-7, -2, 0).Unix(),
+		About: "<p>Lorem ipsum dolor sit amet, consectetur adipiscing elit. Nulla facilisi. " +
+			"Maecenas suscipit aliquet lorem.<p>Reach me at <a href=\"https://example.com\">example.com</a>",
+	}, nil
+}
+
+func (Service) FetchReplies(_ context.Context, name string, _ time.Time) ([]*hn.Reply, error) {
+	return []*hn.Reply{
+		{
+			ID:         101,
+			ParentID:   100,
+			StoryID:    1,
+			StoryTitle: "Lorem ipsum dolor sit amet et quasi architecto",
+			Author:     "beta",
+			Time:       time.Now().Add(-time.Minute * 12).Unix(),
+			Text:       "<p>Nulla facilisi, " + name + ". Maecenas suscipit aliquet lorem.",
+		},
+		{
+			ID:         201,
+			ParentID:   3,
+			StoryID:    3,
+			StoryTitle: "Show HN: consectetur adipiscing elit doris elles",
+			Author:     "gamma",
+			Time:       time.Now().Add(-time.Hour * 2).Unix(),
+			Text:       "<p>Donec sed orci aliquam lorem mattis consequat.",
+		},
+	}, nil
+}
+
+// Account pretends to be logged in and accepts every action.
+type Account struct{}
+
+func (Account) Login(_ context.Context, username string, _ string) (*hn.Session, error) {
+	return &hn.Session{Username: username, Cookie: username + "&mock"}, nil
+}
+
+func (Account) Username() string {
+	return "alfa"
+}
+
+func (Account) Upvote(_ context.Context, _ int) error {
+	return nil
 }
 
-func (s Service) FetchItem(id int) *item.Item {
+func (Account) Favorite(_ context.Context, _ int) error {
+	return nil
+}
+
+func (Account) Unfavorite(_ context.Context, _ int) error {
+	return nil
+}
+
+func (Account) FetchFavorites(_ context.Context) ([]int, error) {
+	return []int{1, 2, 3}, nil
+}
+
+func (Account) Reply(_ context.Context, _ int, _ string) error {
+	// Simulate the time it takes to post, so that the spinner shows
+	time.Sleep(time.Second * 1)
+
 	return nil
 }
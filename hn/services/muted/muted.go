@@ -0,0 +1,80 @@
+// Package muted provides an hn.Service that leaves out the stories that are
+// muted in the killfile and marks the comments by muted users.
+package muted
+
+import (
+	"context"
+	"time"
+
+	"clx/hn"
+	"clx/item"
+	"clx/killfile"
+)
+
+type Service struct {
+	next     hn.Service
+	killfile *killfile.Killfile
+}
+
+// New returns a service that filters the responses of next with k. Entries
+// that are added to k later apply to the following requests.
+func New(next hn.Service, k *killfile.Killfile) *Service {
+	return &Service{next: next, killfile: k}
+}
+
+// FetchItems returns fewer stories than requested if some are muted.
+func (s *Service) FetchItems(ctx context.Context, offset int, itemsToFetch int, category int) ([]*item.Item, error) {
+	stories, err := s.next.FetchItems(ctx, offset, itemsToFetch, category)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.killfile.Stories(stories), nil
+}
+
+func (s *Service) FetchItem(ctx context.Context, id int) (*item.Item, error) {
+	return s.next.FetchItem(ctx, id)
+}
+
+func (s *Service) FetchComments(ctx context.Context, id int) (*item.Item, error) {
+	story, err := s.next.FetchComments(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	s.killfile.MuteComments(story)
+
+	return story, nil
+}
+
+// Search returns fewer results than requested if some are muted.
+func (s *Service) Search(ctx context.Context, query hn.SearchQuery, offset int, itemsToFetch int) ([]*item.Item, error) {
+	results, err := s.next.Search(ctx, query, offset, itemsToFetch)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.killfile.Stories(results), nil
+}
+
+func (s *Service) FetchUser(ctx context.Context, name string) (*hn.User, error) {
+	return s.next.FetchUser(ctx, name)
+}
+
+// FetchReplies leaves out the replies by muted users.
+func (s *Service) FetchReplies(ctx context.Context, name string, after time.Time) ([]*hn.Reply, error) {
+	replies, err := s.next.FetchReplies(ctx, name, after)
+	if err != nil {
+		return nil, err
+	}
+
+	kept := make([]*hn.Reply, 0, len(replies))
+
+	for _, reply := range replies {
+		if !s.killfile.MutesUser(reply.Author) {
+			kept = append(kept, reply)
+		}
+	}
+
+	return kept, nil
+}
@@ -0,0 +1,59 @@
+package services
+
+import (
+	"path/filepath"
+	"time"
+
+	"clx/file"
+	"clx/hn"
+	"clx/hn/services/cache"
+	"clx/hn/services/hybrid"
+	"clx/hn/services/mock"
+	"clx/hn/services/web"
+	"clx/settings"
+)
+
+// Cached responses older than this are removed on startup.
+const maxCacheAge = 30 * 24 * time.Hour
+
+// New returns the service that the rest of circumflex should use to talk to
+// Hacker News, based on the given config.
+func New(config *settings.Config) hn.Service {
+	if config.DebugMode {
+		return mock.Service{}
+	}
+
+	cacheDir := filepath.Join(file.PathToCacheDirectory(), "responses")
+
+	if config.Offline {
+		return cache.NewOffline(cacheDir)
+	}
+
+	commentBackend := hybrid.HackerWeb
+	if config.CommentBackend == settings.CommentBackendFirebase {
+		commentBackend = hybrid.Firebase
+	}
+
+	service := cache.New(hybrid.New(config.FirebaseURL, config.AlgoliaURL, config.HackerWebURL, commentBackend),
+		cacheDir, config.CategoryCacheTTL, config.CommentCacheTTL)
+
+	// Keep the cache from growing forever. Failing to prune is not a reason
+	// to stop the user from browsing, so the error is ignored.
+	_ = service.Prune(maxCacheAge)
+
+	return service
+}
+
+// NewAccount returns the account that actions such as voting and replying are
+// made with. The session is read from the session file that 'clx login'
+// writes; without one, every action fails with hn.ErrUnauthorized. The
+// account is never nil, even if the session file could not be read.
+func NewAccount(config *settings.Config) (hn.Account, error) {
+	if config.DebugMode {
+		return mock.Account{}, nil
+	}
+
+	session, err := web.LoadSession(file.PathToSessionFile())
+
+	return web.New(config.WebURL, session), err
+}
@@ -0,0 +1,79 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"clx/hn"
+)
+
+// LoadSession reads the session that SaveSession wrote to path. A missing
+// file means that the user is not logged in and returns nil.
+func LoadSession(path string) (*hn.Session, error) {
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("could not read session: %w", err)
+	}
+
+	session := new(hn.Session)
+	if err := json.Unmarshal(content, session); err != nil {
+		return nil, fmt.Errorf("could not decode session: %w", err)
+	}
+
+	return session, nil
+}
+
+// SaveSession writes session to path. The cookie gives full access to the
+// account, so the file is only readable by the current user.
+func SaveSession(path string, session *hn.Session) error {
+	content, err := json.Marshal(session)
+	if err != nil {
+		return fmt.Errorf("could not encode session: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("could not create path to session: %w", err)
+	}
+
+	// The session is written to a new file, which CreateTemp makes readable
+	// only by the current user, and then replaces the old file. Writing to
+	// the old file would keep its permissions.
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".session-*")
+	if err != nil {
+		return fmt.Errorf("could not write session: %w", err)
+	}
+
+	_, err = tmp.Write(content)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+
+		return fmt.Errorf("could not write session: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveSession deletes the session at path. It is not an error if there is
+// no session.
+func RemoveSession(path string) error {
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("could not remove session: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,317 @@
+// Package web provides an hn.Account that talks to news.ycombinator.com.
+// Hacker News has no API for voting, favoriting or commenting, so the service
+// fetches the same pages and submits the same forms as a browser would.
+package web
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"clx/app"
+	"clx/hn"
+	clxhttp "clx/utils/http"
+)
+
+const (
+	cookieName = "user"
+
+	// Stop paging through the favorites after this many pages in case the
+	// 'More' link never disappears
+	maxFavoritesPages = 50
+)
+
+var (
+	hmacPattern     = regexp.MustCompile(`name=["']hmac["']\s+value=["']([^"']+)["']`)
+	favoritePattern = regexp.MustCompile(`class=["']athing[^"']*["']\s+id=["'](\d+)["']`)
+	logoutPattern   = regexp.MustCompile(`href=["']logout`)
+	morePattern     = regexp.MustCompile(`class=["']morelink["']`)
+)
+
+type Service struct {
+	baseURL string
+	session *hn.Session
+	client  *http.Client
+}
+
+// New returns a service for the Hacker News website at baseURL. session may
+// be nil, in which case only Login works until it has succeeded.
+func New(baseURL string, session *hn.Session) *Service {
+	return &Service{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		session: session,
+		client: &http.Client{
+			Timeout: 10 * time.Second,
+
+			// Forms are answered with a redirect on success. Following it
+			// would only hide the response that tells whether it worked.
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+func (s *Service) Login(ctx context.Context, username string, password string) (*hn.Session, error) {
+	form := url.Values{}
+	form.Set("acct", username)
+	form.Set("pw", password)
+	form.Set("goto", "news")
+
+	resp, _, err := s.do(ctx, http.MethodPost, "/login", form)
+	if err != nil {
+		return nil, fmt.Errorf("could not log in: %w", err)
+	}
+
+	// A wrong password is answered with the login form and no cookie
+	for _, cookie := range resp.Cookies() {
+		if cookie.Name == cookieName && cookie.Value != "" {
+			s.session = &hn.Session{Username: username, Cookie: cookie.Value}
+
+			return s.session, nil
+		}
+	}
+
+	return nil, fmt.Errorf("could not log in: %w", &hn.Error{Kind: hn.ErrBadLogin, URL: s.baseURL + "/login"})
+}
+
+// Username returns the name of the logged in user, or an empty string if
+// there is no session.
+func (s *Service) Username() string {
+	if s.session == nil {
+		return ""
+	}
+
+	return s.session.Username
+}
+
+func (s *Service) Upvote(ctx context.Context, id int) error {
+	page, err := s.fetchItemPage(ctx, id)
+	if err != nil {
+		return fmt.Errorf("could not upvote item %d: %w", id, err)
+	}
+
+	auth, ok := findAuth(page, fmt.Sprintf(`vote\?id=%d&(?:amp;)?how=up`, id))
+	if !ok {
+		return fmt.Errorf("could not upvote item %d: %w", id, &hn.Error{Kind: hn.ErrRejected,
+			URL: s.itemURL(id), Err: errors.New("no upvote link, the item may be your own or too old")})
+	}
+
+	query := url.Values{}
+	query.Set("id", strconv.Itoa(id))
+	query.Set("how", "up")
+	query.Set("auth", auth)
+
+	if _, _, err := s.do(ctx, http.MethodGet, "/vote?"+query.Encode(), nil); err != nil {
+		return fmt.Errorf("could not upvote item %d: %w", id, err)
+	}
+
+	return nil
+}
+
+func (s *Service) Favorite(ctx context.Context, id int) error {
+	return s.fave(ctx, id, false)
+}
+
+func (s *Service) Unfavorite(ctx context.Context, id int) error {
+	return s.fave(ctx, id, true)
+}
+
+func (s *Service) fave(ctx context.Context, id int, un bool) error {
+	page, err := s.fetchItemPage(ctx, id)
+	if err != nil {
+		return fmt.Errorf("could not favorite item %d: %w", id, err)
+	}
+
+	// The link is 'fave?id=1&un=t&auth=...' if the item is already a
+	// favorite, so the same pattern finds the token for both directions
+	auth, ok := findAuth(page, fmt.Sprintf(`fave\?id=%d(?:&(?:amp;)?un=t)?`, id))
+	if !ok {
+		return fmt.Errorf("could not favorite item %d: %w", id, &hn.Error{Kind: hn.ErrRejected,
+			URL: s.itemURL(id), Err: errors.New("no favorite link")})
+	}
+
+	query := url.Values{}
+	query.Set("id", strconv.Itoa(id))
+	query.Set("auth", auth)
+
+	if un {
+		query.Set("un", "t")
+	}
+
+	if _, _, err := s.do(ctx, http.MethodGet, "/fave?"+query.Encode(), nil); err != nil {
+		return fmt.Errorf("could not favorite item %d: %w", id, err)
+	}
+
+	return nil
+}
+
+// FetchFavorites returns the IDs of the stories on the favorites page of the
+// logged in user, in the order they appear there.
+func (s *Service) FetchFavorites(ctx context.Context) ([]int, error) {
+	if s.session == nil {
+		return nil, fmt.Errorf("could not fetch favorites: %w", &hn.Error{Kind: hn.ErrUnauthorized})
+	}
+
+	var ids []int
+
+	for page := 1; page <= maxFavoritesPages; page++ {
+		query := url.Values{}
+		query.Set("id", s.session.Username)
+		query.Set("p", strconv.Itoa(page))
+
+		_, body, err := s.do(ctx, http.MethodGet, "/favorites?"+query.Encode(), nil)
+		if err != nil {
+			return nil, fmt.Errorf("could not fetch favorites: %w", err)
+		}
+
+		for _, match := range favoritePattern.FindAllStringSubmatch(body, -1) {
+			id, _ := strconv.Atoi(match[1])
+			ids = append(ids, id)
+		}
+
+		if !morePattern.MatchString(body) {
+			break
+		}
+	}
+
+	return ids, nil
+}
+
+// Reply posts text as a reply to the story or comment with the given ID.
+// Paragraphs are separated by blank lines, like on the website.
+func (s *Service) Reply(ctx context.Context, parentID int, text string) error {
+	page, err := s.fetchItemPage(ctx, parentID)
+	if err != nil {
+		return fmt.Errorf("could not reply to item %d: %w", parentID, err)
+	}
+
+	match := hmacPattern.FindStringSubmatch(page)
+	if match == nil {
+		return fmt.Errorf("could not reply to item %d: %w", parentID, &hn.Error{Kind: hn.ErrRejected,
+			URL: s.itemURL(parentID), Err: errors.New("the item can not be replied to")})
+	}
+
+	form := url.Values{}
+	form.Set("parent", strconv.Itoa(parentID))
+	form.Set("goto", "item?id="+strconv.Itoa(parentID))
+	form.Set("hmac", match[1])
+	form.Set("text", text)
+
+	resp, body, err := s.do(ctx, http.MethodPost, "/comment", form)
+	if err != nil {
+		return fmt.Errorf("could not reply to item %d: %w", parentID, err)
+	}
+
+	// Rejected comments are answered with a page that explains why instead
+	// of a redirect to the thread
+	if resp.StatusCode == http.StatusOK {
+		if strings.Contains(body, "posting too fast") {
+			return fmt.Errorf("could not reply to item %d: %w", parentID,
+				&hn.Error{Kind: hn.ErrRateLimited, URL: s.baseURL + "/comment"})
+		}
+
+		return fmt.Errorf("could not reply to item %d: %w", parentID,
+			&hn.Error{Kind: hn.ErrRejected, URL: s.baseURL + "/comment"})
+	}
+
+	return nil
+}
+
+// fetchItemPage returns the HTML of the page of an item, which has the tokens
+// that the forms for voting, favoriting and replying need.
+func (s *Service) fetchItemPage(ctx context.Context, id int) (string, error) {
+	if s.session == nil {
+		return "", &hn.Error{Kind: hn.ErrUnauthorized}
+	}
+
+	_, body, err := s.do(ctx, http.MethodGet, "/item?id="+strconv.Itoa(id), nil)
+	if err != nil {
+		return "", err
+	}
+
+	if strings.Contains(body, "No such item.") {
+		return "", &hn.Error{Kind: hn.ErrNotFound, URL: s.itemURL(id)}
+	}
+
+	// The cookie has expired or been revoked
+	if !logoutPattern.MatchString(body) {
+		return "", &hn.Error{Kind: hn.ErrUnauthorized, URL: s.itemURL(id)}
+	}
+
+	return body, nil
+}
+
+func (s *Service) itemURL(id int) string {
+	return s.baseURL + "/item?id=" + strconv.Itoa(id)
+}
+
+// findAuth returns the auth token of the first link whose URL starts with
+// the pattern link.
+func findAuth(page string, link string) (string, bool) {
+	pattern := regexp.MustCompile(link + `&(?:amp;)?auth=([0-9a-zA-Z]+)`)
+
+	match := pattern.FindStringSubmatch(page)
+	if match == nil {
+		return "", false
+	}
+
+	return match[1], true
+}
+
+// do sends a request to path and returns the response and its body. form is
+// sent as the body of POST requests.
+func (s *Service) do(ctx context.Context, method string, path string, form url.Values) (*http.Response, string, error) {
+	var body io.Reader
+	if form != nil {
+		body = strings.NewReader(form.Encode())
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, s.baseURL+path, body)
+	if err != nil {
+		return nil, "", &hn.Error{Kind: hn.ErrUnavailable, URL: s.baseURL + path, Err: err}
+	}
+
+	req.Header.Set("User-Agent", app.Name+"/"+app.Version)
+
+	if form != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+
+	if s.session != nil {
+		req.AddCookie(&http.Cookie{Name: cookieName, Value: s.session.Cookie})
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, "", &hn.Error{Kind: clxhttp.ErrorKind(err), URL: req.URL.String(), Err: err}
+	}
+
+	defer resp.Body.Close()
+
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", &hn.Error{Kind: clxhttp.ErrorKind(err), URL: req.URL.String(), Err: err}
+	}
+
+	switch code := resp.StatusCode; {
+	case code == http.StatusNotFound:
+		return nil, "", &hn.Error{Kind: hn.ErrNotFound, URL: req.URL.String()}
+
+	// Hacker News answers with 503 when requests come in too quickly
+	case code == http.StatusTooManyRequests || code == http.StatusServiceUnavailable:
+		return nil, "", &hn.Error{Kind: hn.ErrRateLimited, URL: req.URL.String()}
+
+	case code >= http.StatusBadRequest:
+		return nil, "", &hn.Error{Kind: hn.ErrUnavailable, URL: req.URL.String(), Err: errors.New(resp.Status)}
+	}
+
+	return resp, string(content), nil
+}
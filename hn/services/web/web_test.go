@@ -0,0 +1,177 @@
+package web_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"clx/hn"
+	"clx/hn/fake"
+	"clx/hn/services/hybrid"
+	"clx/hn/services/web"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func login(t *testing.T, server *fake.Server, username string) *web.Service {
+	t.Helper()
+
+	service := web.New(server.WebURL(), nil)
+
+	session, err := service.Login(context.Background(), username, fake.Password)
+	require.NoError(t, err)
+	assert.Equal(t, username, session.Username)
+	assert.Equal(t, username, service.Username())
+
+	return service
+}
+
+func TestLogin(t *testing.T) {
+	t.Parallel()
+
+	server := fake.NewServer()
+	defer server.Close()
+
+	session, err := web.New(server.WebURL(), nil).Login(context.Background(), "alfa", fake.Password)
+	require.NoError(t, err)
+
+	// The session can be reused by a new service
+	restored := web.New(server.WebURL(), session)
+	assert.Equal(t, "alfa", restored.Username())
+	assert.NoError(t, restored.Upvote(context.Background(), 2))
+
+	expired := web.New(server.WebURL(), &hn.Session{Username: "alfa", Cookie: "alfa&expired"})
+	assert.ErrorIs(t, expired.Upvote(context.Background(), 2), hn.ErrUnauthorized)
+
+	_, err = web.New(server.WebURL(), nil).Login(context.Background(), "alfa", "wrong")
+	assert.ErrorIs(t, err, hn.ErrBadLogin)
+}
+
+func TestUpvote(t *testing.T) {
+	t.Parallel()
+
+	server := fake.NewServer()
+	defer server.Close()
+
+	service := login(t, server, "theta")
+	firebase := hybrid.New(server.FirebaseURL(), server.AlgoliaURL(), server.HackerWebURL(), hybrid.Firebase)
+
+	require.NoError(t, service.Upvote(context.Background(), 2))
+
+	// Voting twice only counts once
+	require.NoError(t, service.Upvote(context.Background(), 2))
+
+	story, err := firebase.FetchItem(context.Background(), 2)
+	require.NoError(t, err)
+	assert.Equal(t, 88, story.Points)
+
+	assert.ErrorIs(t, service.Upvote(context.Background(), 999), hn.ErrNotFound)
+}
+
+func TestFavorites(t *testing.T) {
+	t.Parallel()
+
+	server := fake.NewServer()
+	defer server.Close()
+
+	service := login(t, server, "alfa")
+
+	favorites, err := service.FetchFavorites(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, favorites)
+
+	require.NoError(t, service.Favorite(context.Background(), 3))
+	require.NoError(t, service.Favorite(context.Background(), 1))
+	require.NoError(t, service.Favorite(context.Background(), 5))
+	require.NoError(t, service.Unfavorite(context.Background(), 1))
+
+	favorites, err = service.FetchFavorites(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []int{5, 3}, favorites)
+}
+
+func TestReply(t *testing.T) {
+	t.Parallel()
+
+	server := fake.NewServer()
+	defer server.Close()
+
+	service := login(t, server, "theta")
+	firebase := hybrid.New(server.FirebaseURL(), server.AlgoliaURL(), server.HackerWebURL(), hybrid.Firebase)
+
+	require.NoError(t, service.Reply(context.Background(), 3, "Lorem ipsum\n\ndolor sit amet"))
+
+	story, err := firebase.FetchComments(context.Background(), 3)
+	require.NoError(t, err)
+	assert.Equal(t, 2, story.CommentsCount)
+	require.Len(t, story.Comments, 2)
+	assert.Equal(t, "theta", story.Comments[1].User)
+	assert.Contains(t, story.Comments[1].Content, "dolor sit amet")
+
+	assert.ErrorIs(t, service.Reply(context.Background(), 3, " "), hn.ErrRejected)
+}
+
+func TestNotLoggedIn(t *testing.T) {
+	t.Parallel()
+
+	server := fake.NewServer()
+	defer server.Close()
+
+	service := web.New(server.WebURL(), nil)
+
+	assert.Empty(t, service.Username())
+	assert.ErrorIs(t, service.Upvote(context.Background(), 1), hn.ErrUnauthorized)
+	assert.ErrorIs(t, service.Favorite(context.Background(), 1), hn.ErrUnauthorized)
+	assert.ErrorIs(t, service.Reply(context.Background(), 1, "Lorem ipsum"), hn.ErrUnauthorized)
+
+	_, err := service.FetchFavorites(context.Background())
+	assert.ErrorIs(t, err, hn.ErrUnauthorized)
+}
+
+func TestSession(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "clx", "session.json")
+
+	session, err := web.LoadSession(path)
+	require.NoError(t, err)
+	assert.Nil(t, session)
+
+	require.NoError(t, web.SaveSession(path, &hn.Session{Username: "alfa", Cookie: "alfa&secret"}))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	session, err = web.LoadSession(path)
+	require.NoError(t, err)
+	assert.Equal(t, &hn.Session{Username: "alfa", Cookie: "alfa&secret"}, session)
+
+	require.NoError(t, web.RemoveSession(path))
+
+	session, err = web.LoadSession(path)
+	require.NoError(t, err)
+	assert.Nil(t, session)
+}
+
+func TestSaveSessionRestrictsExistingFile(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "session.json")
+
+	require.NoError(t, os.WriteFile(path, []byte("{}"), 0o644))
+	require.NoError(t, os.Chmod(path, 0o644))
+	require.NoError(t, web.SaveSession(path, &hn.Session{Username: "alfa", Cookie: "alfa&secret"}))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	// The temporary file has been renamed
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
@@ -0,0 +1,10 @@
+package hn
+
+// User is the profile of a Hacker News user. Created is a Unix timestamp and
+// About is HTML in the same format as the text of a comment.
+type User struct {
+	ID      string
+	Karma   int
+	Created int64
+	About   string
+}
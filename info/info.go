@@ -4,53 +4,93 @@ import (
 	"strings"
 
 	"clx/constants/nerdfonts"
-	. "github.com/logrusorgru/aurora/v3"
 
 	"clx/constants/margins"
 	"clx/keymaps"
+	"clx/theme"
 	text "github.com/MichaelMure/go-term-text"
 )
 
-func GetText(screenWidth int, enableNerdFonts bool) string {
+func GetText(screenWidth int, enableNerdFonts bool, keymap *keymaps.Keymap) string {
 	keys := new(keymaps.List)
 	keys.Init()
 
-	keys.AddHeader(Red(" Main Menu ").Underline().String())
+	content := theme.Current().Content
+
+	add := func(description string, actions ...string) {
+		if help := keymap.Help(actions...); help != "" {
+			keys.AddKeymap(description, help)
+		}
+	}
+
+	keys.AddHeader(content.Red.Render(" Main Menu ", theme.Underline))
+	keys.AddSeparator()
+	add("View comment section", keymaps.ListComments)
+	add("View article in Reader Mode", keymaps.ListReader)
+	keys.AddSeparator()
+	add("Next / prev story", keymaps.ListDown, keymaps.ListUp)
+	add("Next / prev page", keymaps.ListNextPage, keymaps.ListPrevPage)
+	add("First / last story on the page", keymaps.ListTop, keymaps.ListBottom)
 	keys.AddSeparator()
-	keys.AddKeymap("View comment section", "Enter")
-	keys.AddKeymap("View article in Reader Mode", "Space")
+	add("Refresh", keymaps.ListRefresh)
+	add("Change category", keymaps.ListNextCategory)
+	add("Prev / next day in Past", keymaps.ListPrevDay, keymaps.ListNextDay)
+	add("Search / leave search results", keymaps.ListSearch, keymaps.ListBack)
+	add("Show submitter's profile", keymaps.ListProfile)
+	add("Show / hide the full about text of a profile", keymaps.ListAbout)
 	keys.AddSeparator()
-	keys.AddKeymap("Refresh", "r")
-	keys.AddKeymap("Change category", "Tab")
+	add("Open story link in browser", keymaps.ListOpenLink)
+	add("Open comments in browser", keymaps.ListOpenComments)
 	keys.AddSeparator()
-	keys.AddKeymap("Open story link in browser", "o")
-	keys.AddKeymap("Open comments in browser", "c")
+	add("Add to favorites", keymaps.ListAddFavorite)
+	add("Remove from favorites", keymaps.ListRemoveFavorite)
+	add("Mute domain or submitter", keymaps.ListMute)
 	keys.AddSeparator()
-	keys.AddKeymap("Add to favorites", "f")
-	keys.AddKeymap("Remove from favorites", "x")
+	add("Upvote", keymaps.ListUpvote)
+	add("Reply to story", keymaps.ListReply)
 	keys.AddSeparator()
-	keys.AddKeymap("Bring up this screen", "i, ?")
-	keys.AddKeymap("Quit to prompt", "q")
+	add("Bring up this screen", keymaps.ListHelp)
+	add("Quit to prompt", keymaps.ListQuit)
 	keys.AddSeparator()
 
-	keys.AddHeader(Yellow(" Comment Section / Reader Mode ").Underline().String())
+	keys.AddHeader(content.Yellow.Render(" Comment Section ", theme.Underline))
 	keys.AddSeparator()
-	keys.AddKeymap("Down / up one line", "j, k")
-	keys.AddKeymap("Down / up one half-window", "d, u")
+	add("Next / prev comment", keymaps.CommentsDown, keymaps.CommentsUp)
+	add("Down / up one half-window", keymaps.CommentsHalfPageDown, keymaps.CommentsHalfPageUp)
 	keys.AddSeparator()
-	keys.AddKeymap("Hide / show all replies", "h, l")
-	keys.AddKeymap("Next / prev top-level comment", "n, N")
+	add("Collapse / expand replies", keymaps.CommentsCollapse, keymaps.CommentsExpand)
+	add("Collapse / expand all replies", keymaps.CommentsCollapseAll, keymaps.CommentsExpandAll)
+	add("Show muted comment (not in less)", keymaps.CommentsToggle)
+	add("Next / prev top-level comment", keymaps.CommentsNextTopLevel, keymaps.CommentsPrevTopLevel)
+	add("Parent / top-level comment", keymaps.CommentsParent, keymaps.CommentsRoot)
+	add("Next / prev sibling", keymaps.CommentsNextSibling, keymaps.CommentsPrevSibling)
+	add("Next / prev new comment", keymaps.CommentsNextNew, keymaps.CommentsPrevNew)
+	add("Search", keymaps.CommentsSearch)
+	keys.AddSeparator()
+	add("Cycle sort order", keymaps.CommentsSort)
+	add("Only OP / only this author", keymaps.CommentsOnlyOP, keymaps.CommentsOnlyAuthor)
+	add("Filter by text", keymaps.CommentsFilter)
+	keys.AddSeparator()
+	add("Upvote / reply to comment", keymaps.CommentsUpvote, keymaps.CommentsReply)
+	add("Return to circumflex", keymaps.CommentsQuit)
+	keys.AddSeparator()
+
+	keys.AddHeader(content.Magenta.Render(" Reader Mode ", theme.Underline))
 	keys.AddSeparator()
-	keys.AddKeymap("Return to circumflex", "q")
+	add("Down / up one line", keymaps.ReaderDown, keymaps.ReaderUp)
+	add("Down / up one half-window", keymaps.ReaderHalfPageDown, keymaps.ReaderHalfPageUp)
+	add("Next / prev headline", keymaps.ReaderNextHeadline, keymaps.ReaderPrevHeadline)
+	add("Search", keymaps.ReaderSearch)
+	add("Return to circumflex", keymaps.ReaderQuit)
 	keys.AddSeparator()
 
-	keys.AddHeader(Blue(" Legend ").Underline().String())
+	keys.AddHeader(content.Blue.Render(" Legend ", theme.Underline))
 	keys.AddSeparator()
-	keys.AddKeymap("Original Poster", Red(getOP(enableNerdFonts)).String())
-	keys.AddKeymap("Parent Poster", Magenta(getPP(enableNerdFonts)).String())
-	keys.AddKeymap("Moderator", Green(getMod(enableNerdFonts)).String())
+	keys.AddKeymap("Original Poster", content.Red.Render(getOP(enableNerdFonts)))
+	keys.AddKeymap("Parent Poster", content.Magenta.Render(getPP(enableNerdFonts)))
+	keys.AddKeymap("Moderator", content.Green.Render(getMod(enableNerdFonts)))
 	keys.AddSeparator()
-	keys.AddKeymap("New comment indicator", Cyan("●").String())
+	keys.AddKeymap("New comment indicator", content.Cyan.Render("●"))
 
 	keys.AddSeparator()
 	keys.AddSeparator()
@@ -14,4 +14,13 @@ type Item struct {
 	Comments      []*Item
 	Content       string
 	CommentsCount int
+
+	// Muted is set on comments by users in the killfile. It is not saved with
+	// the favorites.
+	Muted bool `json:"-"`
+
+	// CommentID is set on items that stand for a comment in the story with
+	// ID, such as replies. The comment is focused when the comment section is
+	// opened.
+	CommentID int `json:"-"`
 }
@@ -0,0 +1,448 @@
+package keymaps
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Contexts that keys are bound in. A key can be bound to one action in each
+// context.
+const (
+	ContextList     = "list"
+	ContextComments = "comments"
+	ContextReader   = "reader"
+)
+
+// Actions in the list.
+const (
+	ListUp             = "list.up"
+	ListDown           = "list.down"
+	ListPrevPage       = "list.prev-page"
+	ListNextPage       = "list.next-page"
+	ListTop            = "list.top"
+	ListBottom         = "list.bottom"
+	ListNextCategory   = "list.next-category"
+	ListPrevCategory   = "list.prev-category"
+	ListPrevDay        = "list.prev-day"
+	ListNextDay        = "list.next-day"
+	ListComments       = "list.comments"
+	ListReader         = "list.reader"
+	ListRefresh        = "list.refresh"
+	ListSearch         = "list.search"
+	ListProfile        = "list.profile"
+	ListAbout          = "list.about"
+	ListOpenLink       = "list.open-link"
+	ListOpenComments   = "list.open-comments"
+	ListAddFavorite    = "list.add-favorite"
+	ListRemoveFavorite = "list.remove-favorite"
+	ListMute           = "list.mute"
+	ListUpvote         = "list.upvote"
+	ListReply          = "list.reply"
+	ListHelp           = "list.help"
+	ListBack           = "list.back"
+	ListQuit           = "list.quit"
+)
+
+// Actions in comment sections.
+const (
+	CommentsDown         = "comments.down"
+	CommentsUp           = "comments.up"
+	CommentsHalfPageDown = "comments.half-page-down"
+	CommentsHalfPageUp   = "comments.half-page-up"
+	CommentsPageDown     = "comments.page-down"
+	CommentsPageUp       = "comments.page-up"
+	CommentsTop          = "comments.top"
+	CommentsBottom       = "comments.bottom"
+	CommentsCollapse     = "comments.collapse"
+	CommentsExpand       = "comments.expand"
+	CommentsToggle       = "comments.toggle"
+	CommentsCollapseAll  = "comments.collapse-all"
+	CommentsExpandAll    = "comments.expand-all"
+	CommentsParent       = "comments.parent"
+	CommentsRoot         = "comments.root"
+	CommentsNextSibling  = "comments.next-sibling"
+	CommentsPrevSibling  = "comments.prev-sibling"
+	CommentsNextTopLevel = "comments.next-top-level"
+	CommentsPrevTopLevel = "comments.prev-top-level"
+	CommentsNextNew      = "comments.next-new"
+	CommentsPrevNew      = "comments.prev-new"
+	CommentsSearch       = "comments.search"
+	CommentsFilter       = "comments.filter"
+	CommentsSort         = "comments.sort"
+	CommentsOnlyOP       = "comments.only-op"
+	CommentsOnlyAuthor   = "comments.only-author"
+	CommentsReply        = "comments.reply"
+	CommentsUpvote       = "comments.upvote"
+	CommentsBack         = "comments.back"
+	CommentsQuit         = "comments.quit"
+)
+
+// Actions in Reader Mode.
+const (
+	ReaderDown         = "reader.down"
+	ReaderUp           = "reader.up"
+	ReaderHalfPageDown = "reader.half-page-down"
+	ReaderHalfPageUp   = "reader.half-page-up"
+	ReaderPageDown     = "reader.page-down"
+	ReaderPageUp       = "reader.page-up"
+	ReaderTop          = "reader.top"
+	ReaderBottom       = "reader.bottom"
+	ReaderNextHeadline = "reader.next-headline"
+	ReaderPrevHeadline = "reader.prev-headline"
+	ReaderSearch       = "reader.search"
+	ReaderBack         = "reader.back"
+	ReaderQuit         = "reader.quit"
+)
+
+// namedKeys are the keys besides single characters and ctrl+ and alt+
+// combinations, as they are named by bubbletea.
+var namedKeys = map[string]bool{
+	"enter": true, "tab": true, "shift+tab": true, "esc": true, "backspace": true, "delete": true,
+	"up": true, "down": true, "left": true, "right": true, "home": true, "end": true,
+	"pgup": true, "pgdown": true, " ": true,
+}
+
+type binding struct {
+	action string
+	keys   []string
+
+	// line is the line in the keymap file that set the keys, or 0 for the
+	// default keys
+	line int
+}
+
+// Keymap holds the keys of every action. A nil Keymap has the default keys.
+type Keymap struct {
+	bindings []*binding
+}
+
+// Default returns the keys that circumflex has always used.
+func Default() *Keymap {
+	defaults := []struct {
+		action string
+		keys   []string
+	}{
+		{ListUp, []string{"k", "up"}},
+		{ListDown, []string{"j", "down"}},
+		{ListPrevPage, []string{"h", "left"}},
+		{ListNextPage, []string{"l", "right"}},
+		{ListTop, []string{"g"}},
+		{ListBottom, []string{"G"}},
+		{ListNextCategory, []string{"tab"}},
+		{ListPrevCategory, []string{"shift+tab"}},
+		{ListPrevDay, []string{"["}},
+		{ListNextDay, []string{"]"}},
+		{ListComments, []string{"enter"}},
+		{ListReader, []string{" "}},
+		{ListRefresh, []string{"r"}},
+		{ListSearch, []string{"/"}},
+		{ListProfile, []string{"p"}},
+		{ListAbout, []string{"a"}},
+		{ListOpenLink, []string{"o"}},
+		{ListOpenComments, []string{"c"}},
+		{ListAddFavorite, []string{"f", "V"}},
+		{ListRemoveFavorite, []string{"x"}},
+		{ListMute, []string{"m"}},
+		{ListUpvote, []string{"u"}},
+		{ListReply, []string{"R"}},
+		{ListHelp, []string{"i", "?"}},
+		{ListBack, []string{"esc"}},
+		{ListQuit, []string{"q"}},
+
+		{CommentsDown, []string{"j", "down"}},
+		{CommentsUp, []string{"k", "up"}},
+		{CommentsHalfPageDown, []string{"d", "ctrl+d"}},
+		{CommentsHalfPageUp, []string{"u", "ctrl+u"}},
+		{CommentsPageDown, []string{"f", " ", "pgdown"}},
+		{CommentsPageUp, []string{"b", "pgup"}},
+		{CommentsTop, []string{"g", "home"}},
+		{CommentsBottom, []string{"G", "end"}},
+		{CommentsCollapse, []string{"h", "left"}},
+		{CommentsExpand, []string{"l", "right"}},
+		{CommentsToggle, []string{"enter"}},
+		{CommentsCollapseAll, []string{"H"}},
+		{CommentsExpandAll, []string{"L"}},
+		{CommentsParent, []string{"p"}},
+		{CommentsRoot, []string{"P"}},
+		{CommentsNextSibling, []string{"]"}},
+		{CommentsPrevSibling, []string{"["}},
+		{CommentsNextTopLevel, []string{"n"}},
+		{CommentsPrevTopLevel, []string{"N"}},
+		{CommentsNextNew, []string{"."}},
+		{CommentsPrevNew, []string{","}},
+		{CommentsSearch, []string{"/"}},
+		{CommentsFilter, []string{"&"}},
+		{CommentsSort, []string{"s"}},
+		{CommentsOnlyOP, []string{"o"}},
+		{CommentsOnlyAuthor, []string{"a"}},
+		{CommentsReply, []string{"R"}},
+		{CommentsUpvote, []string{"v"}},
+		{CommentsBack, []string{"esc"}},
+		{CommentsQuit, []string{"q"}},
+
+		{ReaderDown, []string{"j", "down", "enter"}},
+		{ReaderUp, []string{"k", "up"}},
+		{ReaderHalfPageDown, []string{"d", "ctrl+d"}},
+		{ReaderHalfPageUp, []string{"u", "ctrl+u"}},
+		{ReaderPageDown, []string{"f", " ", "pgdown"}},
+		{ReaderPageUp, []string{"b", "pgup"}},
+		{ReaderTop, []string{"g", "home"}},
+		{ReaderBottom, []string{"G", "end"}},
+		{ReaderNextHeadline, []string{"n"}},
+		{ReaderPrevHeadline, []string{"N"}},
+		{ReaderSearch, []string{"/"}},
+		{ReaderBack, []string{"esc"}},
+		{ReaderQuit, []string{"q"}},
+	}
+
+	k := &Keymap{bindings: make([]*binding, 0, len(defaults))}
+
+	for _, d := range defaults {
+		k.bindings = append(k.bindings, &binding{action: d.action, keys: d.keys})
+	}
+
+	return k
+}
+
+var defaultKeymap = Default()
+
+func (k *Keymap) orDefault() *Keymap {
+	if k == nil {
+		return defaultKeymap
+	}
+
+	return k
+}
+
+// Keys returns the keys that are bound to action, as they are named by
+// bubbletea.
+func (k *Keymap) Keys(action string) []string {
+	if b := k.orDefault().lookup(action); b != nil {
+		return b.keys
+	}
+
+	return nil
+}
+
+// Action returns the action that key is bound to in context, or an empty
+// string if there is none.
+func (k *Keymap) Action(context string, key string) string {
+	for _, b := range k.orDefault().bindings {
+		if contextOf(b.action) != context {
+			continue
+		}
+
+		for _, bound := range b.keys {
+			if bound == key {
+				return b.action
+			}
+		}
+	}
+
+	return ""
+}
+
+// Actions returns the actions in context.
+func Actions(context string) []string {
+	var actions []string
+
+	for _, b := range defaultKeymap.bindings {
+		if contextOf(b.action) == context {
+			actions = append(actions, b.action)
+		}
+	}
+
+	return actions
+}
+
+func (k *Keymap) lookup(action string) *binding {
+	for _, b := range k.bindings {
+		if b.action == action {
+			return b
+		}
+	}
+
+	return nil
+}
+
+func contextOf(action string) string {
+	context, _, _ := strings.Cut(action, ".")
+
+	return context
+}
+
+// Load reads the keymap at path. A keymap that does not exist has the
+// default keys.
+func Load(path string) (*Keymap, error) {
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return Default(), nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("could not read keymap: %w", err)
+	}
+
+	return Parse(string(content))
+}
+
+// Parse reads a keymap with one action per line, followed by '=' and the
+// keys that replace its default keys:
+//
+//	list.upvote = +
+//	comments.collapse = h left
+//	list.mute =
+//
+// Keys are named as in bubbletea, such as ctrl+d, shift+tab and pgdown, and
+// space is the space bar. An action without keys is unbound. Empty lines and
+// lines starting with '#' are ignored. Keys that are bound to more than one
+// action in the same context are reported together.
+func Parse(content string) (*Keymap, error) {
+	k := Default()
+	scanner := bufio.NewScanner(strings.NewReader(content))
+
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		action, value, found := strings.Cut(line, "=")
+		if !found {
+			return nil, fmt.Errorf("line %d: expected action = keys", lineNumber)
+		}
+
+		b := k.lookup(strings.TrimSpace(action))
+		if b == nil {
+			return nil, fmt.Errorf("line %d: unknown action %q", lineNumber, strings.TrimSpace(action))
+		}
+
+		keys, err := parseKeys(value)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+
+		b.keys = keys
+		b.line = lineNumber
+	}
+
+	if err := k.conflicts(); err != nil {
+		return nil, err
+	}
+
+	return k, nil
+}
+
+func parseKeys(value string) ([]string, error) {
+	var keys []string
+
+	for _, key := range strings.Fields(value) {
+		if key == "space" {
+			key = " "
+		}
+
+		if !isKey(key) {
+			return nil, fmt.Errorf("unknown key %q, expected a character, space, enter, tab, shift+tab, esc, "+
+				"backspace, delete, an arrow key, home, end, pgup, pgdown, ctrl+ or alt+ and a character", key)
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+func isKey(key string) bool {
+	if namedKeys[key] || utf8.RuneCountInString(key) == 1 {
+		return true
+	}
+
+	for _, modifier := range []string{"ctrl+", "alt+"} {
+		if rest := strings.TrimPrefix(key, modifier); rest != key && utf8.RuneCountInString(rest) == 1 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// conflicts returns an error that lists every key that is bound to more than
+// one action in the same context.
+func (k *Keymap) conflicts() error {
+	type boundKey struct {
+		context string
+		key     string
+	}
+
+	bound := make(map[boundKey]*binding)
+
+	var problems []string
+
+	for _, b := range k.bindings {
+		for _, key := range b.keys {
+			bk := boundKey{context: contextOf(b.action), key: key}
+
+			other, exists := bound[bk]
+			if !exists {
+				bound[bk] = b
+
+				continue
+			}
+
+			line := b.line
+			if line == 0 {
+				line = other.line
+			}
+
+			problems = append(problems, fmt.Sprintf("line %d: %s is bound to both %s and %s", line,
+				Name(key), other.action, b.action))
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+
+	sort.Strings(problems)
+
+	return fmt.Errorf("conflicting keys:\n%s", strings.Join(problems, "\n"))
+}
+
+// Name returns key as it is shown on the help screen, for example Enter or
+// Space.
+func Name(key string) string {
+	switch {
+	case key == " ":
+		return "Space"
+
+	case len(key) > 1:
+		return strings.ToUpper(key[:1]) + key[1:]
+
+	default:
+		return key
+	}
+}
+
+// Help returns the keys of actions as they are shown on the help screen. Only
+// the first key of each action is shown when there are several actions.
+func (k *Keymap) Help(actions ...string) string {
+	var names []string
+
+	for _, action := range actions {
+		keys := k.Keys(action)
+
+		if len(keys) != 0 && len(actions) > 1 {
+			keys = keys[:1]
+		}
+
+		for _, key := range keys {
+			names = append(names, Name(key))
+		}
+	}
+
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,101 @@
+package keymaps_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"clx/keymaps"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	content := `# Upvote with + and mute nothing
+list.upvote = +
+list.mute =
+comments.collapse = h ctrl+h
+`
+
+	keymap, err := keymaps.Parse(content)
+
+	assert.NoError(t, err)
+	assert.Equal(t, keymaps.ListUpvote, keymap.Action(keymaps.ContextList, "+"))
+	assert.Equal(t, "", keymap.Action(keymaps.ContextList, "u"))
+	assert.Equal(t, "", keymap.Action(keymaps.ContextList, "m"))
+	assert.Equal(t, []string{"h", "ctrl+h"}, keymap.Keys(keymaps.CommentsCollapse))
+	assert.Equal(t, keymaps.ReaderPageDown, keymap.Action(keymaps.ContextReader, " "))
+}
+
+func TestParseErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		content string
+		err     string
+	}{
+		{content: "list.upvote", err: "line 1: expected action = keys"},
+		{content: "\n# comment\nlist.downvote = d", err: "line 3: unknown action \"list.downvote\""},
+		{content: "list.upvote = ctrl+up", err: "line 1: unknown key \"ctrl+up\""},
+		{content: "list.upvote = x", err: "line 1: x is bound to both list.remove-favorite and list.upvote"},
+		{
+			content: "comments.sort = enter\ncomments.filter = enter",
+			err:     "line 1: Enter is bound to both comments.toggle and comments.sort",
+		},
+	}
+
+	for _, test := range tests {
+		_, err := keymaps.Parse(test.content)
+
+		assert.Error(t, err, test.content)
+		assert.Contains(t, err.Error(), test.err, test.content)
+	}
+}
+
+func TestParseAllowsSameKeyInDifferentContexts(t *testing.T) {
+	t.Parallel()
+
+	keymap, err := keymaps.Parse("list.refresh = s\nreader.search = s\nreader.next-headline = /")
+
+	assert.NoError(t, err)
+	assert.Equal(t, keymaps.ListRefresh, keymap.Action(keymaps.ContextList, "s"))
+	assert.Equal(t, keymaps.ReaderSearch, keymap.Action(keymaps.ContextReader, "s"))
+	assert.Equal(t, keymaps.CommentsSort, keymap.Action(keymaps.ContextComments, "s"))
+}
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	keymap, err := keymaps.Load(filepath.Join(dir, "keymap"))
+	assert.NoError(t, err)
+	assert.Equal(t, keymaps.Default(), keymap)
+
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "keymap"), []byte("list.quit = Q"), 0o600))
+
+	keymap, err = keymaps.Load(filepath.Join(dir, "keymap"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Q"}, keymap.Keys(keymaps.ListQuit))
+}
+
+func TestNilKeymapHasDefaultKeys(t *testing.T) {
+	t.Parallel()
+
+	var keymap *keymaps.Keymap
+
+	assert.Equal(t, keymaps.ListComments, keymap.Action(keymaps.ContextList, "enter"))
+	assert.Equal(t, []string{"i", "?"}, keymap.Keys(keymaps.ListHelp))
+}
+
+func TestHelp(t *testing.T) {
+	t.Parallel()
+
+	keymap := keymaps.Default()
+
+	assert.Equal(t, "i, ?", keymap.Help(keymaps.ListHelp))
+	assert.Equal(t, "Tab, Shift+tab", keymap.Help(keymaps.ListNextCategory, keymaps.ListPrevCategory))
+	assert.Equal(t, "Space", keymap.Help(keymaps.ListReader))
+}
@@ -0,0 +1,239 @@
+// Package killfile reads and writes the list of muted users, domains and
+// title patterns.
+//
+// The killfile has one entry per line:
+//
+//	user: name
+//	domain: example.com
+//	title: (?i)regular expression
+//
+// Empty lines and lines starting with '#' are ignored.
+package killfile
+
+import (
+	"bufio"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	"clx/item"
+)
+
+const (
+	KindUser   = "user"
+	KindDomain = "domain"
+	KindTitle  = "title"
+)
+
+const header = `# Stories by these users, from these domains or with titles that match these
+# regular expressions are not shown, and comments by these users are
+# collapsed. One entry per line, for example:
+#
+#   user: name
+#   domain: example.com
+#   title: (?i)\bcrypto
+`
+
+// Killfile is safe for concurrent use, since stories are filtered while
+// they are fetched in the background.
+type Killfile struct {
+	path string
+
+	mu      sync.RWMutex
+	users   map[string]bool
+	domains map[string]bool
+	titles  []*regexp.Regexp
+}
+
+// New returns an empty killfile that entries are added to at path.
+func New(path string) *Killfile {
+	return &Killfile{path: path, users: make(map[string]bool), domains: make(map[string]bool)}
+}
+
+// Load reads the killfile at path. A killfile that does not exist is empty.
+func Load(path string) (*Killfile, error) {
+	k := New(path)
+
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return k, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("could not read killfile: %w", err)
+	}
+
+	if err := k.parse(string(content)); err != nil {
+		return nil, err
+	}
+
+	return k, nil
+}
+
+func (k *Killfile) parse(content string) error {
+	scanner := bufio.NewScanner(strings.NewReader(content))
+
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		kind, value, found := strings.Cut(line, ":")
+		kind = strings.TrimSpace(kind)
+		value = strings.TrimSpace(value)
+
+		if !found || value == "" {
+			return fmt.Errorf("line %d: expected 'user:', 'domain:' or 'title:' followed by a value", lineNumber)
+		}
+
+		if err := k.add(kind, value); err != nil {
+			return fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+	}
+
+	return nil
+}
+
+func (k *Killfile) add(kind string, value string) error {
+	switch kind {
+	case KindUser:
+		k.users[strings.ToLower(value)] = true
+
+	case KindDomain:
+		k.domains[strings.ToLower(strings.TrimPrefix(value, "www."))] = true
+
+	case KindTitle:
+		pattern, err := regexp.Compile(value)
+		if err != nil {
+			return fmt.Errorf("invalid title pattern: %w", err)
+		}
+
+		k.titles = append(k.titles, pattern)
+
+	default:
+		return fmt.Errorf("unknown entry %q, expected user, domain or title", kind)
+	}
+
+	return nil
+}
+
+// Mute adds an entry to the killfile and appends it to the file, which is
+// created if it does not exist. Users and domains that are already muted are
+// not added again.
+func (k *Killfile) Mute(kind string, value string) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if (kind == KindUser && k.users[strings.ToLower(value)]) ||
+		(kind == KindDomain && k.domains[strings.ToLower(strings.TrimPrefix(value, "www."))]) {
+		return nil
+	}
+
+	if err := k.add(kind, value); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(k.path), 0o700); err != nil {
+		return fmt.Errorf("could not create killfile directory: %w", err)
+	}
+
+	isNew := false
+	if _, err := os.Stat(k.path); os.IsNotExist(err) {
+		isNew = true
+	}
+
+	f, err := os.OpenFile(k.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("could not open killfile: %w", err)
+	}
+
+	defer f.Close()
+
+	entry := kind + ": " + value + "\n"
+	if isNew {
+		entry = header + "\n" + entry
+	}
+
+	if _, err := f.WriteString(entry); err != nil {
+		return fmt.Errorf("could not write killfile: %w", err)
+	}
+
+	return nil
+}
+
+// IsEmpty reports whether nothing is muted.
+func (k *Killfile) IsEmpty() bool {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	return len(k.users) == 0 && len(k.domains) == 0 && len(k.titles) == 0
+}
+
+// MutesUser reports whether the stories and comments by user are muted.
+func (k *Killfile) MutesUser(user string) bool {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	return k.users[strings.ToLower(user)]
+}
+
+// MutesStory reports whether story is by a muted user, from a muted domain
+// or one of its subdomains, or has a title that matches a muted pattern.
+func (k *Killfile) MutesStory(story *item.Item) bool {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	if k.users[strings.ToLower(story.User)] {
+		return true
+	}
+
+	// The domain of a story is the registered domain, so the host is
+	// needed for muted subdomains
+	domains := []string{strings.ToLower(story.Domain)}
+	if u, err := url.Parse(story.URL); err == nil && u.Hostname() != "" {
+		domains = append(domains, strings.ToLower(strings.TrimPrefix(u.Hostname(), "www.")))
+	}
+
+	for muted := range k.domains {
+		for _, domain := range domains {
+			if domain == muted || strings.HasSuffix(domain, "."+muted) {
+				return true
+			}
+		}
+	}
+
+	for _, pattern := range k.titles {
+		if pattern.MatchString(story.Title) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Stories returns the stories that are not muted.
+func (k *Killfile) Stories(stories []*item.Item) []*item.Item {
+	kept := make([]*item.Item, 0, len(stories))
+
+	for _, story := range stories {
+		if !k.MutesStory(story) {
+			kept = append(kept, story)
+		}
+	}
+
+	return kept
+}
+
+// MuteComments marks the comments by muted users below story as muted.
+func (k *Killfile) MuteComments(story *item.Item) {
+	for _, c := range story.Comments {
+		c.Muted = k.MutesUser(c.User)
+
+		k.MuteComments(c)
+	}
+}
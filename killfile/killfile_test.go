@@ -0,0 +1,124 @@
+package killfile_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"clx/item"
+	"clx/killfile"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func load(t *testing.T, content string) *killfile.Killfile {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "killfile")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	k, err := killfile.Load(path)
+	require.NoError(t, err)
+
+	return k
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	t.Parallel()
+
+	k, err := killfile.Load(filepath.Join(t.TempDir(), "killfile"))
+	require.NoError(t, err)
+
+	assert.True(t, k.IsEmpty())
+}
+
+func TestLoadInvalidFile(t *testing.T) {
+	t.Parallel()
+
+	for _, content := range []string{"user: alfa\nbravo", "language: go", "title: (unclosed", "domain:"} {
+		path := filepath.Join(t.TempDir(), "killfile")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+		_, err := killfile.Load(path)
+		assert.Error(t, err, content)
+	}
+
+	path := filepath.Join(t.TempDir(), "killfile")
+	require.NoError(t, os.WriteFile(path, []byte("# comment\n\nuser: alfa\nbravo\n"), 0o600))
+
+	_, err := killfile.Load(path)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "line 4")
+}
+
+func TestMutesStory(t *testing.T) {
+	t.Parallel()
+
+	k := load(t, "# comment\nuser: Alfa\ndomain: www.example.com\ntitle: (?i)\\bcrypto\n")
+
+	tests := []struct {
+		story *item.Item
+		muted bool
+	}{
+		{&item.Item{User: "alfa"}, true},
+		{&item.Item{User: "bravo"}, false},
+		{&item.Item{Domain: "example.com", URL: "https://example.com/a"}, true},
+		{&item.Item{Domain: "example.com", URL: "https://blog.example.com/a"}, true},
+		{&item.Item{Domain: "notexample.com", URL: "https://notexample.com/a"}, false},
+		{&item.Item{Title: "Crypto winter"}, true},
+		{&item.Item{Title: "Cryptography"}, true},
+		{&item.Item{Title: "Ask HN: Favorite books?"}, false},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.muted, k.MutesStory(test.story), "%+v", test.story)
+	}
+
+	stories := k.Stories([]*item.Item{{ID: 1, User: "alfa"}, {ID: 2, User: "bravo"}})
+	require.Len(t, stories, 1)
+	assert.Equal(t, 2, stories[0].ID)
+}
+
+func TestMuteComments(t *testing.T) {
+	t.Parallel()
+
+	k := load(t, "user: alfa\n")
+
+	story := &item.Item{Comments: []*item.Item{
+		{User: "bravo", Comments: []*item.Item{{User: "ALFA"}}},
+		{User: "alfa"},
+	}}
+
+	k.MuteComments(story)
+
+	assert.False(t, story.Comments[0].Muted)
+	assert.True(t, story.Comments[0].Comments[0].Muted)
+	assert.True(t, story.Comments[1].Muted)
+}
+
+func TestMute(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "clx", "killfile")
+	k, err := killfile.Load(path)
+	require.NoError(t, err)
+
+	require.NoError(t, k.Mute(killfile.KindDomain, "example.com"))
+	require.NoError(t, k.Mute(killfile.KindUser, "alfa"))
+	require.NoError(t, k.Mute(killfile.KindUser, "Alfa"))
+
+	assert.True(t, k.MutesUser("alfa"))
+	assert.True(t, k.MutesStory(&item.Item{Domain: "example.com"}))
+	assert.Error(t, k.Mute(killfile.KindTitle, "(unclosed"))
+
+	reloaded, err := killfile.Load(path)
+	require.NoError(t, err)
+	assert.True(t, reloaded.MutesUser("alfa"))
+	assert.True(t, reloaded.MutesStory(&item.Item{Domain: "example.com"}))
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "domain: example.com\nuser: alfa\n")
+	assert.NotContains(t, string(content), "user: Alfa")
+}
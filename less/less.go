@@ -3,18 +3,63 @@ package less
 import (
 	_ "embed"
 	"os"
+	"strings"
+	"unicode/utf8"
+
+	"clx/constants/unicode"
+	"clx/keymaps"
 )
 
 //go:embed lesskey
 var lesskey string
 
+const (
+	collapse = "filter   ^M&^N" + unicode.InvisibleCharacter + "\\r"
+	expand   = "filter   ^M&^N" + unicode.AnotherInvisibleCharacter + "\\r"
+)
+
+// commands are the less commands of the actions that less can do on its own
+var commands = map[string]string{
+	keymaps.CommentsDown:         "forw-line",
+	keymaps.CommentsUp:           "back-line",
+	keymaps.CommentsHalfPageDown: "forw-scroll",
+	keymaps.CommentsHalfPageUp:   "back-scroll",
+	keymaps.CommentsPageDown:     "forw-screen",
+	keymaps.CommentsPageUp:       "back-screen",
+	keymaps.CommentsTop:          "goto-line",
+	keymaps.CommentsBottom:       "goto-end",
+	keymaps.CommentsSearch:       "forw-search",
+	keymaps.CommentsQuit:         "quit",
+	keymaps.CommentsCollapse:     collapse,
+	keymaps.CommentsExpand:       expand,
+	keymaps.ReaderDown:           "forw-line",
+	keymaps.ReaderUp:             "back-line",
+	keymaps.ReaderHalfPageDown:   "forw-scroll",
+	keymaps.ReaderHalfPageUp:     "back-scroll",
+	keymaps.ReaderPageDown:       "forw-screen",
+	keymaps.ReaderPageUp:         "back-screen",
+	keymaps.ReaderTop:            "goto-line",
+	keymaps.ReaderBottom:         "goto-end",
+	keymaps.ReaderSearch:         "forw-search",
+	keymaps.ReaderQuit:           "quit",
+}
+
+// lessKeys are the named keys in lesskey notation
+var lessKeys = map[string]string{
+	"enter": "\\r", "tab": "\\t", "esc": "\\e", "backspace": "\\b", "delete": "\\kx",
+	"up": "\\ku", "down": "\\kd", "left": "\\kl", "right": "\\kr", "home": "\\kh", "end": "\\ke",
+	"pgup": "\\kU", "pgdown": "\\kD", " ": "\\40",
+}
+
 type Lesskey struct {
 	tempLesskeyFile *os.File
 }
 
-func NewLesskey() *Lesskey {
+// NewLesskey writes a lesskey file with the keys in keymap of the actions in
+// context, which is keymaps.ContextComments or keymaps.ContextReader.
+func NewLesskey(keymap *keymaps.Keymap, context string) *Lesskey {
 	tempLesskeyFile, _ := os.CreateTemp("", "lesskey*")
-	_, _ = tempLesskeyFile.WriteString(lesskey)
+	_, _ = tempLesskeyFile.WriteString(Generate(keymap, context))
 
 	key := new(Lesskey)
 	key.tempLesskeyFile = tempLesskeyFile
@@ -22,6 +67,72 @@ func NewLesskey() *Lesskey {
 	return key
 }
 
+// Generate returns the lesskey file for the actions in context.
+func Generate(keymap *keymaps.Keymap, context string) string {
+	var b strings.Builder
+
+	b.WriteString(lesskey)
+
+	for _, action := range keymaps.Actions(context) {
+		command, ok := commands[action]
+		if !ok {
+			continue
+		}
+
+		for _, key := range keymap.Keys(action) {
+			if lessKey, ok := toLessKey(key); ok {
+				b.WriteString(padding(lessKey) + command + "\n")
+			}
+		}
+	}
+
+	if context == keymaps.ContextComments {
+		b.WriteString("\n# Special commands for startup\n" +
+			"# C is shorthand for 'Collapse' and A is shorthand for 'Auto expand'\n" +
+			"C    " + collapse + "\n" +
+			"A    " + expand + "\n")
+	}
+
+	return b.String()
+}
+
+// toLessKey returns key in lesskey notation, or false if less can not bind it.
+func toLessKey(key string) (string, bool) {
+	if lessKey, ok := lessKeys[key]; ok {
+		return lessKey, true
+	}
+
+	switch {
+	case strings.HasPrefix(key, "ctrl+"):
+		return "^" + strings.ToUpper(strings.TrimPrefix(key, "ctrl+")), true
+
+	case strings.HasPrefix(key, "alt+"):
+		return "\\e" + escape(strings.TrimPrefix(key, "alt+")), true
+
+	case utf8.RuneCountInString(key) == 1:
+		return escape(key), true
+	}
+
+	return "", false
+}
+
+// padding aligns the commands after keys of up to four characters.
+func padding(lessKey string) string {
+	if width := utf8.RuneCountInString(lessKey); width < 5 {
+		return lessKey + strings.Repeat(" ", 5-width)
+	}
+
+	return lessKey + " "
+}
+
+func escape(key string) string {
+	if key == "\\" || key == "^" || key == "#" {
+		return "\\" + key
+	}
+
+	return key
+}
+
 func (key *Lesskey) GetPath() string {
 	return key.tempLesskeyFile.Name()
 }
@@ -0,0 +1,37 @@
+package less_test
+
+import (
+	"testing"
+
+	"clx/keymaps"
+	"clx/less"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerate(t *testing.T) {
+	t.Parallel()
+
+	keymap, err := keymaps.Parse("comments.collapse = ctrl+h #\ncomments.down = space\ncomments.page-down = f\ncomments.quit = alt+q")
+	assert.NoError(t, err)
+
+	lesskey := less.Generate(keymap, keymaps.ContextComments)
+
+	assert.Contains(t, lesskey, "^H   filter   ^M&^N⁣\\r\n")
+	assert.Contains(t, lesskey, "\\#   filter   ^M&^N⁣\\r\n")
+	assert.Contains(t, lesskey, "\\40  forw-line\n")
+	assert.Contains(t, lesskey, "\\eq  quit\n")
+	assert.Contains(t, lesskey, "\\kr  filter   ^M&^N‌\\r\n")
+	assert.Contains(t, lesskey, "C    filter")
+	assert.NotContains(t, lesskey, "h    filter")
+}
+
+func TestGenerateReader(t *testing.T) {
+	t.Parallel()
+
+	lesskey := less.Generate(nil, keymaps.ContextReader)
+
+	assert.Contains(t, lesskey, "\\r   forw-line\n")
+	assert.Contains(t, lesskey, "\\kD  forw-screen\n")
+	assert.NotContains(t, lesskey, "filter   ")
+}
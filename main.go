@@ -1,12 +1,15 @@
 package main
 
 import (
+	"os"
+
 	"clx/cmd"
 )
 
 func main() {
 	rootCmd := cmd.Root()
 	if err := rootCmd.Execute(); err != nil {
-		panic(err)
+		// cobra has already printed the error together with the usage
+		os.Exit(1)
 	}
 }
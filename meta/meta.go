@@ -3,25 +3,31 @@ package meta
 import (
 	"fmt"
 	"strconv"
+	"strings"
 
 	"clx/constants/nerdfonts"
 
 	"clx/comment"
 	"clx/constants/unicode"
+	"clx/hn"
 	"clx/item"
 	"clx/settings"
 	"clx/syntax"
+	"clx/theme"
+	"clx/watchlist"
 
 	text "github.com/MichaelMure/go-term-text"
 
 	. "github.com/logrusorgru/aurora/v3"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/nleeper/goment"
 )
 
 const (
 	newLine      = "\n"
 	newParagraph = "\n\n"
+	reset        = "\033[0m"
 )
 
 func GetReaderModeMetaBlock(title string, url string, lineWidth int) string {
@@ -33,8 +39,8 @@ func GetReaderModeMetaBlock(title string, url string, lineWidth int) string {
 
 	formattedTitle, _ := text.Wrap(Bold(title).String(), lineWidth)
 	formattedTitle = unicode.ZeroWidthSpace + newLine + formattedTitle
-	formattedURL := Blue(text.TruncateMax(url, lineWidth-2)).String()
-	info := newParagraph + Green("Reader Mode").String()
+	formattedURL := theme.Current().Content.Blue.Render(text.TruncateMax(url, lineWidth-2))
+	info := newParagraph + theme.Current().Content.Green.Render("Reader Mode")
 
 	return formattedTitle + newParagraph + style.Render(formattedURL+info) + newParagraph
 }
@@ -68,14 +74,67 @@ func GetCommentSectionMetaBlock(c *item.Item, config *settings.Config, newCommen
 	return getHeadline(c.Title, config) + newParagraph + style.Render(url+joined+rootComment)
 }
 
+// GetUserMetaBlock returns the profile of user in a box of the given width.
+// The about text is cut off after maxAboutLines lines, or shown in full if
+// maxAboutLines is 0.
+func GetUserMetaBlock(user *hn.User, config *settings.Config, lineWidth int, maxAboutLines int) string {
+	style := lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		PaddingLeft(1).
+		PaddingRight(1).
+		Width(lineWidth)
+
+	info := getAuthor(user.ID, config.EnableNerdFonts) + "  " + getKarma(user.Karma, config.EnableNerdFonts) +
+		"  " + Faint("joined "+getAccountAge(user.Created)).String()
+	about := parseAbout(user.About, config, lineWidth, maxAboutLines)
+
+	return style.Render(info + about)
+}
+
+func getKarma(karma int, enableNerdFonts bool) string {
+	if enableNerdFonts {
+		karmaLabel := fmt.Sprintf("%d %s", karma, nerdfonts.Score)
+
+		return theme.Current().Content.Yellow.Render(karmaLabel)
+	}
+
+	return fmt.Sprintf("%s karma", theme.Current().Content.Yellow.Render(strconv.Itoa(karma)))
+}
+
+func getAccountAge(created int64) string {
+	moment, err := goment.Unix(created)
+	if err != nil {
+		return ""
+	}
+
+	return moment.FromNow()
+}
+
+func parseAbout(about string, config *settings.Config, lineWidth int, maxLines int) string {
+	if about == "" {
+		return ""
+	}
+
+	// The about text is not wrapped in a paragraph like comments are
+	formattedAbout := comment.Print("<p>"+about, config, lineWidth-2, lineWidth)
+	wrappedAbout, lines := text.Wrap(formattedAbout, lineWidth-2)
+
+	if maxLines > 0 && lines > maxLines {
+		wrappedAbout = strings.Join(strings.Split(wrappedAbout, newLine)[:maxLines], newLine) + reset +
+			newLine + Faint("…").String()
+	}
+
+	return newParagraph + wrappedAbout
+}
+
 func getAuthor(author string, enableNerdFonts bool) string {
 	if enableNerdFonts {
 		authorLabel := fmt.Sprintf("%s %s", nerdfonts.Author, author)
 
-		return Red(authorLabel).String()
+		return theme.Current().Content.Red.Render(authorLabel)
 	}
 
-	return fmt.Sprintf("by %s", Red(author).String())
+	return fmt.Sprintf("by %s", theme.Current().Content.Red.Render(author))
 }
 
 func getComments(commentsCount int, enableNerdFonts bool) string {
@@ -84,10 +143,10 @@ func getComments(commentsCount int, enableNerdFonts bool) string {
 	if enableNerdFonts {
 		commentsLabel := fmt.Sprintf("%s %s", nerdfonts.Comment, comments)
 
-		return Magenta(commentsLabel).String()
+		return theme.Current().Content.Magenta.Render(commentsLabel)
 	}
 
-	return fmt.Sprintf("%s comments", Magenta(comments).String())
+	return fmt.Sprintf("%s comments", theme.Current().Content.Magenta.Render(comments))
 }
 
 func getScore(points int, enableNerdFonts bool) string {
@@ -96,22 +155,22 @@ func getScore(points int, enableNerdFonts bool) string {
 	if enableNerdFonts {
 		pointsLabel := fmt.Sprintf("%s %s", score, nerdfonts.Score)
 
-		return Yellow(pointsLabel).String()
+		return theme.Current().Content.Yellow.Render(pointsLabel)
 	}
 
-	return fmt.Sprintf("%s points", Yellow(score).String())
+	return fmt.Sprintf("%s points", theme.Current().Content.Yellow.Render(score))
 }
 
 func getID(id int, enableNerdFonts bool) string {
 	if enableNerdFonts {
 		idLabel := fmt.Sprintf("%d %s", id, nerdfonts.Tag)
 
-		return Green(idLabel).Faint().String()
+		return theme.Current().Content.Green.Render(idLabel, theme.Faint)
 	}
 
 	idLabel := fmt.Sprintf("ID %d", id)
 
-	return Green(idLabel).Faint().String()
+	return theme.Current().Content.Green.Render(idLabel, theme.Faint)
 }
 
 func getNewCommentsInfo(newComments int, enableNerdFonts bool) string {
@@ -122,22 +181,22 @@ func getNewCommentsInfo(newComments int, enableNerdFonts bool) string {
 	comments := strconv.Itoa(newComments)
 
 	if enableNerdFonts {
-		return fmt.Sprintf(" (%s)", Cyan(comments).String())
+		return fmt.Sprintf(" (%s)", theme.Current().Content.Cyan.Render(comments))
 	}
 
-	return fmt.Sprintf(" (%s new)", Cyan(comments).String())
+	return fmt.Sprintf(" (%s new)", theme.Current().Content.Cyan.Render(comments))
 }
 
 func getHeadline(title string, config *settings.Config) string {
-	formattedTitle := highlightTitle(unicode.ZeroWidthSpace+" "+newLine+title, config.DisableHeadlineHighlighting,
-		config.EnableNerdFonts)
+	formattedTitle := highlightTitle(unicode.ZeroWidthSpace+" "+newLine+title, config.Watchlist,
+		config.DisableHeadlineHighlighting, config.EnableNerdFonts)
 	wrappedHeadline, _ := text.Wrap(formattedTitle, config.CommentWidth)
 
 	return wrappedHeadline
 }
 
-func highlightTitle(title string, disableHeadlineHighlighting bool, enableNerdFont bool) string {
-	highlightedTitle := title
+func highlightTitle(title string, rules watchlist.Watchlist, disableHeadlineHighlighting bool, enableNerdFont bool) string {
+	highlightedTitle := syntax.HighlightWatchlist(title, rules, syntax.HeadlineInCommentSection)
 
 	if !disableHeadlineHighlighting {
 		highlightedTitle = syntax.HighlightYCStartupsInHeadlines(highlightedTitle, syntax.HeadlineInCommentSection, enableNerdFont)
@@ -155,7 +214,7 @@ func getURL(url string, domain string, lineWidth int) string {
 	}
 
 	truncatedURL := text.TruncateMax(url, lineWidth-2)
-	formattedURL := Blue(truncatedURL).String() + newLine
+	formattedURL := theme.Current().Content.Blue.Render(truncatedURL) + newLine
 
 	return formattedURL + newLine
 }
@@ -24,4 +24,8 @@ const (
 type Block struct {
 	Kind int
 	Text string
+
+	// Language is the language of a code block as given after the opening
+	// backticks, or empty
+	Language string
 }
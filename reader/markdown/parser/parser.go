@@ -116,6 +116,7 @@ func ConvertToMarkdownBlocks(text string) []*markdown.Block {
 		case strings.HasPrefix(lineWithoutFormatting, "```"):
 			temp.kind = markdown.Code
 			temp.text = ""
+			temp.language = strings.TrimSpace(strings.TrimPrefix(lineWithoutFormatting, "```"))
 
 			isInsideCode = true
 
@@ -237,21 +238,24 @@ func appendNonEmptyBuffer(temp *tempBuffer, blocks []*markdown.Block) ([]*markdo
 	}
 
 	b := markdown.Block{
-		Kind: temp.kind,
-		Text: temp.text,
+		Kind:     temp.kind,
+		Text:     temp.text,
+		Language: temp.language,
 	}
 
 	return append(blocks, &b), nil
 }
 
 type tempBuffer struct {
-	kind int
-	text string
+	kind     int
+	text     string
+	language string
 }
 
 func (b *tempBuffer) reset() {
 	b.kind = 0
 	b.text = ""
+	b.language = ""
 }
 
 func (b *tempBuffer) append(text string) {
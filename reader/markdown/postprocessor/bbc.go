@@ -4,8 +4,7 @@ import (
 	"strings"
 
 	"clx/reader/markdown/postprocessor/filter"
-
-	. "github.com/logrusorgru/aurora/v3"
+	"clx/theme"
 )
 
 func processBBC(text string) string {
@@ -37,10 +36,10 @@ func processBBC(text string) string {
 			break
 		}
 
-		image := Cyan("Image: ").Faint().String()
+		image := theme.Current().Content.Cyan.Render("Image: ", theme.Faint)
 		line = strings.ReplaceAll(line, "image source", image)
 
-		caption := Yellow("Caption: ").Faint().String()
+		caption := theme.Current().Content.Yellow.Render("Caption: ", theme.Faint)
 		line = strings.ReplaceAll(line, "image caption", caption)
 
 		output += line + "\n"
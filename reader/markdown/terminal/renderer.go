@@ -10,12 +10,12 @@ import (
 
 	"clx/constants/unicode"
 	"clx/meta"
+	"clx/screen"
 	"clx/syntax"
+	"clx/theme"
 
 	"github.com/charmbracelet/glamour"
 
-	terminal "github.com/wayneashleyberry/terminal-dimensions"
-
 	termtext "github.com/MichaelMure/go-term-text"
 
 	. "github.com/logrusorgru/aurora/v3"
@@ -46,7 +46,7 @@ func ConvertToTerminalFormat(blocks []*markdown.Block, lineWidth int, indentBloc
 			output += renderImage(block.Text, lineWidth) + "\n\n"
 
 		case markdown.Code:
-			output += renderCode(block.Text) + "\n\n"
+			output += renderCode(block.Text, block.Language) + "\n\n"
 
 		case markdown.Quote:
 			output += renderQuote(block.Text, lineWidth, indentBlock) + "\n\n"
@@ -142,12 +142,13 @@ func renderList(text string, lineWidth int) string {
 }
 
 func renderImage(text string, lineWidth int) string {
-	red := "\u001B[31m"
+	content := theme.Current().Content
 	italic := "\u001B[3m"
 	faint := "\u001B[2m"
 	normal := "\u001B[0m"
-	imageLabel := normal + Red(unicode.Block).Faint().String() + Yellow(unicode.Block).Faint().String() +
-		Blue(unicode.Block).Faint().String() + normal + red + faint + italic + " Image " + normal + faint + italic
+	imageLabel := normal + content.Red.Render(unicode.Block, theme.Faint) + content.Yellow.Render(unicode.Block, theme.Faint) +
+		content.Blue.Render(unicode.Block, theme.Faint) + normal + content.Red.Sequence() + faint + italic + " Image " +
+		normal + faint + italic
 
 	text = regexp.MustCompile(`!\[(.*?)\]\(.*?\)$`).
 		ReplaceAllString(text, imageLabel+`$1`)
@@ -192,19 +193,13 @@ func renderImage(text string, lineWidth int) string {
 	return output
 }
 
-func renderCode(text string) string {
-	screenWidth, _ := terminal.Width()
-
-	text = strings.TrimSuffix(text, "\n")
-	text = strings.TrimPrefix(text, "\n")
+func renderCode(text string, language string) string {
+	screenWidth := screen.GetTerminalWidth()
 
-	text = Faint(text).String()
 	text = removeHrefs(text)
+	text = syntax.HighlightCode(text, language, screenWidth-len(indentLevel1))
 
-	padding := termtext.WrapPad(indentLevel1)
-	text, _ = termtext.Wrap(text, int(screenWidth), padding)
-
-	return text
+	return indentLevel1 + strings.ReplaceAll(text, "\n", "\n"+indentLevel1)
 }
 
 func renderQuote(text string, lineWidth int, indentSymbol string) string {
@@ -223,7 +218,7 @@ func renderQuote(text string, lineWidth int, indentSymbol string) string {
 }
 
 func renderTable(text string) string {
-	screenWidth, _ := terminal.Width()
+	screenWidth := screen.GetTerminalWidth()
 	text = strings.ReplaceAll(text, markdown.ItalicStart, "")
 	text = strings.ReplaceAll(text, markdown.ItalicStop, "")
 
@@ -234,7 +229,7 @@ func renderTable(text string) string {
 	text = removeImageReference(text)
 
 	r, _ := glamour.NewTermRenderer(glamour.WithStyles(glamour.NoTTYStyleConfig),
-		glamour.WithWordWrap(int(screenWidth)))
+		glamour.WithWordWrap(screenWidth))
 
 	out, _ := r.Render(text)
 
@@ -295,7 +290,7 @@ func bldInQuote(text string) string {
 
 func h1(text string, lineWidth int) string {
 	text = preFormatHeader(text)
-	text = White(unicode.Block+" ").String() + Bold(text).String()
+	text = theme.Current().Content.White.Render(unicode.Block+" ") + Bold(text).String()
 
 	text, _ = termtext.Wrap(text, lineWidth)
 
@@ -304,7 +299,7 @@ func h1(text string, lineWidth int) string {
 
 func h2(text string, lineWidth int) string {
 	text = preFormatHeader(text)
-	text = Blue(unicode.Block+" ").String() + Bold(text).String()
+	text = theme.Current().Content.Blue.Render(unicode.Block+" ") + Bold(text).String()
 
 	text, _ = termtext.Wrap(text, lineWidth)
 
@@ -314,7 +309,7 @@ func h2(text string, lineWidth int) string {
 func h3(text string, lineWidth int) string {
 	text = preFormatHeader(text)
 	block := strings.Repeat(unicode.Block, 2)
-	text = Red(block).String() + " " + Bold(text).String()
+	text = theme.Current().Content.Red.Render(block) + " " + Bold(text).String()
 
 	text, _ = termtext.Wrap(text, lineWidth)
 
@@ -324,7 +319,7 @@ func h3(text string, lineWidth int) string {
 func h4(text string, lineWidth int) string {
 	text = preFormatHeader(text)
 	block := strings.Repeat(unicode.Block, 3)
-	text = Magenta(block).String() + " " + Bold(text).String()
+	text = theme.Current().Content.Magenta.Render(block) + " " + Bold(text).String()
 
 	text, _ = termtext.Wrap(text, lineWidth)
 
@@ -334,7 +329,7 @@ func h4(text string, lineWidth int) string {
 func h5(text string, lineWidth int) string {
 	text = preFormatHeader(text)
 	block := strings.Repeat(unicode.Block, 4)
-	text = Yellow(block).String() + " " + Bold(text).String()
+	text = theme.Current().Content.Yellow.Render(block) + " " + Bold(text).String()
 
 	text, _ = termtext.Wrap(text, lineWidth)
 
@@ -344,7 +339,7 @@ func h5(text string, lineWidth int) string {
 func h6(text string, lineWidth int) string {
 	text = preFormatHeader(text)
 	block := strings.Repeat(unicode.Block, 5)
-	text = Green(block).String() + " " + Bold(text).String()
+	text = theme.Current().Content.Green.Render(block) + " " + Bold(text).String()
 
 	text, _ = termtext.Wrap(text, lineWidth)
 
@@ -425,7 +420,7 @@ func trimLeadingZero(text string) string {
 }
 
 func highlightBackticks(text string) string {
-	magenta := "\u001B[35m"
+	magenta := theme.Current().Content.Magenta.Sequence()
 	italic := "\u001B[3m"
 	normal := "\u001B[0m"
 
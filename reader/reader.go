@@ -24,13 +24,17 @@ func GetArticle(url string, title string, width int, indentationSymbol string) (
 		return "", fmt.Errorf("could not fetch url: %w", httpErr)
 	}
 
+	return Render(articleInMarkdown, url, title, width, indentationSymbol), nil
+}
+
+// Render returns an article that has been converted to Markdown as it is shown
+// in Reader Mode.
+func Render(articleInMarkdown string, url string, title string, width int, indentationSymbol string) string {
 	markdownBlocks := parser.ConvertToMarkdownBlocks(articleInMarkdown)
 
 	articleInTerminalFormal := terminal.ConvertToTerminalFormat(markdownBlocks, width, indentationSymbol)
 
 	header := terminal.CreateHeader(title, url, width)
 
-	articleInTerminalFormal = postprocessor.Process(header+articleInTerminalFormal, url)
-
-	return articleInTerminalFormal, nil
+	return postprocessor.Process(header+articleInTerminalFormal, url)
 }
@@ -1,13 +1,22 @@
 package screen
 
 import (
+	"clx/utils/ints"
+
 	terminal "github.com/wayneashleyberry/terminal-dimensions"
 )
 
+const (
+	// Used when stdout is not a terminal, for example when the output is
+	// piped or when running the tests
+	fallbackWidth  = 80
+	fallbackHeight = 24
+)
+
 func GetTerminalHeight() int {
 	height, err := terminal.Height()
-	if err != nil {
-		panic("Could not determine terminal height")
+	if err != nil || height == 0 {
+		return fallbackHeight
 	}
 
 	return int(height)
@@ -15,8 +24,8 @@ func GetTerminalHeight() int {
 
 func GetTerminalWidth() int {
 	width, err := terminal.Width()
-	if err != nil {
-		panic("Could not determine terminal width")
+	if err != nil || width == 0 {
+		return fallbackWidth
 	}
 
 	return int(width)
@@ -27,13 +36,5 @@ func GetSubmissionsToShow(screenHeight int, maxStories int) int {
 	footerHeight := 2
 	adjustedHeight := screenHeight - topBarHeight - footerHeight
 
-	return min(adjustedHeight/2, maxStories)
-}
-
-func min(x, y int) int {
-	if x > y {
-		return y
-	}
-
-	return x
+	return ints.Min(adjustedHeight/2, maxStories)
 }
@@ -1,5 +1,26 @@
 package settings
 
+import (
+	"time"
+
+	"clx/constants/category"
+	"clx/keymaps"
+	"clx/watchlist"
+)
+
+const (
+	DefaultFirebaseURL  = "https://hacker-news.firebaseio.com/v0"
+	DefaultAlgoliaURL   = "https://hn.algolia.com/api/v1"
+	DefaultHackerWebURL = "http://api.hackerwebapp.com"
+	DefaultWebURL       = "https://news.ycombinator.com"
+
+	CommentBackendHackerWeb = "hackerweb"
+	CommentBackendFirebase  = "firebase"
+
+	PagerNative = "native"
+	PagerLess   = "less"
+)
+
 type Config struct {
 	CommentWidth                int
 	DisableHeadlineHighlighting bool
@@ -13,11 +34,52 @@ type Config struct {
 	LesskeyPath                 string
 	AutoExpandComments          bool
 	NoLessVerify                bool
+	FirebaseURL                 string
+	AlgoliaURL                  string
+	HackerWebURL                string
+	WebURL                      string
+	RepliesUser                 string
+	Offline                     bool
+	CategoryCacheTTL            map[int]time.Duration
+	CommentCacheTTL             time.Duration
+	CommentBackend              string
+	Categories                  []int
+	Pager                       string
+	Theme                       string
+	SplitPane                   bool
+	SplitPaneMinWidth           int
+	DisableMouse                bool
+
+	// Watchlist and Keymap are read from their own files, not from the config
+	// file
+	Watchlist watchlist.Watchlist
+	Keymap    *keymaps.Keymap
 }
 
 func Default() *Config {
 	return &Config{
 		CommentWidth:      70,
 		IndentationSymbol: " ▎",
+		FirebaseURL:       DefaultFirebaseURL,
+		AlgoliaURL:        DefaultAlgoliaURL,
+		HackerWebURL:      DefaultHackerWebURL,
+		WebURL:            DefaultWebURL,
+		CategoryCacheTTL: map[int]time.Duration{
+			category.FrontPage: 5 * time.Minute,
+			category.New:       time.Minute,
+			category.Ask:       10 * time.Minute,
+			category.Show:      10 * time.Minute,
+			category.Best:      10 * time.Minute,
+			category.Jobs:      30 * time.Minute,
+			category.Past:      time.Hour,
+			category.Active:    time.Minute,
+		},
+		CommentCacheTTL: 5 * time.Minute,
+		CommentBackend:  CommentBackendHackerWeb,
+		Categories:      []int{category.New, category.Ask, category.Show},
+		Pager:           PagerNative,
+		Theme:           "default",
+
+		SplitPaneMinWidth: 160,
 	}
 }
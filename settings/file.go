@@ -0,0 +1,187 @@
+package settings
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// LineError describes a problem on a single line of a config file.
+type LineError struct {
+	Line int
+	Err  error
+}
+
+func (e *LineError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Err)
+}
+
+func (e *LineError) Unwrap() error {
+	return e.Err
+}
+
+// ParseErrors is returned when a config file contains one or more invalid
+// lines.
+type ParseErrors []*LineError
+
+func (e ParseErrors) Error() string {
+	messages := make([]string, 0, len(e))
+
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+
+	return strings.Join(messages, "\n")
+}
+
+// LoadFile reads the config file at path into config. A missing file is not
+// an error.
+func LoadFile(path string, config *Config) error {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("could not open config file: %w", err)
+	}
+
+	defer f.Close()
+
+	return Parse(f, config)
+}
+
+// Parse reads settings in the config file format from r into config. The
+// format is the same as for .env files: one KEY=value pair per line, with
+// lines starting with # treated as comments. Values may be quoted.
+//
+// All lines are parsed even if some of them are invalid. If any of them
+// are, the returned error is of type ParseErrors.
+func Parse(r io.Reader, config *Config) error {
+	var (
+		errs      ParseErrors
+		firstSeen = make(map[string]int)
+		scanner   = bufio.NewScanner(r)
+		lineNo    = 0
+	)
+
+	for scanner.Scan() {
+		lineNo++
+
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key, value, found := strings.Cut(strings.TrimPrefix(line, "export "), "=")
+		if !found {
+			errs = append(errs, &LineError{Line: lineNo, Err: fmt.Errorf("expected KEY=value, got %q", line)})
+
+			continue
+		}
+
+		key = strings.TrimSpace(key)
+		value = unquote(strings.TrimSpace(value))
+
+		option := LookupOption(key)
+		if option == nil || option.Key() != key {
+			errs = append(errs, &LineError{Line: lineNo, Err: fmt.Errorf("unknown setting %q", key)})
+
+			continue
+		}
+
+		if first, ok := firstSeen[key]; ok {
+			errs = append(errs, &LineError{Line: lineNo, Err: fmt.Errorf("%s is already set on line %d", key, first)})
+
+			continue
+		}
+
+		firstSeen[key] = lineNo
+
+		if err := option.Set(config, value); err != nil {
+			errs = append(errs, &LineError{Line: lineNo, Err: fmt.Errorf("invalid value for %s: %w", key, err)})
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("could not read config file: %w", err)
+	}
+
+	if len(errs) != 0 {
+		return errs
+	}
+
+	return nil
+}
+
+func unquote(value string) string {
+	if len(value) < 2 {
+		return value
+	}
+
+	first, last := value[0], value[len(value)-1]
+	if (first == '"' || first == '\'') && first == last {
+		return value[1 : len(value)-1]
+	}
+
+	return value
+}
+
+// ApplyEnvironment overrides the settings in config with the values of the
+// CLX_* environment variables.
+func ApplyEnvironment(config *Config) error {
+	for _, option := range Options() {
+		value, ok := os.LookupEnv(option.EnvironmentVariable())
+		if !ok {
+			continue
+		}
+
+		if err := option.Set(config, value); err != nil {
+			return fmt.Errorf("invalid value for %s: %w", option.EnvironmentVariable(), err)
+		}
+	}
+
+	return nil
+}
+
+// Format returns the settings in config in the config file format.
+func Format(config *Config) string {
+	var sb strings.Builder
+
+	for _, option := range Options() {
+		sb.WriteString(option.Key() + "=" + quote(option.Get(config)) + "\n")
+	}
+
+	return sb.String()
+}
+
+// DefaultFile returns the contents of a config file where every setting is
+// commented out and set to its default value.
+func DefaultFile() string {
+	var (
+		sb       strings.Builder
+		defaults = Default()
+	)
+
+	sb.WriteString("# circumflex config file\n")
+	sb.WriteString("#\n")
+	sb.WriteString("# Uncomment a line to change a setting. Settings in this file can be\n")
+	sb.WriteString("# overridden with CLX_<SETTING> environment variables and with flags.\n")
+
+	for _, option := range Options() {
+		sb.WriteString("\n# " + option.Description + "\n")
+		sb.WriteString("#" + option.Key() + "=" + quote(option.Get(defaults)) + "\n")
+	}
+
+	return sb.String()
+}
+
+func quote(value string) string {
+	if strings.ContainsAny(value, " #\"'") || value == "" {
+		return `"` + value + `"`
+	}
+
+	return value
+}
@@ -0,0 +1,92 @@
+package settings_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"clx/constants/category"
+	"clx/settings"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	file := `
+# comment
+COMMENT_WIDTH=80
+export NERDFONTS=true
+ALGOLIA_URL="http://localhost:8080/api/v1"
+CATEGORIES=best, new,active
+REPLIES_USER=dang
+`
+
+	config := settings.Default()
+	require.NoError(t, settings.Parse(strings.NewReader(file), config))
+
+	assert.Equal(t, 80, config.CommentWidth)
+	assert.True(t, config.EnableNerdFonts)
+	assert.Equal(t, "http://localhost:8080/api/v1", config.AlgoliaURL)
+	assert.Equal(t, settings.DefaultFirebaseURL, config.FirebaseURL)
+	assert.Equal(t, []int{category.Best, category.New, category.Active}, config.Categories)
+	assert.Equal(t, "dang", config.RepliesUser)
+}
+
+func TestParseErrors(t *testing.T) {
+	t.Parallel()
+
+	file := `COMMENT_WIDTH=wide
+NERDFONTS=true
+NERDFONTS=false
+UNKNOWN=1
+no equals sign
+CATEGORIES=new,new`
+
+	err := settings.Parse(strings.NewReader(file), settings.Default())
+
+	var parseErrors settings.ParseErrors
+
+	require.True(t, errors.As(err, &parseErrors))
+	require.Len(t, parseErrors, 5)
+	assert.Equal(t, 1, parseErrors[0].Line)
+	assert.Equal(t, 3, parseErrors[1].Line)
+	assert.Equal(t, 4, parseErrors[2].Line)
+	assert.Equal(t, 5, parseErrors[3].Line)
+	assert.Equal(t, 6, parseErrors[4].Line)
+}
+
+func TestParseEmptyCategories(t *testing.T) {
+	t.Parallel()
+
+	for _, value := range []string{"", " , ", "\"\""} {
+		config := settings.Default()
+		err := settings.Parse(strings.NewReader("# comment\nCATEGORIES="+value), config)
+
+		var parseErrors settings.ParseErrors
+
+		require.True(t, errors.As(err, &parseErrors), value)
+		require.Len(t, parseErrors, 1)
+		assert.Equal(t, 2, parseErrors[0].Line)
+		assert.Equal(t, settings.Default().Categories, config.Categories)
+	}
+}
+
+func TestDefaultFileRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	config := settings.Default()
+	require.NoError(t, settings.Parse(strings.NewReader(settings.DefaultFile()), config))
+	assert.Equal(t, settings.Default(), config)
+
+	config.CommentWidth = 100
+	config.DisableEmojis = true
+	config.Categories = []int{category.Jobs, category.Past}
+	config.RepliesUser = "dang"
+
+	parsed := settings.Default()
+	require.NoError(t, settings.Parse(strings.NewReader(settings.Format(config)), parsed))
+	assert.Equal(t, config, parsed)
+}
@@ -0,0 +1,364 @@
+package settings
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"clx/constants/category"
+)
+
+const environmentPrefix = "CLX_"
+
+// Option is a setting that can be set in the config file, through an
+// environment variable and with a command line flag. Name is the name of
+// the flag; the key in the config file and the environment variable are
+// derived from it.
+type Option struct {
+	Name        string
+	Description string
+
+	get func(c *Config) string
+	set func(c *Config, value string) error
+}
+
+// Key returns the key used for this option in the config file, for example
+// COMMENT_WIDTH.
+func (o *Option) Key() string {
+	return strings.ToUpper(strings.ReplaceAll(o.Name, "-", "_"))
+}
+
+// EnvironmentVariable returns the name of the environment variable that
+// overrides the config file, for example CLX_COMMENT_WIDTH.
+func (o *Option) EnvironmentVariable() string {
+	return environmentPrefix + o.Key()
+}
+
+// Get returns the current value of the option in c, formatted the same way
+// as in the config file.
+func (o *Option) Get(c *Config) string {
+	return o.get(c)
+}
+
+// Set parses value and stores it in c.
+func (o *Option) Set(c *Config, value string) error {
+	return o.set(c, value)
+}
+
+// Options returns all options in the order they appear in the config file.
+func Options() []*Option {
+	return []*Option{
+		intOption("comment-width", "Set the comment width",
+			func(c *Config) *int { return &c.CommentWidth }),
+		boolOption("plain-headlines", "Disable syntax highlighting for headlines",
+			func(c *Config) *bool { return &c.DisableHeadlineHighlighting }),
+		boolOption("plain-comments", "Disable syntax highlighting for comments",
+			func(c *Config) *bool { return &c.DisableCommentHighlighting }),
+		boolOption("disable-emojis", "Disable conversion of smileys to emojis",
+			func(c *Config) *bool { return &c.DisableEmojis }),
+		boolOption("disable-history", "Disable marking stories as read",
+			func(c *Config) *bool { return &c.DoNotMarkSubmissionsAsRead }),
+		boolOption("hide-indent", "Hide the indentation bar to the left of the reply",
+			func(c *Config) *bool { return &c.HideIndentSymbol }),
+		boolOption("nerdfonts", "Enable Nerd Fonts",
+			func(c *Config) *bool { return &c.EnableNerdFonts }),
+		boolOption("auto-expand", "Automatically expand all replies upon entering the comment section",
+			func(c *Config) *bool { return &c.AutoExpandComments }),
+		boolOption("no-less-verify", "Disable checking less version on startup",
+			func(c *Config) *bool { return &c.NoLessVerify }),
+		enumOption("pager", "Show comment sections and articles in the built-in viewer (native) or in less",
+			[]string{PagerNative, PagerLess},
+			func(c *Config) *string { return &c.Pager }),
+		stringOption("theme", "Color theme, either a built-in theme or the name of a file in the themes directory",
+			func(c *Config) *string { return &c.Theme }),
+		boolOption("split-pane", "Show the selected story and its top comments next to the list on wide screens",
+			func(c *Config) *bool { return &c.SplitPane }),
+		intOption("split-pane-min-width", "Narrowest terminal width that the list is split at",
+			func(c *Config) *int { return &c.SplitPaneMinWidth }),
+		boolOption("disable-mouse", "Disable the mouse in the list, which lets the terminal select text",
+			func(c *Config) *bool { return &c.DisableMouse }),
+		urlOption("firebase-url", "Base URL of the Firebase API",
+			func(c *Config) *string { return &c.FirebaseURL }),
+		urlOption("algolia-url", "Base URL of the Algolia API",
+			func(c *Config) *string { return &c.AlgoliaURL }),
+		urlOption("hackerweb-url", "Base URL of the hackerweb API",
+			func(c *Config) *string { return &c.HackerWebURL }),
+		urlOption("web-url", "Base URL of the Hacker News website, used for logging in, voting and replying",
+			func(c *Config) *string { return &c.WebURL }),
+		userOption("replies-user", "User whose replies the Replies category shows, the logged-in user if empty",
+			func(c *Config) *string { return &c.RepliesUser }),
+		categoriesOption("categories", "Categories shown in the header, in order ("+
+			strings.Join(category.Selectable(), ", ")+")"),
+		enumOption("comment-backend", "Fetch comments from hackerweb (falls back to firebase on errors) or firebase",
+			[]string{CommentBackendHackerWeb, CommentBackendFirebase},
+			func(c *Config) *string { return &c.CommentBackend }),
+		boolOption("offline", "Only show stories and comments from the cache",
+			func(c *Config) *bool { return &c.Offline }),
+		categoryTTLOption("cache-ttl-front-page", "How long the front page is cached, for example 5m", category.FrontPage),
+		categoryTTLOption("cache-ttl-new", "How long the New category is cached", category.New),
+		categoryTTLOption("cache-ttl-ask", "How long the Ask HN category is cached", category.Ask),
+		categoryTTLOption("cache-ttl-show", "How long the Show HN category is cached", category.Show),
+		categoryTTLOption("cache-ttl-best", "How long the Best category is cached", category.Best),
+		categoryTTLOption("cache-ttl-jobs", "How long the Jobs category is cached", category.Jobs),
+		categoryTTLOption("cache-ttl-past", "How long the Past category is cached", category.Past),
+		categoryTTLOption("cache-ttl-active", "How long the Active category is cached", category.Active),
+		durationOption("cache-ttl-comments", "How long comment sections are cached",
+			func(c *Config) *time.Duration { return &c.CommentCacheTTL }),
+	}
+}
+
+// LookupOption returns the option with the given flag name or config file
+// key, or nil if there is no such option.
+func LookupOption(nameOrKey string) *Option {
+	for _, option := range Options() {
+		if option.Name == nameOrKey || option.Key() == nameOrKey {
+			return option
+		}
+	}
+
+	return nil
+}
+
+func intOption(name string, description string, field func(c *Config) *int) *Option {
+	return &Option{
+		Name:        name,
+		Description: description,
+		get: func(c *Config) string {
+			return strconv.Itoa(*field(c))
+		},
+		set: func(c *Config, value string) error {
+			n, err := strconv.Atoi(value)
+			if err != nil || n <= 0 {
+				return fmt.Errorf("%q is not a positive whole number", value)
+			}
+
+			*field(c) = n
+
+			return nil
+		},
+	}
+}
+
+func boolOption(name string, description string, field func(c *Config) *bool) *Option {
+	return &Option{
+		Name:        name,
+		Description: description,
+		get: func(c *Config) string {
+			return strconv.FormatBool(*field(c))
+		},
+		set: func(c *Config, value string) error {
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("%q is not true or false", value)
+			}
+
+			*field(c) = b
+
+			return nil
+		},
+	}
+}
+
+func stringOption(name string, description string, field func(c *Config) *string) *Option {
+	return &Option{
+		Name:        name,
+		Description: description,
+		get: func(c *Config) string {
+			return *field(c)
+		},
+		set: func(c *Config, value string) error {
+			if strings.TrimSpace(value) == "" {
+				return fmt.Errorf("%q is empty", value)
+			}
+
+			*field(c) = value
+
+			return nil
+		},
+	}
+}
+
+// userOption is a Hacker News user name, which may be left empty.
+func userOption(name string, description string, field func(c *Config) *string) *Option {
+	return &Option{
+		Name:        name,
+		Description: description,
+		get: func(c *Config) string {
+			return *field(c)
+		},
+		set: func(c *Config, value string) error {
+			for _, r := range value {
+				if !isUserNameRune(r) {
+					return fmt.Errorf("%q is not a user name", value)
+				}
+			}
+
+			*field(c) = value
+
+			return nil
+		},
+	}
+}
+
+func isUserNameRune(r rune) bool {
+	return r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_'
+}
+
+func urlOption(name string, description string, field func(c *Config) *string) *Option {
+	return &Option{
+		Name:        name,
+		Description: description,
+		get: func(c *Config) string {
+			return *field(c)
+		},
+		set: func(c *Config, value string) error {
+			u, err := url.Parse(value)
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				return fmt.Errorf("%q is not an http or https URL", value)
+			}
+
+			*field(c) = value
+
+			return nil
+		},
+	}
+}
+
+func enumOption(name string, description string, values []string, field func(c *Config) *string) *Option {
+	return &Option{
+		Name:        name,
+		Description: description,
+		get: func(c *Config) string {
+			return *field(c)
+		},
+		set: func(c *Config, value string) error {
+			for _, v := range values {
+				if v == value {
+					*field(c) = value
+
+					return nil
+				}
+			}
+
+			return fmt.Errorf("%q is not one of %s", value, strings.Join(values, ", "))
+		},
+	}
+}
+
+func durationOption(name string, description string, field func(c *Config) *time.Duration) *Option {
+	return &Option{
+		Name:        name,
+		Description: description,
+		get: func(c *Config) string {
+			return formatDuration(*field(c))
+		},
+		set: func(c *Config, value string) error {
+			d, err := parseDuration(value)
+			if err != nil {
+				return err
+			}
+
+			*field(c) = d
+
+			return nil
+		},
+	}
+}
+
+func categoryTTLOption(name string, description string, cat int) *Option {
+	return &Option{
+		Name:        name,
+		Description: description,
+		get: func(c *Config) string {
+			return formatDuration(c.CategoryCacheTTL[cat])
+		},
+		set: func(c *Config, value string) error {
+			d, err := parseDuration(value)
+			if err != nil {
+				return err
+			}
+
+			if c.CategoryCacheTTL == nil {
+				c.CategoryCacheTTL = make(map[int]time.Duration)
+			}
+
+			c.CategoryCacheTTL[cat] = d
+
+			return nil
+		},
+	}
+}
+
+func parseDuration(value string) (time.Duration, error) {
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("%q is not a duration such as 30s, 5m or 1h", value)
+	}
+
+	return d, nil
+}
+
+// formatDuration formats d without the trailing zero units that
+// time.Duration.String adds, so that 5m is shown as 5m and not 5m0s.
+func formatDuration(d time.Duration) string {
+	s := d.String()
+
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+
+	return s
+}
+
+func categoriesOption(name string, description string) *Option {
+	return &Option{
+		Name:        name,
+		Description: description,
+		get: func(c *Config) string {
+			names := make([]string, 0, len(c.Categories))
+
+			for _, cat := range c.Categories {
+				names = append(names, category.Name(cat))
+			}
+
+			return strings.Join(names, ",")
+		},
+		set: func(c *Config, value string) error {
+			var categories []int
+
+			for _, name := range strings.Split(value, ",") {
+				if strings.TrimSpace(name) == "" {
+					continue
+				}
+
+				cat, ok := category.Parse(name)
+				if !ok {
+					return fmt.Errorf("%q is not one of %s", name, strings.Join(category.Selectable(), ", "))
+				}
+
+				for _, existing := range categories {
+					if existing == cat {
+						return fmt.Errorf("%q is listed more than once", name)
+					}
+				}
+
+				categories = append(categories, cat)
+			}
+
+			// The header would be empty
+			if len(categories) == 0 {
+				return fmt.Errorf("%q lists none of %s", value, strings.Join(category.Selectable(), ", "))
+			}
+
+			c.Categories = categories
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,196 @@
+package syntax
+
+import (
+	"strings"
+
+	"clx/theme"
+
+	"github.com/alecthomas/chroma"
+	"github.com/alecthomas/chroma/lexers"
+	"github.com/mattn/go-runewidth"
+)
+
+const (
+	tabWidth = 4
+
+	// continuationMarker starts the lines that a long line of code is
+	// wrapped onto
+	continuationMarker = "↪ "
+)
+
+// HighlightCode colors code with the lexer for language, or with the lexer
+// that chroma guesses from code if language is empty or unknown, and wraps
+// lines that are wider than width. Code that no lexer recognizes is dimmed.
+// Every line has its own escape codes, so lines can be shown on their own.
+func HighlightCode(code string, language string, width int) string {
+	code = prepareCode(code)
+
+	lexer := getLexer(code, language)
+	if lexer == nil {
+		return DimCode(code, width)
+	}
+
+	iterator, err := chroma.Coalesce(lexer).Tokenise(nil, code)
+	if err != nil {
+		return DimCode(code, width)
+	}
+
+	w := newCodeWriter(width)
+
+	for _, token := range iterator.Tokens() {
+		color, attributes := getTokenStyle(token.Type)
+
+		w.write(token.Value, color, attributes...)
+	}
+
+	return w.String()
+}
+
+// DimCode dims code and wraps lines that are wider than width in the same way
+// as HighlightCode. Empty lines are dimmed too, as every line of a code block
+// has always been.
+func DimCode(code string, width int) string {
+	w := newCodeWriter(width)
+	w.write(prepareCode(code), theme.Color{}, theme.Faint)
+
+	lines := strings.Split(w.String(), "\n")
+
+	for i, line := range lines {
+		if line == "" {
+			lines[i] = theme.Color{}.Render("", theme.Faint)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func prepareCode(code string) string {
+	lines := strings.Split(strings.Trim(code, "\n"), "\n")
+
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(strings.ReplaceAll(line, "\t", strings.Repeat(" ", tabWidth)), " ")
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func getLexer(code string, language string) chroma.Lexer {
+	if language != "" {
+		if lexer := lexers.Get(language); lexer != nil {
+			return lexer
+		}
+	}
+
+	return lexers.Analyse(code)
+}
+
+func getTokenStyle(tokenType chroma.TokenType) (theme.Color, []string) {
+	content := theme.Current().Content
+
+	switch {
+	case tokenType.InCategory(chroma.Comment):
+		return theme.Color{}, []string{theme.Faint, theme.Italic}
+
+	case tokenType.InCategory(chroma.Keyword):
+		return content.Magenta, nil
+
+	case tokenType == chroma.NameFunction || tokenType == chroma.NameClass || tokenType == chroma.NameTag:
+		return content.Blue, nil
+
+	case tokenType == chroma.NameBuiltin || tokenType == chroma.NameDecorator:
+		return content.Cyan, nil
+
+	case tokenType == chroma.NameAttribute || tokenType.InSubCategory(chroma.LiteralNumber):
+		return content.Yellow, nil
+
+	case tokenType.InSubCategory(chroma.LiteralString) || tokenType == chroma.GenericInserted:
+		return content.Green, nil
+
+	case tokenType == chroma.GenericDeleted || tokenType == chroma.Error:
+		return content.Red, nil
+
+	default:
+		return theme.Color{}, nil
+	}
+}
+
+// codeWriter breaks code into lines of at most width columns and styles each
+// piece of a line on its own.
+type codeWriter struct {
+	lines  []string
+	line   strings.Builder
+	column int
+	width  int
+}
+
+func newCodeWriter(width int) *codeWriter {
+	// Leave room for at least a few characters after the continuation marker
+	minWidth := runewidth.StringWidth(continuationMarker) + 4
+
+	if width < minWidth {
+		width = minWidth
+	}
+
+	return &codeWriter{width: width}
+}
+
+func (w *codeWriter) write(text string, color theme.Color, attributes ...string) {
+	for i, line := range strings.Split(text, "\n") {
+		if i > 0 {
+			w.endLine()
+		}
+
+		w.writeLine(line, color, attributes)
+	}
+}
+
+func (w *codeWriter) writeLine(text string, color theme.Color, attributes []string) {
+	for text != "" {
+		if w.column >= w.width {
+			w.endLine()
+			w.line.WriteString(theme.Color{}.Render(continuationMarker, theme.Faint))
+			w.column = runewidth.StringWidth(continuationMarker)
+		}
+
+		piece, pieceWidth := cut(text, w.width-w.column)
+
+		w.line.WriteString(color.Render(piece, attributes...))
+		w.column += pieceWidth
+		text = text[len(piece):]
+	}
+}
+
+func (w *codeWriter) endLine() {
+	w.lines = append(w.lines, w.line.String())
+	w.line.Reset()
+	w.column = 0
+}
+
+func (w *codeWriter) String() string {
+	lines := append(w.lines, w.line.String())
+
+	// Lexers end the code with a newline
+	for len(lines) > 1 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// cut returns the longest start of text that fits in width columns, but at
+// least one character, and its width.
+func cut(text string, width int) (string, int) {
+	columns := 0
+
+	for i, r := range text {
+		runeWidth := runewidth.RuneWidth(r)
+
+		if columns+runeWidth > width && i > 0 {
+			return text[:i], columns
+		}
+
+		columns += runeWidth
+	}
+
+	return text, columns
+}
@@ -0,0 +1,69 @@
+package syntax_test
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"clx/syntax"
+	"clx/theme"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+	"github.com/muesli/termenv"
+	"github.com/stretchr/testify/assert"
+)
+
+var escapeCodes = regexp.MustCompile("\033\\[[0-9;]*m")
+
+func setTheme() {
+	lipgloss.SetColorProfile(termenv.ANSI)
+	theme.Set(&theme.Theme{Content: theme.Palette{
+		Red: theme.Same("1"), Green: theme.Same("2"), Yellow: theme.Same("3"), Blue: theme.Same("4"),
+		Magenta: theme.Same("5"), Cyan: theme.Same("6"), White: theme.Same("7"),
+	}})
+}
+
+func TestHighlightCode(t *testing.T) {
+	setTheme()
+
+	code := "func main() {\n\t/* a\n\tb */\n\tfmt.Println(\"hello\", 42)\n}\n"
+	highlighted := syntax.HighlightCode(code, "go", 80)
+
+	assert.Contains(t, highlighted, "\033[35mfunc\033[0m")
+	assert.Contains(t, highlighted, "\033[34mmain\033[0m")
+	assert.Contains(t, highlighted, "\033[32m\"hello\"\033[0m")
+	assert.Contains(t, highlighted, "\033[33m42\033[0m")
+	assert.Equal(t, strings.ReplaceAll(strings.TrimSuffix(code, "\n"), "\t", "    "),
+		escapeCodes.ReplaceAllString(highlighted, ""))
+
+	// The comment spans two lines, which are styled on their own
+	lines := strings.Split(highlighted, "\n")
+	assert.True(t, strings.HasSuffix(lines[1], "\033[0m"), lines[1])
+	assert.True(t, strings.HasPrefix(lines[2], "\033["), lines[2])
+}
+
+func TestHighlightCodeWraps(t *testing.T) {
+	setTheme()
+
+	highlighted := syntax.HighlightCode(`x = "`+strings.Repeat("a", 30)+`"`, "python", 12)
+	lines := strings.Split(escapeCodes.ReplaceAllString(highlighted, ""), "\n")
+
+	assert.Greater(t, len(lines), 1)
+
+	for i, line := range lines {
+		assert.LessOrEqual(t, runewidth.StringWidth(line), 12, line)
+
+		if i > 0 {
+			assert.True(t, strings.HasPrefix(line, "↪ "), line)
+		}
+	}
+}
+
+func TestDimCode(t *testing.T) {
+	setTheme()
+
+	assert.Equal(t, "\033[2mif\033[0m\n\033[2m    x\033[0m", syntax.DimCode("\nif\n\tx\n", 80))
+	assert.Equal(t, "\033[2m- a\033[0m\n\033[2m\033[0m\n\033[2m- b\033[0m", syntax.DimCode("- a\n\n- b", 80))
+	assert.Equal(t, "\033[2mlorem ipsum\033[0m", syntax.HighlightCode("lorem ipsum", "", 80))
+}
@@ -2,12 +2,15 @@ package syntax
 
 import (
 	"regexp"
+	"strconv"
 	"strings"
 
 	"clx/constants/nerdfonts"
 
 	"clx/constants/style"
 	"clx/constants/unicode"
+	"clx/theme"
+	"clx/watchlist"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/logrusorgru/aurora/v3"
 )
@@ -18,10 +21,7 @@ const (
 	bold         = "\033[1m"
 	reverse      = "\033[7m"
 	italic       = "\033[3m"
-	magenta      = "\033[35m"
 	faint        = "\033[2m"
-	green        = "\033[32m"
-	red          = "\033[31m"
 
 	Unselected = iota
 	HeadlineInCommentSection
@@ -52,26 +52,26 @@ func HighlightYCStartupsInHeadlines(comment string, highlightType int, enableNer
 func getYCBar(text string, highlightType int, enableNerdFonts bool) string {
 	switch highlightType {
 	case Selected:
-		return label(text, style.GetOrange(), lipgloss.Color("16"), highlightType, enableNerdFonts)
+		return label(text, style.GetOrange(), style.GetLabelFg(), highlightType, enableNerdFonts)
 
 	case MarkAsRead:
-		return label(text, lipgloss.Color("237"), style.GetOrangeFaint(), highlightType, enableNerdFonts)
+		return label(text, style.GetFaintLabelFg(), style.GetOrangeFaint(), highlightType, enableNerdFonts)
 
 	default:
-		return label(text, lipgloss.Color("232"), style.GetOrange(), highlightType, enableNerdFonts)
+		return label(text, style.GetLabelFg(), style.GetOrange(), highlightType, enableNerdFonts)
 	}
 }
 
 func getYCBarNerdFonts(text string, highlightType int, enableNerdFonts bool) string {
 	switch highlightType {
 	case Selected:
-		return label(text, style.GetOrange(), lipgloss.Color("16"), highlightType, enableNerdFonts)
+		return label(text, style.GetOrange(), style.GetLabelFg(), highlightType, enableNerdFonts)
 
 	case MarkAsRead:
-		return label(text, lipgloss.Color("234"), style.GetOrangeFaint(), highlightType, enableNerdFonts)
+		return label(text, style.GetFaintLabelFg(), style.GetOrangeFaint(), highlightType, enableNerdFonts)
 
 	default:
-		return label(text, lipgloss.Color("16"), style.GetOrange(), highlightType, enableNerdFonts)
+		return label(text, style.GetLabelFg(), style.GetOrange(), highlightType, enableNerdFonts)
 	}
 }
 
@@ -85,13 +85,13 @@ func HighlightYear(comment string, highlightType int, enableNerdFonts bool) stri
 func getYear(text string, highlightType int, enableNerdFont bool) string {
 	switch highlightType {
 	case Selected:
-		return label(text, lipgloss.AdaptiveColor{Light: "16", Dark: "16"}, lipgloss.AdaptiveColor{Light: "27", Dark: "214"}, highlightType, enableNerdFont)
+		return label(text, style.GetLabelFg(), style.GetYear(), highlightType, enableNerdFont)
 
 	case MarkAsRead:
-		return label(text, lipgloss.AdaptiveColor{Light: "39", Dark: "94"}, style.GetHeaderBg(), highlightType, enableNerdFont)
+		return label(text, style.GetFaintYear(), style.GetHeaderBg(), highlightType, enableNerdFont)
 
 	default:
-		return label(text, lipgloss.AdaptiveColor{Light: "27", Dark: "214"}, style.GetLogoBg(), highlightType, enableNerdFont)
+		return label(text, style.GetYear(), style.GetLogoBg(), highlightType, enableNerdFont)
 	}
 }
 
@@ -169,12 +169,13 @@ func HighlightHackerNewsHeadlines(title string, highlightType int) string {
 	launchHN := "Launch HN:"
 
 	highlight := getHighlight(highlightType)
+	content := theme.Current().Content
 
-	title = strings.ReplaceAll(title, askHN, aurora.Blue(askHN).String()+highlight)
-	title = strings.ReplaceAll(title, showHN, aurora.Red(showHN).String()+highlight)
-	title = strings.ReplaceAll(title, tellHN, aurora.Magenta(tellHN).String()+highlight)
-	title = strings.ReplaceAll(title, thankHN, aurora.Cyan(thankHN).String()+highlight)
-	title = strings.ReplaceAll(title, launchHN, aurora.Green(launchHN).String()+highlight)
+	title = strings.ReplaceAll(title, askHN, content.Blue.Render(askHN)+highlight)
+	title = strings.ReplaceAll(title, showHN, content.Red.Render(showHN)+highlight)
+	title = strings.ReplaceAll(title, tellHN, content.Magenta.Render(tellHN)+highlight)
+	title = strings.ReplaceAll(title, thankHN, content.Cyan.Render(thankHN)+highlight)
+	title = strings.ReplaceAll(title, launchHN, content.Green.Render(launchHN)+highlight)
 
 	return title
 }
@@ -188,9 +189,9 @@ func getHighlight(highlightType int) string {
 	case MarkAsRead:
 		return faint + italic
 	case AddToFavorites:
-		return green + reverse
+		return theme.Current().Content.Green.Sequence() + reverse
 	case RemoveFromFavorites:
-		return red + reverse
+		return theme.Current().Content.Red.Sequence() + reverse
 	default:
 		return ""
 	}
@@ -208,24 +209,97 @@ func HighlightSpecialContent(title string, highlightType int, enableNerdFonts bo
 		return title
 	}
 
-	title = strings.ReplaceAll(title, "[audio]", aurora.Cyan("audio").String()+highlight)
-	title = strings.ReplaceAll(title, "[video]", aurora.Cyan("video").String()+highlight)
-	title = strings.ReplaceAll(title, "[pdf]", aurora.Cyan("pdf").String()+highlight)
-	title = strings.ReplaceAll(title, "[PDF]", aurora.Cyan("PDF").String()+highlight)
+	cyan := theme.Current().Content.Cyan
+
+	title = strings.ReplaceAll(title, "[audio]", cyan.Render("audio")+highlight)
+	title = strings.ReplaceAll(title, "[video]", cyan.Render("video")+highlight)
+	title = strings.ReplaceAll(title, "[pdf]", cyan.Render("pdf")+highlight)
+	title = strings.ReplaceAll(title, "[PDF]", cyan.Render("PDF")+highlight)
 
 	return title
 }
 
+// HighlightWatchlist styles the matches of the rules in the watchlist.
+func HighlightWatchlist(text string, rules watchlist.Watchlist, highlightType int) string {
+	return rules.Highlight(text, getHighlight(highlightType))
+}
+
+// links matches the links in comments before TrimURLs: the anchor tags and
+// the URLs themselves.
+var links = regexp.MustCompile(`<a\b[^>]*>|https?://[^,"\) \n]+`)
+
+// HighlightWatchlistInComment styles the matches of the rules in the watchlist
+// like HighlightWatchlist, but leaves links alone so that TrimURLs can still
+// shorten and color them.
+func HighlightWatchlistInComment(comment string, rules watchlist.Watchlist, highlightType int) string {
+	if len(rules) == 0 {
+		return comment
+	}
+
+	var sb strings.Builder
+
+	last := 0
+
+	for _, loc := range links.FindAllStringIndex(comment, -1) {
+		sb.WriteString(HighlightWatchlist(comment[last:loc[0]], rules, highlightType))
+		sb.WriteString(comment[loc[0]:loc[1]])
+
+		last = loc[1]
+	}
+
+	sb.WriteString(HighlightWatchlist(comment[last:], rules, highlightType))
+
+	return sb.String()
+}
+
+// WatchlistLabels returns the labels of the rules that match a headline, or a
+// marker in the color of the rule for rules without a label.
+func WatchlistLabels(rules watchlist.Watchlist, highlightType int, enableNerdFonts bool) string {
+	var (
+		labels string
+		seen   = make(map[string]bool)
+	)
+
+	for _, rule := range rules {
+		if seen[rule.Label] {
+			continue
+		}
+
+		seen[rule.Label] = true
+		color := lipgloss.Color(strconv.Itoa(rule.Color))
+
+		if rule.Label == "" {
+			labels += " " + rule.Sequence() + "◆" + reset + getHighlight(highlightType)
+
+			continue
+		}
+
+		if highlightType == MarkAsRead {
+			labels += " " + label(rule.Label, color, style.GetHeaderBg(), highlightType, enableNerdFonts) +
+				getHighlight(highlightType)
+
+			continue
+		}
+
+		labels += " " + label(rule.Label, style.GetLabelFg(), color, highlightType, enableNerdFonts) +
+			getHighlight(highlightType)
+	}
+
+	return labels
+}
+
 func getSpecialContentRoundedBar(text string, highlightType int, enableNerdFonts bool) string {
+	blue := theme.Current().Content.Blue.Lipgloss()
+
 	switch highlightType {
 	case Selected:
-		return label(text, lipgloss.Color("4"), lipgloss.AdaptiveColor{Light: "255", Dark: "16"}, highlightType, enableNerdFonts)
+		return label(text, blue, style.GetLabelFg(), highlightType, enableNerdFonts)
 
 	case MarkAsRead:
 		return label(text, style.GetUnselectedItemFg(), style.GetHeaderBg(), highlightType, enableNerdFonts)
 
 	default:
-		return label(text, lipgloss.AdaptiveColor{Light: "255", Dark: "16"}, lipgloss.Color("4"), highlightType, enableNerdFonts)
+		return label(text, style.GetLabelFg(), blue, highlightType, enableNerdFonts)
 	}
 }
 
@@ -286,64 +360,20 @@ func HighlightDomain(domain string) string {
 }
 
 func HighlightReferences(input string) string {
-	input = strings.ReplaceAll(input, "[0]", "["+aurora.White("0").String()+"]")
-	input = strings.ReplaceAll(input, "[1]", "["+aurora.Red("1").String()+"]")
-	input = strings.ReplaceAll(input, "[2]", "["+aurora.Yellow("2").String()+"]")
-	input = strings.ReplaceAll(input, "[3]", "["+aurora.Green("3").String()+"]")
-	input = strings.ReplaceAll(input, "[4]", "["+aurora.Blue("4").String()+"]")
-	input = strings.ReplaceAll(input, "[5]", "["+aurora.Cyan("5").String()+"]")
-	input = strings.ReplaceAll(input, "[6]", "["+aurora.Magenta("6").String()+"]")
-	input = strings.ReplaceAll(input, "[7]", "["+aurora.BrightWhite("7").String()+"]")
-	input = strings.ReplaceAll(input, "[8]", "["+aurora.BrightRed("8").String()+"]")
-	input = strings.ReplaceAll(input, "[9]", "["+aurora.BrightYellow("9").String()+"]")
-	input = strings.ReplaceAll(input, "[10]", "["+aurora.BrightGreen("10").String()+"]")
+	for i, color := range theme.Current().References {
+		reference := strconv.Itoa(i)
+		input = strings.ReplaceAll(input, "["+reference+"]", "["+color.Render(reference)+"]")
+	}
 
 	return input
 }
 
 func ColorizeIndentSymbol(indentSymbol string, level int) string {
-	switch level {
-	case 0:
-		indentSymbol = ""
-	case 1:
-		indentSymbol = aurora.Red(indentSymbol).String()
-	case 2:
-		indentSymbol = aurora.Yellow(indentSymbol).String()
-	case 3:
-		indentSymbol = aurora.Green(indentSymbol).String()
-	case 4:
-		indentSymbol = aurora.Cyan(indentSymbol).String()
-	case 5:
-		indentSymbol = aurora.Blue(indentSymbol).String()
-	case 6:
-		indentSymbol = aurora.Magenta(indentSymbol).String()
-	case 7:
-		indentSymbol = aurora.BrightRed(indentSymbol).String()
-	case 8:
-		indentSymbol = aurora.BrightYellow(indentSymbol).String()
-	case 9:
-		indentSymbol = aurora.BrightGreen(indentSymbol).String()
-	case 10:
-		indentSymbol = aurora.BrightCyan(indentSymbol).String()
-	case 11:
-		indentSymbol = aurora.BrightBlue(indentSymbol).String()
-	case 12:
-		indentSymbol = aurora.BrightMagenta(indentSymbol).String()
-	case 13:
-		indentSymbol = aurora.Red(indentSymbol).String()
-	case 14:
-		indentSymbol = aurora.Yellow(indentSymbol).String()
-	case 15:
-		indentSymbol = aurora.Green(indentSymbol).String()
-	case 16:
-		indentSymbol = aurora.Cyan(indentSymbol).String()
-	case 17:
-		indentSymbol = aurora.Blue(indentSymbol).String()
-	case 18:
-		indentSymbol = aurora.Magenta(indentSymbol).String()
+	if level == 0 {
+		return reset
 	}
 
-	return reset + indentSymbol
+	return reset + theme.Current().RainbowColor(level).Render(indentSymbol)
 }
 
 func TrimURLs(comment string, disableCommentHighlighting bool) string {
@@ -356,7 +386,7 @@ func TrimURLs(comment string, disableCommentHighlighting bool) string {
 	comment = expression.ReplaceAllString(comment, "")
 
 	e := regexp.MustCompile(`https?://([^,"\) \n]+)`)
-	comment = e.ReplaceAllString(comment, aurora.Blue(`$1`).String())
+	comment = e.ReplaceAllString(comment, theme.Current().Content.Blue.Render(`$1`))
 
 	comment = strings.ReplaceAll(comment, "."+reset+" ", reset+". ")
 
@@ -376,7 +406,7 @@ func HighlightBackticks(input string) string {
 
 	for i := 0; i < numberOfBackticks+1; i++ {
 		if isOnFirstBacktick {
-			input = strings.Replace(input, backtick, italic+magenta, 1)
+			input = strings.Replace(input, backtick, italic+theme.Current().Content.Magenta.Sequence(), 1)
 		} else {
 			input = strings.Replace(input, backtick, reset, 1)
 		}
@@ -388,13 +418,13 @@ func HighlightBackticks(input string) string {
 }
 
 func HighlightMentions(input string) string {
+	content := theme.Current().Content
+
 	exp := regexp.MustCompile(`((?:^| )\B@[\w.]+)`)
-	input = exp.ReplaceAllString(input, aurora.Yellow(`$1`).String())
+	input = exp.ReplaceAllString(input, content.Yellow.Render(`$1`))
 
-	input = strings.ReplaceAll(input, aurora.Yellow("@dang").String(),
-		aurora.Green("@dang").String())
-	input = strings.ReplaceAll(input, aurora.Yellow(" @dang").String(),
-		aurora.Green(" @dang").String())
+	input = strings.ReplaceAll(input, content.Yellow.Render("@dang"), content.Green.Render("@dang"))
+	input = strings.ReplaceAll(input, content.Yellow.Render(" @dang"), content.Green.Render(" @dang"))
 
 	return input
 }
@@ -410,15 +440,16 @@ func HighlightVariables(input string) string {
 
 	exp := regexp.MustCompile(`(\$+[a-zA-Z_\-]+)`)
 
-	return exp.ReplaceAllString(input, aurora.Cyan(`$1`).String())
+	return exp.ReplaceAllString(input, theme.Current().Content.Cyan.Render(`$1`))
 }
 
 func HighlightAbbreviations(input string) string {
 	iAmNotALawyer := "IANAL"
 	iAmALawyer := "IAAL"
+	content := theme.Current().Content
 
-	input = strings.ReplaceAll(input, iAmNotALawyer, aurora.Red(iAmNotALawyer).String())
-	input = strings.ReplaceAll(input, iAmALawyer, aurora.Green(iAmALawyer).String())
+	input = strings.ReplaceAll(input, iAmNotALawyer, content.Red.Render(iAmNotALawyer))
+	input = strings.ReplaceAll(input, iAmALawyer, content.Green.Render(iAmALawyer))
 
 	return input
 }
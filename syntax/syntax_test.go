@@ -0,0 +1,30 @@
+package syntax_test
+
+import (
+	"testing"
+
+	"clx/syntax"
+	"clx/theme"
+	"clx/watchlist"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHighlightWatchlistInComment(t *testing.T) {
+	setTheme()
+
+	rules, err := watchlist.Parse("keyword red: rust\nkeyword green: nofollow")
+	require.NoError(t, err)
+
+	// Comments are passed through ReplaceHTML first, which removes </a>
+	comment := `See <a href="https://www.rust-lang.org/learn" rel="nofollow">https://www.rust-lang.org/learn for rust`
+	highlighted := syntax.HighlightWatchlistInComment(comment, rules, syntax.Unselected)
+
+	// Matches in links are left alone, so that the link is shortened and
+	// colored in one piece
+	assert.Equal(t, "See "+theme.Current().Content.Blue.Render("www.rust-lang.org/learn")+" for "+
+		rules[0].Sequence()+"rust\033[0m", syntax.TrimURLs(highlighted, false))
+
+	assert.Equal(t, comment, syntax.HighlightWatchlistInComment(comment, nil, syntax.Unselected))
+}
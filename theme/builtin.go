@@ -0,0 +1,226 @@
+package theme
+
+import (
+	"sort"
+
+	"github.com/muesli/termenv"
+)
+
+// builtins return a new copy of each built-in theme, so that themes based on
+// them can change their colors.
+var builtins = map[string]func() *Theme{
+	Default:      newDefault,
+	Dark:         func() *Theme { return only(newDefault(), Dark) },
+	Light:        func() *Theme { return only(newDefault(), Light) },
+	HighContrast: newHighContrast,
+	Solarized:    newSolarized,
+	Monochrome:   func() *Theme { return &Theme{Name: Monochrome} },
+}
+
+// Builtins returns the names of the built-in themes in alphabetical order.
+func Builtins() []string {
+	names := make([]string, 0, len(builtins))
+
+	for name := range builtins {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// terminalPalette uses the terminal's own colors for content, like
+// circumflex always has.
+func terminalPalette() Palette {
+	return Palette{
+		Red:     Same("1"),
+		Green:   Same("2"),
+		Yellow:  Same("3"),
+		Blue:    Same("4"),
+		Magenta: Same("5"),
+		Cyan:    Same("6"),
+		White:   Same("7"),
+	}
+}
+
+func terminalRainbow() []Color {
+	return colors("1", "3", "2", "6", "4", "5", "9", "11", "10", "14", "12", "13")
+}
+
+func terminalReferences() []Color {
+	return colors("7", "1", "3", "2", "4", "6", "5", "15", "9", "11", "10")
+}
+
+func colors(values ...string) []Color {
+	c := make([]Color, 0, len(values))
+
+	for _, value := range values {
+		c = append(c, Same(value))
+	}
+
+	return c
+}
+
+// newDefault adapts to the background of the terminal.
+func newDefault() *Theme {
+	logoBackground := Color{Light: "252", Dark: "#0f1429"}
+	headerBackground := Color{Light: "254", Dark: "#2d3454"}
+	text := Color{Light: "235", Dark: "251"}
+
+	// The header background is too bright when it is degraded to 256 colors
+	if termenv.ColorProfile() != termenv.TrueColor {
+		headerBackground.Dark = "237"
+	}
+
+	return &Theme{
+		Name:                Default,
+		Magenta:             Same("200"),
+		Yellow:              Color{Light: "208", Dark: "214"},
+		Blue:                Same("33"),
+		Pink:                Same("219"),
+		Green:               Color{Light: "28", Dark: "42"},
+		Cyan:                Color{Light: "31", Dark: "45"},
+		Red:                 Color{Light: "160", Dark: "203"},
+		Orange:              Same("214"),
+		FaintOrange:         Same("94"),
+		Text:                text,
+		FaintText:           Color{Light: "247", Dark: "239"},
+		LabelText:           Same("16"),
+		FaintLabelText:      Same("237"),
+		LogoBackground:      logoBackground,
+		HeaderBackground:    headerBackground,
+		PaginatorBackground: logoBackground,
+		Year:                Color{Light: "27", Dark: "214"},
+		FaintYear:           Color{Light: "39", Dark: "94"},
+		Content:             terminalPalette(),
+		Rainbow:             terminalRainbow(),
+		References:          terminalReferences(),
+	}
+}
+
+func newHighContrast() *Theme {
+	text := Color{Light: "0", Dark: "15"}
+	background := Color{Light: "15", Dark: "0"}
+
+	return &Theme{
+		Name:                HighContrast,
+		Magenta:             Color{Light: "5", Dark: "13"},
+		Yellow:              Color{Light: "3", Dark: "11"},
+		Blue:                Color{Light: "4", Dark: "12"},
+		Pink:                Color{Light: "5", Dark: "13"},
+		Green:               Color{Light: "2", Dark: "10"},
+		Cyan:                Color{Light: "6", Dark: "14"},
+		Red:                 Color{Light: "1", Dark: "9"},
+		Orange:              Color{Light: "3", Dark: "11"},
+		FaintOrange:         Color{Light: "3", Dark: "11"},
+		Text:                text,
+		FaintText:           text,
+		LabelText:           background,
+		FaintLabelText:      background,
+		LogoBackground:      background,
+		HeaderBackground:    background,
+		PaginatorBackground: background,
+		Year:                Color{Light: "4", Dark: "11"},
+		FaintYear:           Color{Light: "4", Dark: "11"},
+		Content: Palette{
+			Red:     Color{Light: "1", Dark: "9"},
+			Green:   Color{Light: "2", Dark: "10"},
+			Yellow:  Color{Light: "3", Dark: "11"},
+			Blue:    Color{Light: "4", Dark: "12"},
+			Magenta: Color{Light: "5", Dark: "13"},
+			Cyan:    Color{Light: "6", Dark: "14"},
+			White:   text,
+		},
+		Rainbow:    colors("9", "11", "10", "14", "12", "13"),
+		References: colors("15", "9", "11", "10", "12", "14", "13"),
+	}
+}
+
+// newSolarized uses the colors of Solarized by Ethan Schoonover.
+func newSolarized() *Theme {
+	const (
+		base03  = "#002b36"
+		base02  = "#073642"
+		base01  = "#586e75"
+		base00  = "#657b83"
+		base0   = "#839496"
+		base1   = "#93a1a1"
+		base2   = "#eee8d5"
+		base3   = "#fdf6e3"
+		yellow  = "#b58900"
+		orange  = "#cb4b16"
+		red     = "#dc322f"
+		magenta = "#d33682"
+		violet  = "#6c71c4"
+		blue    = "#268bd2"
+		cyan    = "#2aa198"
+		green   = "#859900"
+	)
+
+	content := Palette{
+		Red:     Same(red),
+		Green:   Same(green),
+		Yellow:  Same(yellow),
+		Blue:    Same(blue),
+		Magenta: Same(magenta),
+		Cyan:    Same(cyan),
+		White:   Color{Light: base02, Dark: base2},
+	}
+
+	return &Theme{
+		Name:                Solarized,
+		Magenta:             Same(magenta),
+		Yellow:              Same(yellow),
+		Blue:                Same(blue),
+		Pink:                Same(violet),
+		Green:               Same(green),
+		Cyan:                Same(cyan),
+		Red:                 Same(red),
+		Orange:              Same(orange),
+		FaintOrange:         Color{Light: base2, Dark: base02},
+		Text:                Color{Light: base00, Dark: base0},
+		FaintText:           Color{Light: base1, Dark: base01},
+		LabelText:           Color{Light: base3, Dark: base03},
+		FaintLabelText:      Color{Light: base1, Dark: base01},
+		LogoBackground:      Color{Light: base2, Dark: base03},
+		HeaderBackground:    Color{Light: base2, Dark: base02},
+		PaginatorBackground: Color{Light: base2, Dark: base03},
+		Year:                Same(orange),
+		FaintYear:           Color{Light: base1, Dark: base01},
+		Content:             content,
+		Rainbow:             colors(red, yellow, green, cyan, blue, magenta, orange, violet),
+		References: []Color{
+			content.White, Same(red), Same(yellow), Same(green), Same(blue), Same(cyan), Same(magenta),
+			Same(orange), Same(violet),
+		},
+	}
+}
+
+// only returns t with the colors for the given background on both
+// backgrounds.
+func only(t *Theme, background string) *Theme {
+	pick := func(c *Color) {
+		if background == Dark {
+			c.Light = c.Dark
+		} else {
+			c.Dark = c.Light
+		}
+	}
+
+	for _, c := range t.colors() {
+		pick(c)
+	}
+
+	for i := range t.Rainbow {
+		pick(&t.Rainbow[i])
+	}
+
+	for i := range t.References {
+		pick(&t.References[i])
+	}
+
+	t.Name = background
+
+	return t
+}
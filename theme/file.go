@@ -0,0 +1,239 @@
+package theme
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	keyBase       = "base"
+	keyRainbow    = "rainbow"
+	keyReferences = "references"
+)
+
+var hexColor = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+type field struct {
+	key   string
+	color *Color
+}
+
+// fields returns the colors of t by their key in theme files.
+func (t *Theme) fields() []field {
+	return []field{
+		{"magenta", &t.Magenta},
+		{"yellow", &t.Yellow},
+		{"blue", &t.Blue},
+		{"pink", &t.Pink},
+		{"green", &t.Green},
+		{"cyan", &t.Cyan},
+		{"red", &t.Red},
+		{"orange", &t.Orange},
+		{"faint-orange", &t.FaintOrange},
+		{"text", &t.Text},
+		{"faint-text", &t.FaintText},
+		{"label-text", &t.LabelText},
+		{"faint-label-text", &t.FaintLabelText},
+		{"logo-background", &t.LogoBackground},
+		{"header-background", &t.HeaderBackground},
+		{"paginator-background", &t.PaginatorBackground},
+		{"year", &t.Year},
+		{"faint-year", &t.FaintYear},
+		{"content-red", &t.Content.Red},
+		{"content-green", &t.Content.Green},
+		{"content-yellow", &t.Content.Yellow},
+		{"content-blue", &t.Content.Blue},
+		{"content-magenta", &t.Content.Magenta},
+		{"content-cyan", &t.Content.Cyan},
+		{"content-white", &t.Content.White},
+	}
+}
+
+func (t *Theme) colors() []*Color {
+	fields := t.fields()
+	c := make([]*Color, 0, len(fields))
+
+	for _, f := range fields {
+		c = append(c, f.color)
+	}
+
+	return c
+}
+
+// Load returns the theme with the given name from dir, or the built-in theme
+// with that name if dir has no such theme.
+func Load(name string, dir string) (*Theme, error) {
+	path := filepath.Join(dir, name+fileExtension)
+
+	content, err := os.ReadFile(path)
+	if err == nil {
+		t, parseErr := Parse(name, string(content))
+		if parseErr != nil {
+			return nil, fmt.Errorf("%s: %w", path, parseErr)
+		}
+
+		return t, nil
+	}
+
+	if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("could not read theme: %w", err)
+	}
+
+	newTheme, ok := builtins[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown theme %q, expected one of %s or a file in %s", name,
+			strings.Join(Builtins(), ", "), dir)
+	}
+
+	return newTheme(), nil
+}
+
+// Available returns the names of the built-in themes followed by the themes
+// in dir.
+func Available(dir string) []string {
+	names := Builtins()
+
+	paths, _ := filepath.Glob(filepath.Join(dir, "*"+fileExtension))
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		name := strings.TrimSuffix(filepath.Base(path), fileExtension)
+		if _, isBuiltin := builtins[name]; !isBuiltin {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+// Parse reads a theme file. The colors that it does not set are taken from
+// its base theme, or from the default theme if it has none.
+func Parse(name string, content string) (*Theme, error) {
+	type line struct {
+		number int
+		key    string
+		value  string
+	}
+
+	var (
+		lines   []line
+		base    = Default
+		scanner = bufio.NewScanner(strings.NewReader(content))
+	)
+
+	for number := 1; scanner.Scan(); number++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		key, value, found := strings.Cut(text, "=")
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+
+		if !found || value == "" {
+			return nil, fmt.Errorf("line %d: expected key = color", number)
+		}
+
+		if key == keyBase {
+			base = value
+
+			continue
+		}
+
+		lines = append(lines, line{number: number, key: key, value: value})
+	}
+
+	newTheme, ok := builtins[base]
+	if !ok {
+		return nil, fmt.Errorf("unknown base theme %q, expected one of %s", base, strings.Join(Builtins(), ", "))
+	}
+
+	t := newTheme()
+	t.Name = name
+
+	for _, l := range lines {
+		if err := t.set(l.key, l.value); err != nil {
+			return nil, fmt.Errorf("line %d: %w", l.number, err)
+		}
+	}
+
+	return t, nil
+}
+
+func (t *Theme) set(key string, value string) error {
+	switch key {
+	case keyRainbow, keyReferences:
+		var list []Color
+
+		for _, v := range strings.FieldsFunc(value, func(r rune) bool { return r == ' ' || r == ',' }) {
+			c, err := parseColor(v)
+			if err != nil {
+				return err
+			}
+
+			list = append(list, c)
+		}
+
+		if key == keyRainbow {
+			t.Rainbow = list
+		} else {
+			t.References = list
+		}
+
+		return nil
+	}
+
+	for _, f := range t.fields() {
+		if f.key == key {
+			c, err := parseColor(value)
+			if err != nil {
+				return err
+			}
+
+			*f.color = c
+
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unknown color %q", key)
+}
+
+// parseColor reads a color, or a light and a dark color separated by a
+// slash.
+func parseColor(value string) (Color, error) {
+	if value == "none" {
+		return Color{}, nil
+	}
+
+	light, dark, found := strings.Cut(value, "/")
+	if !found {
+		dark = light
+	}
+
+	for _, v := range []string{light, dark} {
+		if !isColor(v) {
+			return Color{}, fmt.Errorf("invalid color %q, expected a number from 0 to 255, a hex color "+
+				"such as #2d3454 or none", v)
+		}
+	}
+
+	return Color{Light: light, Dark: dark}, nil
+}
+
+func isColor(value string) bool {
+	if hexColor.MatchString(value) {
+		return true
+	}
+
+	n, err := strconv.Atoi(value)
+
+	return err == nil && n >= 0 && n <= 255
+}
@@ -0,0 +1,216 @@
+// Package theme holds the colors that the header, the list, comment sections
+// and Reader Mode are drawn with.
+//
+// Besides the built-in themes, themes are read from files in
+// ~/.config/circumflex/themes with one color per line:
+//
+//	# Start from a built-in theme and change some of its colors
+//	base = solarized
+//	magenta = 200
+//	header-background = 254/#2d3454
+//	content-red = none
+//	rainbow = 1 3 2 6 4 5
+//
+// A color is an ANSI color from 0 to 15, a 256-color number or a hex color.
+// Two colors separated by a slash are used on light and dark backgrounds, and
+// none leaves the terminal's own color. Empty lines and lines starting with
+// '#' are ignored.
+package theme
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+const (
+	Default       = "default"
+	Dark          = "dark"
+	Light         = "light"
+	HighContrast  = "high-contrast"
+	Solarized     = "solarized"
+	Monochrome    = "monochrome"
+	fileExtension = ".theme"
+
+	reset = "\033[0m"
+)
+
+// Attributes that can be combined with a color in Render and Sequence.
+const (
+	Bold      = "1"
+	Faint     = "2"
+	Italic    = "3"
+	Underline = "4"
+	Reverse   = "7"
+)
+
+// Color is a color for light and dark backgrounds. The zero value leaves the
+// terminal's own color.
+type Color struct {
+	Light string
+	Dark  string
+}
+
+// Same returns a color that does not depend on the background.
+func Same(value string) Color {
+	return Color{Light: value, Dark: value}
+}
+
+// IsNone reports whether the color leaves the terminal's own color.
+func (c Color) IsNone() bool {
+	return c.Light == "" && c.Dark == ""
+}
+
+// Lipgloss returns the color for lipgloss styles.
+func (c Color) Lipgloss() lipgloss.TerminalColor {
+	if c.IsNone() {
+		return lipgloss.NoColor{}
+	}
+
+	return lipgloss.AdaptiveColor{Light: c.Light, Dark: c.Dark}
+}
+
+// Sequence returns the escape sequence that sets the attributes and then the
+// color as the foreground, or an empty string if there is nothing to set.
+func (c Color) Sequence(attributes ...string) string {
+	codes := append([]string{}, attributes...)
+
+	if code := c.code(); code != "" {
+		codes = append(codes, code)
+	}
+
+	if len(codes) == 0 {
+		return ""
+	}
+
+	return "\033[" + strings.Join(codes, ";") + "m"
+}
+
+// Render returns s in the color with the given attributes, followed by a
+// reset.
+func (c Color) Render(s string, attributes ...string) string {
+	sequence := c.Sequence(attributes...)
+	if sequence == "" {
+		return s
+	}
+
+	return sequence + s + reset
+}
+
+func (c Color) code() string {
+	value := c.Dark
+	if !lipgloss.HasDarkBackground() {
+		value = c.Light
+	}
+
+	if value == "" {
+		return ""
+	}
+
+	// Colors are degraded like in lipgloss styles, but still written when
+	// the output is not a terminal, such as when it is piped to less
+	profile := lipgloss.ColorProfile()
+	if profile == termenv.Ascii {
+		profile = termenv.TrueColor
+	}
+
+	color := profile.Color(value)
+	if color == nil {
+		return ""
+	}
+
+	return color.Sequence(false)
+}
+
+// Palette holds the colors of text in headlines, comments and Reader Mode.
+type Palette struct {
+	Red     Color
+	Green   Color
+	Yellow  Color
+	Blue    Color
+	Magenta Color
+	Cyan    Color
+	White   Color
+}
+
+type Theme struct {
+	Name string
+
+	// Accent colors of the header, the list, labels and the status bar
+	Magenta Color
+	Yellow  Color
+	Blue    Color
+	Pink    Color
+	Green   Color
+	Cyan    Color
+	Red     Color
+	Orange  Color
+
+	// FaintOrange is the background of YC labels of stories that have been
+	// read
+	FaintOrange Color
+
+	// Text is used for unselected categories and the status bar, FaintText
+	// for other pages and empty states, and LabelText on labels with an
+	// accent color as background
+	Text           Color
+	FaintText      Color
+	LabelText      Color
+	FaintLabelText Color
+
+	LogoBackground      Color
+	HeaderBackground    Color
+	PaginatorBackground Color
+
+	// Year and FaintYear are the colors of years in headlines
+	Year      Color
+	FaintYear Color
+
+	// Content colors headlines, comments and Reader Mode
+	Content Palette
+
+	// Rainbow colors the indentation of replies by depth, repeating from the
+	// start for deeper replies
+	Rainbow []Color
+
+	// References colors the references [0] to [n] in comments
+	References []Color
+}
+
+// RainbowColor returns the color of replies at the given depth, starting at 1.
+func (t *Theme) RainbowColor(level int) Color {
+	if len(t.Rainbow) == 0 || level < 1 {
+		return Color{}
+	}
+
+	return t.Rainbow[(level-1)%len(t.Rainbow)]
+}
+
+var (
+	mu      sync.RWMutex
+	current = builtins[Default]()
+)
+
+// Current returns the theme that is used for drawing.
+func Current() *Theme {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	return current
+}
+
+// Set makes t the theme that is used for drawing.
+func Set(t *Theme) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	current = t
+}
+
+// NoColor reports whether colors are turned off with NO_COLOR, in which case
+// the monochrome theme is used.
+func NoColor() bool {
+	return termenv.EnvNoColor()
+}
@@ -0,0 +1,115 @@
+package theme_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"clx/theme"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	content := `# Solarized with a different header
+base = solarized
+header-background = 254/#2d3454
+content-red = none
+rainbow = 1 3, 2
+`
+
+	parsed, err := theme.Parse("custom", content)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "custom", parsed.Name)
+	assert.Equal(t, theme.Color{Light: "254", Dark: "#2d3454"}, parsed.HeaderBackground)
+	assert.True(t, parsed.Content.Red.IsNone())
+	assert.Equal(t, []theme.Color{theme.Same("1"), theme.Same("3"), theme.Same("2")}, parsed.Rainbow)
+
+	solarized, _ := theme.Load(theme.Solarized, t.TempDir())
+	assert.Equal(t, solarized.Magenta, parsed.Magenta)
+}
+
+func TestParseDefaultsToDefaultTheme(t *testing.T) {
+	t.Parallel()
+
+	parsed, err := theme.Parse("custom", "magenta = 5")
+
+	assert.NoError(t, err)
+	assert.Equal(t, theme.Same("5"), parsed.Magenta)
+	assert.Equal(t, theme.Same("214"), parsed.Orange)
+}
+
+func TestParseErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		content string
+		err     string
+	}{
+		{content: "magenta", err: "line 1: expected key = color"},
+		{content: "\n# comment\nmagenta = 256", err: "line 3: invalid color \"256\""},
+		{content: "magenta = #12345", err: "line 1: invalid color \"#12345\""},
+		{content: "purple = 5", err: "line 1: unknown color \"purple\""},
+		{content: "rainbow = 1 red", err: "line 1: invalid color \"red\""},
+		{content: "base = nord", err: "unknown base theme \"nord\""},
+	}
+
+	for _, test := range tests {
+		_, err := theme.Parse("custom", test.content)
+
+		assert.Error(t, err, test.content)
+		assert.Contains(t, err.Error(), test.err, test.content)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "solarized.theme"), []byte("magenta = 5"), 0o600))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "nord.theme"), []byte("base = dark"), 0o600))
+
+	userTheme, err := theme.Load(theme.Solarized, dir)
+	assert.NoError(t, err)
+	assert.Equal(t, theme.Same("5"), userTheme.Magenta)
+
+	builtin, err := theme.Load(theme.HighContrast, dir)
+	assert.NoError(t, err)
+	assert.Equal(t, theme.HighContrast, builtin.Name)
+
+	dark, err := theme.Load(theme.Dark, dir)
+	assert.NoError(t, err)
+	assert.Equal(t, theme.Same("#0f1429"), dark.LogoBackground)
+
+	_, err = theme.Load("gruvbox", dir)
+	assert.Error(t, err)
+
+	assert.Equal(t, append(theme.Builtins(), "nord"), theme.Available(dir))
+}
+
+func TestRender(t *testing.T) {
+	lipgloss.SetColorProfile(termenv.ANSI)
+
+	assert.Equal(t, "\033[32mtext\033[0m", theme.Same("2").Render("text"))
+	assert.Equal(t, "\033[2;36mtext\033[0m", theme.Same("6").Render("text", theme.Faint))
+	assert.Equal(t, "\033[4mtext\033[0m", theme.Color{}.Render("text", theme.Underline))
+	assert.Equal(t, "text", theme.Color{}.Render("text"))
+}
+
+func TestRainbowColor(t *testing.T) {
+	t.Parallel()
+
+	th := &theme.Theme{Rainbow: []theme.Color{theme.Same("1"), theme.Same("2")}}
+
+	assert.Equal(t, theme.Same("1"), th.RainbowColor(1))
+	assert.Equal(t, theme.Same("2"), th.RainbowColor(2))
+	assert.Equal(t, theme.Same("1"), th.RainbowColor(3))
+	assert.True(t, th.RainbowColor(0).IsNone())
+	assert.True(t, new(theme.Theme).RainbowColor(1).IsNone())
+}
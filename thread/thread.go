@@ -0,0 +1,175 @@
+// Package thread sorts and filters the comments of a story. The functions
+// return new trees and leave the items they are given unchanged.
+package thread
+
+import (
+	"html"
+	"regexp"
+	"sort"
+	"strings"
+
+	"clx/item"
+)
+
+const (
+	OrderOriginal = "original"
+	OrderNewest   = "newest"
+	OrderReplies  = "replies"
+	OrderNew      = "new"
+)
+
+// Orders holds the sort orders in the order they are cycled through.
+var Orders = []string{OrderOriginal, OrderNewest, OrderReplies, OrderNew}
+
+var tagPattern = regexp.MustCompile(`<[^>]*>`)
+
+// Sort returns the comments with the replies on every level in the given
+// order. Comments that are equal keep their original order.
+//
+//   - original: the order from Hacker News
+//   - newest: newest first
+//   - replies: the comments with the most replies, at any depth, first
+//   - new: the comments that were posted after lastVisited, or that have such
+//     replies, first
+func Sort(comments []*item.Item, order string, lastVisited int64) []*item.Item {
+	if order == OrderOriginal || order == "" {
+		return comments
+	}
+
+	var key func(c *item.Item) int64
+
+	switch order {
+	case OrderNewest:
+		key = func(c *item.Item) int64 { return c.Time }
+
+	case OrderReplies:
+		key = func(c *item.Item) int64 { return int64(CountReplies(c)) }
+
+	case OrderNew:
+		key = func(c *item.Item) int64 {
+			if hasNew(c, lastVisited) {
+				return 1
+			}
+
+			return 0
+		}
+
+	default:
+		return comments
+	}
+
+	sorted := make([]*item.Item, len(comments))
+	keys := make(map[*item.Item]int64, len(comments))
+
+	for i, c := range comments {
+		copied := *c
+		copied.Comments = Sort(c.Comments, order, lastVisited)
+		sorted[i] = &copied
+		keys[&copied] = key(c)
+	}
+
+	// Higher keys come first
+	sort.SliceStable(sorted, func(i, j int) bool { return keys[sorted[i]] > keys[sorted[j]] })
+
+	return sorted
+}
+
+// IsOrder reports whether order is one of Orders.
+func IsOrder(order string) bool {
+	for _, o := range Orders {
+		if o == order {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NextOrder returns the order after order in Orders.
+func NextOrder(order string) string {
+	for i, o := range Orders {
+		if o == order {
+			return Orders[(i+1)%len(Orders)]
+		}
+	}
+
+	return OrderOriginal
+}
+
+// Filter selects comments by author and text. Comments that match are shown
+// together with their parents, so that they can be read in context. Empty
+// fields match every comment.
+type Filter struct {
+	Author string
+	Text   string
+}
+
+func (f Filter) IsEmpty() bool {
+	return f.Author == "" && f.Text == ""
+}
+
+// Matches reports whether c is by Author and contains Text.
+func (f Filter) Matches(c *item.Item) bool {
+	if f.Author != "" && !strings.EqualFold(c.User, f.Author) {
+		return false
+	}
+
+	return f.Text == "" || Contains(c, f.Text)
+}
+
+// Apply returns the comments that match together with their parents.
+func (f Filter) Apply(comments []*item.Item) []*item.Item {
+	if f.IsEmpty() {
+		return comments
+	}
+
+	var filtered []*item.Item
+
+	for _, c := range comments {
+		replies := f.Apply(c.Comments)
+
+		if len(replies) == 0 && !f.Matches(c) {
+			continue
+		}
+
+		copied := *c
+		copied.Comments = replies
+		filtered = append(filtered, &copied)
+	}
+
+	return filtered
+}
+
+// Contains reports whether the author or the text of c contains term,
+// ignoring case and formatting.
+func Contains(c *item.Item, term string) bool {
+	content := strings.ReplaceAll(c.Content, "<p>", " ")
+	content = html.UnescapeString(tagPattern.ReplaceAllString(content, ""))
+
+	return strings.Contains(strings.ToLower(c.User+" "+content), strings.ToLower(term))
+}
+
+// CountReplies returns the number of replies to c at any depth.
+func CountReplies(c *item.Item) int {
+	count := len(c.Comments)
+
+	for _, reply := range c.Comments {
+		count += CountReplies(reply)
+	}
+
+	return count
+}
+
+func hasNew(c *item.Item, lastVisited int64) bool {
+	if c.Time > lastVisited {
+		return true
+	}
+
+	for _, reply := range c.Comments {
+		if hasNew(reply, lastVisited) {
+			return true
+		}
+	}
+
+	return false
+}
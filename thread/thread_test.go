@@ -0,0 +1,87 @@
+package thread_test
+
+import (
+	"testing"
+
+	"clx/item"
+	"clx/thread"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newComments returns these comments, where the number is the time they
+// were posted at:
+//
+//	1 alfa
+//	  4 op
+//	5 beta
+//	  2 gamma
+//	    3 alfa
+//	6 op
+func newComments() []*item.Item {
+	return []*item.Item{
+		{ID: 1, User: "alfa", Time: 1, Content: "<p>Lorem ipsum", Comments: []*item.Item{
+			{ID: 4, User: "op", Time: 4, Content: "<p>Dolor <i>sit</i> amet"},
+		}},
+		{ID: 5, User: "beta", Time: 5, Content: "<p>Consectetur", Comments: []*item.Item{
+			{ID: 2, User: "gamma", Time: 2, Content: "<p>Adipiscing &amp; elit", Comments: []*item.Item{
+				{ID: 3, User: "alfa", Time: 3, Content: "<p>Sed do eiusmod"},
+			}},
+		}},
+		{ID: 6, User: "op", Time: 6, Content: "<p>Tempor"},
+	}
+}
+
+// ids returns the IDs of the comments in the order they are shown.
+func ids(comments []*item.Item) []int {
+	var result []int
+
+	for _, c := range comments {
+		result = append(result, c.ID)
+		result = append(result, ids(c.Comments)...)
+	}
+
+	return result
+}
+
+func TestSort(t *testing.T) {
+	t.Parallel()
+
+	comments := newComments()
+
+	assert.Equal(t, []int{1, 4, 5, 2, 3, 6}, ids(thread.Sort(comments, thread.OrderOriginal, 0)))
+	assert.Equal(t, []int{6, 5, 2, 3, 1, 4}, ids(thread.Sort(comments, thread.OrderNewest, 0)))
+	assert.Equal(t, []int{5, 2, 3, 1, 4, 6}, ids(thread.Sort(comments, thread.OrderReplies, 0)))
+
+	// Threads with comments that are newer than 3 come first
+	assert.Equal(t, []int{1, 4, 5, 2, 3, 6}, ids(thread.Sort(comments, thread.OrderNew, 3)))
+	assert.Equal(t, []int{6, 1, 4, 5, 2, 3}, ids(thread.Sort(comments, thread.OrderNew, 5)))
+
+	// The comments that were passed in are left as they were
+	assert.Equal(t, []int{1, 4, 5, 2, 3, 6}, ids(comments))
+}
+
+func TestNextOrder(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, thread.OrderNewest, thread.NextOrder(thread.OrderOriginal))
+	assert.Equal(t, thread.OrderOriginal, thread.NextOrder(thread.OrderNew))
+	assert.True(t, thread.IsOrder("replies"))
+	assert.False(t, thread.IsOrder("oldest"))
+}
+
+func TestFilter(t *testing.T) {
+	t.Parallel()
+
+	comments := newComments()
+
+	assert.Equal(t, []int{1, 4, 6}, ids(thread.Filter{Author: "op"}.Apply(comments)))
+	assert.Equal(t, []int{1, 5, 2, 3}, ids(thread.Filter{Author: "ALFA"}.Apply(comments)))
+	assert.Equal(t, []int{5, 2}, ids(thread.Filter{Text: "adipiscing & ELIT"}.Apply(comments)))
+	assert.Equal(t, []int{1, 4}, ids(thread.Filter{Author: "op", Text: "dolor sit"}.Apply(comments)))
+	assert.Empty(t, thread.Filter{Author: "delta"}.Apply(comments))
+	assert.Equal(t, ids(comments), ids(thread.Filter{}.Apply(comments)))
+
+	// The comments that were passed in are left as they were
+	assert.Equal(t, []int{1, 4, 5, 2, 3, 6}, ids(comments))
+}
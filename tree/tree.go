@@ -18,6 +18,7 @@ import (
 	"clx/meta"
 	"clx/settings"
 	"clx/syntax"
+	"clx/theme"
 	"clx/tree/postprocessor"
 
 	. "github.com/logrusorgru/aurora/v3"
@@ -35,12 +36,13 @@ func Print(comments *item.Item, config *settings.Config, screenWidth int, lastVi
 
 	header := getHeader(comments, config, lastVisited)
 	firstCommentID := getFirstCommentID(comments.Comments)
+	firstNewCommentID := getFirstNewCommentID(comments.Comments, lastVisited)
 
 	replies := ""
 
 	for _, reply := range comments.Comments {
 		replies += printReplies(reply, config, commentSectionScreenWidth, comments.User, "", firstCommentID,
-			lastVisited)
+			firstNewCommentID, lastVisited)
 	}
 
 	commentSection := postprocessor.Process(header+replies+newLine, screenWidth)
@@ -48,6 +50,48 @@ func Print(comments *item.Item, config *settings.Config, screenWidth int, lastVi
 	return commentSection
 }
 
+// PrintHeader returns the headline and the info box at the top of the comment
+// section of story.
+func PrintHeader(story *item.Item, config *settings.Config, screenWidth int, lastVisited int64) string {
+	return postprocessor.Process(getHeader(story, config, lastVisited), screenWidth)
+}
+
+// PrintComment returns c indented for its level, without its replies and
+// without the markers that less needs for collapsing. parentPoster is the
+// author of the top-level comment of the thread, or empty for top-level
+// comments.
+func PrintComment(c *item.Item, config *settings.Config, screenWidth int, originalPoster string,
+	parentPoster string, lastVisited int64,
+) string {
+	return printComment(c, config, screenWidth-margins.CommentSectionLeftMargin, originalPoster, parentPoster,
+		lastVisited)
+}
+
+// Separator returns the line that is shown above each top-level comment
+// except the first.
+func Separator(commentWidth int) string {
+	return Faint(strings.Repeat("▁", commentWidth)).String()
+}
+
+// NewCommentsDivider returns the line that is shown above the first comment
+// that was posted since the last visit.
+func NewCommentsDivider(level int, commentWidth int) string {
+	label := "── new since your last visit "
+	line := label
+
+	if width := commentWidth - level - text.Len(label); width > 0 {
+		line += strings.Repeat("─", width)
+	}
+
+	return getIndentString(level) + theme.Current().Content.Cyan.Render(line)
+}
+
+// NewCommentsCount returns the number of replies below c that were posted
+// after lastVisited.
+func NewCommentsCount(c *item.Item, lastVisited int64) int {
+	return getNewCommentsCount(c, lastVisited)
+}
+
 func getFirstCommentID(comments []*item.Item) int {
 	if len(comments) == 0 {
 		return 0
@@ -56,6 +100,26 @@ func getFirstCommentID(comments []*item.Item) int {
 	return comments[0].ID
 }
 
+// getFirstNewCommentID returns the ID of the first comment that is printed
+// and was posted after lastVisited, or 0 if there is none.
+func getFirstNewCommentID(comments []*item.Item, lastVisited int64) int {
+	for _, c := range comments {
+		if c.Content == "[deleted]" && len(c.Comments) == 0 {
+			continue
+		}
+
+		if lastVisited < c.Time {
+			return c.ID
+		}
+
+		if id := getFirstNewCommentID(c.Comments, lastVisited); id != 0 {
+			return id
+		}
+	}
+
+	return 0
+}
+
 func getHeader(c *item.Item, config *settings.Config, lastVisited int64) string {
 	newComments := getNewCommentsCount(c, lastVisited)
 
@@ -63,22 +127,21 @@ func getHeader(c *item.Item, config *settings.Config, lastVisited int64) string
 }
 
 func printReplies(c *item.Item, config *settings.Config, screenWidth int, originalPoster string,
-	parentPoster string, firstCommentID int, lastVisited int64,
+	parentPoster string, firstCommentID int, firstNewCommentID int, lastVisited int64,
 ) string {
 	isDeletedAndHasNoReplies := c.Content == "[deleted]" && len(c.Comments) == 0
 	if isDeletedAndHasNoReplies {
 		return ""
 	}
 
-	indentation := getIndentString(c.Level)
-	indentSize := len(indentation)
-	availableScreenWidth := screenWidth - indentSize - margins.CommentSectionLeftMargin
-	adjustedCommentWidth := config.CommentWidth - c.Level
+	indentedComment := printComment(c, config, screenWidth, originalPoster, parentPoster, lastVisited)
+	fullComment := getSeparator(c.Level, config.CommentWidth, c.ID, firstCommentID)
 
-	comment := formatComment(c, config, originalPoster, parentPoster, adjustedCommentWidth, availableScreenWidth,
-		lastVisited)
-	indentedComment, _ := text.WrapWithPad(comment, screenWidth, indentation)
-	fullComment := getSeparator(c.Level, config.CommentWidth, c.ID, firstCommentID) + indentedComment + newLine
+	if c.ID == firstNewCommentID {
+		fullComment += NewCommentsDivider(c.Level, config.CommentWidth) + newLine
+	}
+
+	fullComment += indentedComment + newLine
 	fullComment += getButton(c.Level, getReplyCount(c), config.CommentWidth, config.EnableNerdFonts)
 
 	fullCommentWithFilterTag := addFilterTag(c.Level, fullComment)
@@ -89,12 +152,27 @@ func printReplies(c *item.Item, config *settings.Config, screenWidth int, origin
 
 	for _, reply := range c.Comments {
 		fullCommentWithFilterTag += printReplies(reply, config, screenWidth, originalPoster, parentPoster, firstCommentID,
-			lastVisited)
+			firstNewCommentID, lastVisited)
 	}
 
 	return fullCommentWithFilterTag
 }
 
+func printComment(c *item.Item, config *settings.Config, screenWidth int, originalPoster string,
+	parentPoster string, lastVisited int64,
+) string {
+	indentation := getIndentString(c.Level)
+	indentSize := len(indentation)
+	availableScreenWidth := screenWidth - indentSize - margins.CommentSectionLeftMargin
+	adjustedCommentWidth := config.CommentWidth - c.Level
+
+	comment := formatComment(c, config, originalPoster, parentPoster, adjustedCommentWidth, availableScreenWidth,
+		lastVisited)
+	indentedComment, _ := text.WrapWithPad(comment, screenWidth, indentation)
+
+	return indentedComment
+}
+
 func getButton(level int, replyCount int, commentWidth int, enableNerdFonts bool) string {
 	if replyCount == 0 || level != 0 {
 		return ""
@@ -171,6 +249,10 @@ func addFilterTag(level int, fullComment string) string {
 func formatComment(c *item.Item, config *settings.Config, originalPoster string, parentPoster string, commentWidth int,
 	availableScreenWidth int, lastVisited int64,
 ) string {
+	if c.Muted {
+		return formatMutedComment(c)
+	}
+
 	coloredIndentSymbol := syntax.ColorizeIndentSymbol(config.IndentationSymbol, c.Level)
 
 	header := getCommentHeader(c, originalPoster, parentPoster, lastVisited, config)
@@ -181,6 +263,19 @@ func formatComment(c *item.Item, config *settings.Config, originalPoster string,
 	return header + paddedComment
 }
 
+// formatMutedComment returns the line that stands in for a comment by a user
+// in the killfile. Like the header of other comments, it keeps the marker for
+// jumping between top-level comments in less. Unlike the built-in viewer, less
+// cannot show the comment, so only the line can be searched for.
+func formatMutedComment(c *item.Item) string {
+	indentation := " "
+	if c.Level == 0 {
+		indentation = ""
+	}
+
+	return getZeroWidthSpace(c.Level == 0) + indentation + Faint("▸ muted comment by "+c.User).String()
+}
+
 func getSeparator(level int, commentWidth int, currentCommentID int, firstCommentID int) string {
 	if currentCommentID == firstCommentID {
 		return ""
@@ -190,7 +285,7 @@ func getSeparator(level int, commentWidth int, currentCommentID int, firstCommen
 		return newLine
 	}
 
-	return Faint(strings.Repeat("▁", commentWidth)).Faint().String() + newLine + newLine
+	return Separator(commentWidth) + newLine + newLine
 }
 
 func getIndentString(level int) string {
@@ -233,7 +328,7 @@ func getAuthor(author string, lastVisited, timePosted int64) string {
 	commentIsNew := lastVisited < timePosted
 
 	if commentIsNew {
-		return authorInBold + Cyan("●").String() + " "
+		return authorInBold + theme.Current().Content.Cyan.Render("●") + " "
 	}
 
 	return authorInBold
@@ -276,7 +371,7 @@ func getNewCommentsTag(newCommentsCount int, numberOfReplies int) string {
 		return ""
 	}
 
-	return Faint(" (").String() + Faint(strconv.Itoa(newCommentsCount)).Cyan().String() + Faint(")").String()
+	return Faint(" (").String() + theme.Current().Content.Cyan.Render(strconv.Itoa(newCommentsCount), theme.Faint) + Faint(")").String()
 }
 
 func getZeroWidthSpace(enabled bool) string {
@@ -322,18 +417,20 @@ func incrementNewCommentsCount(comments *item.Item, newCommentsSoFar *int, lastV
 }
 
 func getAuthorLabel(author, originalPoster, parentPoster string, enableNerdFonts bool) string {
+	content := theme.Current().Content
+
 	if enableNerdFonts {
 		authorLabel := nerdfonts.Author + " "
 
 		switch author {
 		case "dang":
-			return Green(authorLabel).String()
+			return content.Green.Render(authorLabel)
 
 		case originalPoster:
-			return Red(authorLabel).String()
+			return content.Red.Render(authorLabel)
 
 		case parentPoster:
-			return Magenta(authorLabel).String()
+			return content.Magenta.Render(authorLabel)
 
 		default:
 			return ""
@@ -342,13 +439,13 @@ func getAuthorLabel(author, originalPoster, parentPoster string, enableNerdFonts
 
 	switch author {
 	case "dang":
-		return Green("mod ").String()
+		return content.Green.Render("mod ")
 
 	case originalPoster:
-		return Red("OP ").String()
+		return content.Red.Render("OP ")
 
 	case parentPoster:
-		return Magenta("PP ").String()
+		return content.Magenta.Render("PP ")
 
 	default:
 		return ""
@@ -0,0 +1,89 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"clx/app"
+	"clx/hn"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// MaxConnsPerHost is the number of connections that are kept open to a single
+// host. Requests beyond that wait for a connection to become free.
+const MaxConnsPerHost = 16
+
+// client is shared by all requests so that connections to the same host are
+// reused instead of being opened for every request
+var client = resty.NewWithClient(&http.Client{Transport: newTransport()}).
+	SetHeader("User-Agent", app.Name+"/"+app.Version)
+
+func newTransport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxConnsPerHost = MaxConnsPerHost
+	transport.MaxIdleConnsPerHost = MaxConnsPerHost
+
+	return transport
+}
+
+// Get fetches url and decodes the JSON response into result. Failed requests
+// are returned as *hn.Error.
+func Get(ctx context.Context, url string, timeout time.Duration, result interface{}) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	resp, err := client.R().
+		SetContext(ctx).
+		SetResult(result).
+		Get(url)
+
+	return classify(url, resp, err)
+}
+
+func classify(url string, resp *resty.Response, err error) error {
+	if err != nil {
+		return &hn.Error{Kind: ErrorKind(err), URL: url, Err: err}
+	}
+
+	switch code := resp.StatusCode(); {
+	case code == http.StatusNotFound:
+		return &hn.Error{Kind: hn.ErrNotFound, URL: url}
+
+	case code == http.StatusTooManyRequests:
+		return &hn.Error{Kind: hn.ErrRateLimited, URL: url}
+
+	case code >= http.StatusBadRequest:
+		return &hn.Error{Kind: hn.ErrUnavailable, URL: url, Err: errors.New(resp.Status())}
+	}
+
+	return nil
+}
+
+// ErrorKind returns the hn sentinel error that best describes err, which was
+// returned by an HTTP client while sending a request or reading a response.
+func ErrorKind(err error) error {
+	var (
+		syntaxErr *json.SyntaxError
+		typeErr   *json.UnmarshalTypeError
+		netErr    net.Error
+	)
+
+	switch {
+	case errors.As(err, &syntaxErr), errors.As(err, &typeErr):
+		return hn.ErrDecode
+
+	case errors.Is(err, context.DeadlineExceeded):
+		return hn.ErrTimeout
+
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return hn.ErrTimeout
+
+	default:
+		return hn.ErrUnavailable
+	}
+}
@@ -0,0 +1,26 @@
+// Package ints has the helpers for ints that the standard library of Go 1.19
+// lacks.
+package ints
+
+// Min returns the smaller of a and b.
+func Min(a, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
+
+// Max returns the larger of a and b.
+func Max(a, b int) int {
+	if a > b {
+		return a
+	}
+
+	return b
+}
+
+// Clamp returns n limited to the range from low to high.
+func Clamp(n, low, high int) int {
+	return Min(Max(n, low), high)
+}
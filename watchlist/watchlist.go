@@ -0,0 +1,298 @@
+// Package watchlist reads the user-defined rules that highlight keywords and
+// patterns in headlines and comments.
+//
+// The watchlist has one rule per line:
+//
+//	keyword [style] [label]: text
+//	regex [style] [label]: regular expression
+//
+// Keywords match whole words regardless of case. The style is a color (red,
+// green, yellow, blue, magenta or cyan) that can be combined with bold,
+// italic and underline, for example bold+green. Stories with a matching
+// headline are marked with the label in the list. Empty lines and lines
+// starting with '#' are ignored.
+package watchlist
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	KindKeyword = "keyword"
+	KindRegex   = "regex"
+
+	reset = "\033[0m"
+)
+
+// Colors maps the color names in the watchlist to ANSI colors.
+var Colors = map[string]int{
+	"red":     1,
+	"green":   2,
+	"yellow":  3,
+	"blue":    4,
+	"magenta": 5,
+	"cyan":    6,
+}
+
+var escapeSequence = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+type Rule struct {
+	Pattern *regexp.Regexp
+	Label   string
+
+	// Color is an ANSI color from 1 (red) to 6 (cyan)
+	Color     int
+	Bold      bool
+	Italic    bool
+	Underline bool
+}
+
+// Watchlist holds the rules in the order they appear in the file. When the
+// matches of two rules overlap, the rule that comes first wins.
+type Watchlist []*Rule
+
+// Load reads the watchlist at path. A watchlist that does not exist is
+// empty.
+func Load(path string) (Watchlist, error) {
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("could not read watchlist: %w", err)
+	}
+
+	return Parse(string(content))
+}
+
+// Parse reads the rules in content.
+func Parse(content string) (Watchlist, error) {
+	var w Watchlist
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		rule, err := parseRule(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+
+		w = append(w, rule)
+	}
+
+	return w, nil
+}
+
+func parseRule(line string) (*Rule, error) {
+	definition, value, found := strings.Cut(line, ":")
+	value = strings.TrimSpace(value)
+
+	if !found || value == "" {
+		return nil, fmt.Errorf("expected 'keyword' or 'regex' followed by ':' and a value")
+	}
+
+	fields := strings.Fields(definition)
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("expected 'keyword' or 'regex' before ':'")
+	}
+
+	rule := &Rule{Color: Colors["yellow"]}
+
+	switch fields[0] {
+	case KindKeyword:
+		rule.Pattern = regexp.MustCompile(keywordPattern(value))
+
+	case KindRegex:
+		pattern, err := regexp.Compile(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regex: %w", err)
+		}
+
+		rule.Pattern = pattern
+
+	default:
+		return nil, fmt.Errorf("unknown rule %q, expected keyword or regex", fields[0])
+	}
+
+	labelFields := fields[1:]
+	if len(labelFields) != 0 && rule.setStyle(labelFields[0]) {
+		labelFields = labelFields[1:]
+	}
+
+	rule.Label = strings.Join(labelFields, " ")
+
+	return rule, nil
+}
+
+// keywordPattern matches keyword as a whole word, ignoring case. Keywords
+// that start or end with a symbol, such as C++, can not be followed or
+// preceded by a word boundary there.
+func keywordPattern(keyword string) string {
+	pattern := regexp.QuoteMeta(keyword)
+
+	if first, _ := utf8.DecodeRuneInString(keyword); isWordCharacter(first) {
+		pattern = `\b` + pattern
+	}
+
+	if last, _ := utf8.DecodeLastRuneInString(keyword); isWordCharacter(last) {
+		pattern += `\b`
+	}
+
+	return "(?i)" + pattern
+}
+
+func isWordCharacter(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// setStyle sets the style of the rule if s is a valid style and reports
+// whether it was.
+func (r *Rule) setStyle(s string) bool {
+	styled := *r
+
+	for _, attribute := range strings.Split(s, "+") {
+		switch attribute {
+		case "bold":
+			styled.Bold = true
+
+		case "italic":
+			styled.Italic = true
+
+		case "underline":
+			styled.Underline = true
+
+		default:
+			color, ok := Colors[attribute]
+			if !ok {
+				return false
+			}
+
+			styled.Color = color
+		}
+	}
+
+	*r = styled
+
+	return true
+}
+
+// Sequence returns the escape sequence that starts the style of the rule.
+func (r *Rule) Sequence() string {
+	codes := []string{"3" + strconv.Itoa(r.Color)}
+
+	if r.Bold {
+		codes = append(codes, "1")
+	}
+
+	if r.Italic {
+		codes = append(codes, "3")
+	}
+
+	if r.Underline {
+		codes = append(codes, "4")
+	}
+
+	return "\033[" + strings.Join(codes, ";") + "m"
+}
+
+// Matching returns the rules that match s.
+func (w Watchlist) Matching(s string) Watchlist {
+	var matching Watchlist
+
+	for _, rule := range w {
+		if rule.Pattern.MatchString(s) {
+			matching = append(matching, rule)
+		}
+	}
+
+	return matching
+}
+
+// Highlight styles the matches in s. Escape sequences that are already in s
+// are left alone, and restore is written after each match to continue the
+// style of the surrounding text.
+func (w Watchlist) Highlight(s string, restore string) string {
+	if len(w) == 0 {
+		return s
+	}
+
+	var sb strings.Builder
+
+	last := 0
+
+	for _, loc := range escapeSequence.FindAllStringIndex(s, -1) {
+		sb.WriteString(w.highlightText(s[last:loc[0]], restore))
+		sb.WriteString(s[loc[0]:loc[1]])
+
+		last = loc[1]
+	}
+
+	sb.WriteString(w.highlightText(s[last:], restore))
+
+	return sb.String()
+}
+
+type match struct {
+	start, end int
+	rule       *Rule
+}
+
+// highlightText styles the matches in s, which has no escape sequences.
+func (w Watchlist) highlightText(s string, restore string) string {
+	var matches []match
+
+	for _, rule := range w {
+		for _, loc := range rule.Pattern.FindAllStringIndex(s, -1) {
+			if loc[0] == loc[1] || overlaps(matches, loc[0], loc[1]) {
+				continue
+			}
+
+			matches = append(matches, match{start: loc[0], end: loc[1], rule: rule})
+		}
+	}
+
+	if len(matches) == 0 {
+		return s
+	}
+
+	sort.Slice(matches, func(i, j int) bool { return matches[i].start < matches[j].start })
+
+	var sb strings.Builder
+
+	last := 0
+
+	for _, m := range matches {
+		sb.WriteString(s[last:m.start])
+		sb.WriteString(m.rule.Sequence() + s[m.start:m.end] + reset + restore)
+
+		last = m.end
+	}
+
+	sb.WriteString(s[last:])
+
+	return sb.String()
+}
+
+func overlaps(matches []match, start int, end int) bool {
+	for _, m := range matches {
+		if start < m.end && m.start < end {
+			return true
+		}
+	}
+
+	return false
+}
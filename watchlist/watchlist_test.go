@@ -0,0 +1,98 @@
+package watchlist_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"clx/watchlist"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	w, err := watchlist.Parse(`# Our product
+keyword bold+green ours: circumflex
+
+keyword red competitor x: Show HN: hnterm
+regex: (?i)\bbubble ?tea\b
+keyword: C++
+`)
+	require.NoError(t, err)
+	require.Len(t, w, 4)
+
+	assert.Equal(t, "ours", w[0].Label)
+	assert.Equal(t, watchlist.Colors["green"], w[0].Color)
+	assert.True(t, w[0].Bold)
+	assert.Equal(t, "\033[32;1m", w[0].Sequence())
+
+	assert.Equal(t, "competitor x", w[1].Label)
+	assert.True(t, w[1].Pattern.MatchString("show hn: HNterm"))
+
+	assert.Empty(t, w[2].Label)
+	assert.Equal(t, watchlist.Colors["yellow"], w[2].Color)
+
+	assert.True(t, w[3].Pattern.MatchString("Modern c++ in 2023"))
+}
+
+func TestParseInvalid(t *testing.T) {
+	t.Parallel()
+
+	for _, content := range []string{"circumflex", "word: circumflex", "regex: (unclosed", "keyword:", ": circumflex"} {
+		_, err := watchlist.Parse(content)
+		assert.Error(t, err, content)
+	}
+
+	_, err := watchlist.Parse("keyword: a\n\nregex: (")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "line 3")
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	t.Parallel()
+
+	w, err := watchlist.Load(filepath.Join(t.TempDir(), "watchlist"))
+	require.NoError(t, err)
+	assert.Empty(t, w)
+
+	path := filepath.Join(t.TempDir(), "watchlist")
+	require.NoError(t, os.WriteFile(path, []byte("keyword: go"), 0o600))
+
+	w, err = watchlist.Load(path)
+	require.NoError(t, err)
+	assert.Len(t, w, 1)
+}
+
+func TestMatching(t *testing.T) {
+	t.Parallel()
+
+	w, err := watchlist.Parse("keyword: go\nregex: Rust|Zig\nkeyword: java")
+	require.NoError(t, err)
+
+	matching := w.Matching("Go and Zig are not Google")
+	require.Len(t, matching, 2)
+	assert.Equal(t, w[0], matching[0])
+	assert.Equal(t, w[1], matching[1])
+
+	assert.Empty(t, w.Matching("Google and Javascript"))
+}
+
+func TestHighlight(t *testing.T) {
+	t.Parallel()
+
+	w, err := watchlist.Parse("keyword red: go\nregex blue: go[a-z]+\nkeyword green: 31m")
+	require.NoError(t, err)
+
+	const reverse = "\033[7m"
+
+	// Existing escape sequences are not matched and the first rule wins when
+	// matches overlap
+	assert.Equal(t, reverse+"Why \033[31mGo\033[0m"+reverse+" and \033[34mgoogle\033[0m"+reverse+" 31 m\033[0m",
+		w.Highlight(reverse+"Why Go and google 31 m\033[0m", reverse))
+
+	assert.Equal(t, "Nothing to see", w.Highlight("Nothing to see", ""))
+	assert.Equal(t, "go", watchlist.Watchlist(nil).Highlight("go", ""))
+}